@@ -98,6 +98,42 @@ func (service *Admin) GetBlockchainID(r *http.Request, args *GetBlockchainIDArgs
 	return err
 }
 
+// GetBlockchainsArgs are the arguments for calling GetBlockchains
+type GetBlockchainsArgs struct{}
+
+// APIBlockchain is a blockchain this node has been asked to run
+type APIBlockchain struct {
+	ID       ids.ID `json:"id"`
+	SubnetID ids.ID `json:"subnetID"`
+	VMID     string `json:"vmID"`
+	// False if this node doesn't run the blockchain because its subnet isn't
+	// whitelisted
+	Tracked bool `json:"tracked"`
+}
+
+// GetBlockchainsReply are the results from calling GetBlockchains
+type GetBlockchainsReply struct {
+	Blockchains []APIBlockchain `json:"blockchains"`
+}
+
+// GetBlockchains returns the blockchains this node has been asked to run,
+// including the ones it ignores
+func (service *Admin) GetBlockchains(r *http.Request, args *GetBlockchainsArgs, reply *GetBlockchainsReply) error {
+	service.log.Debug("Admin: GetBlockchains called")
+
+	chains := service.chainManager.Chains()
+	reply.Blockchains = make([]APIBlockchain, len(chains))
+	for i, chain := range chains {
+		reply.Blockchains[i] = APIBlockchain{
+			ID:       chain.ID,
+			SubnetID: chain.SubnetID,
+			VMID:     chain.VMAlias,
+			Tracked:  !chain.Ignored,
+		}
+	}
+	return nil
+}
+
 // PeersArgs are the arguments for calling Peers
 type PeersArgs struct{}
 
@@ -17,12 +17,12 @@ type BlockchainSharedMemory struct {
 
 // GetDatabase returns and locks the provided DB
 func (bsm *BlockchainSharedMemory) GetDatabase(id ids.ID) database.Database {
-	sharedID := bsm.sm.sharedID(id, bsm.blockchainID)
+	sharedID := bsm.sm.SharedID(id, bsm.blockchainID)
 	return bsm.sm.GetDatabase(sharedID)
 }
 
 // ReleaseDatabase unlocks the provided DB
 func (bsm *BlockchainSharedMemory) ReleaseDatabase(id ids.ID) {
-	sharedID := bsm.sm.sharedID(id, bsm.blockchainID)
+	sharedID := bsm.sm.SharedID(id, bsm.blockchainID)
 	bsm.sm.ReleaseDatabase(sharedID)
 }
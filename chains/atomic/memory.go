@@ -89,8 +89,9 @@ func (sm *SharedMemory) releaseLock(id ids.ID) *sync.Mutex {
 	return &rc.lock
 }
 
-// sharedID calculates the ID of the shared memory space
-func (sm *SharedMemory) sharedID(id1, id2 ids.ID) ids.ID {
+// SharedID calculates the ID of the memory space shared by the chains with IDs
+// [id1] and [id2]
+func (sm *SharedMemory) SharedID(id1, id2 ids.ID) ids.ID {
 	idKey1 := id1.Key()
 	idKey2 := id2.Key()
 
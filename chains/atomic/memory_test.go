@@ -20,8 +20,8 @@ func TestSharedMemorySharedID(t *testing.T) {
 	sm := SharedMemory{}
 	sm.Initialize(logging.NoLog{}, memdb.New())
 
-	sharedID0 := sm.sharedID(blockchainID0, blockchainID1)
-	sharedID1 := sm.sharedID(blockchainID1, blockchainID0)
+	sharedID0 := sm.SharedID(blockchainID0, blockchainID1)
+	sharedID1 := sm.SharedID(blockchainID1, blockchainID0)
 
 	if !sharedID0.Equals(sharedID1) {
 		t.Fatalf("SharedMemory.sharedID should be communitive")
@@ -32,7 +32,7 @@ func TestSharedMemoryMakeReleaseLock(t *testing.T) {
 	sm := SharedMemory{}
 	sm.Initialize(logging.NoLog{}, memdb.New())
 
-	sharedID := sm.sharedID(blockchainID0, blockchainID1)
+	sharedID := sm.SharedID(blockchainID0, blockchainID1)
 
 	lock0 := sm.makeLock(sharedID)
 
@@ -57,7 +57,7 @@ func TestSharedMemoryUnknownFree(t *testing.T) {
 	sm := SharedMemory{}
 	sm.Initialize(logging.NoLog{}, memdb.New())
 
-	sharedID := sm.sharedID(blockchainID0, blockchainID1)
+	sharedID := sm.SharedID(blockchainID0, blockchainID1)
 
 	defer func() {
 		if recover() == nil {
@@ -21,7 +21,7 @@ func TestWriteAll(t *testing.T) {
 	sm := SharedMemory{}
 	sm.Initialize(logging.NoLog{}, prefixedDBSharedMemory)
 
-	sharedID := sm.sharedID(blockchainID0, blockchainID1)
+	sharedID := sm.SharedID(blockchainID0, blockchainID1)
 
 	sharedDB := sm.GetDatabase(sharedID)
 
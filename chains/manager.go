@@ -4,13 +4,19 @@
 package chains
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ava-labs/gecko/api"
 	"github.com/ava-labs/gecko/api/keystore"
 	"github.com/ava-labs/gecko/chains/atomic"
 	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/compressdb"
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/meterdb"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/database/prefixdb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
@@ -43,6 +49,14 @@ const (
 	gossipFrequency    = 10 * time.Second
 )
 
+var (
+	// ErrUnknownVM is returned by ValidateGenesis if the VM isn't registered
+	// on this node
+	ErrUnknownVM = errors.New("vm isn't registered on this node")
+
+	errUnknownVMType = errors.New("the vm should have type avalanche.DAGVM or snowman.ChainVM")
+)
+
 // Manager manages the chains running on this node.
 // It can:
 //   * Create a chain
@@ -70,6 +84,21 @@ type Manager interface {
 	// Given an alias, return the ID of the VM associated with that alias
 	LookupVM(string) (ids.ID, error)
 
+	// Returns nil if a chain running the VM [vmID] with feature extensions
+	// [fxIDs] could be started from [genesisData]. The VM is initialized
+	// against a throwaway database and shut down again; the node's state
+	// isn't modified. Returns ErrUnknownVM if the VM or one of the feature
+	// extensions isn't registered on this node.
+	ValidateGenesis(vmID ids.ID, fxIDs []ids.ID, genesisData []byte) error
+
+	// Returns the chains that this node has been asked to create, including
+	// the ones it ignored because their subnets aren't whitelisted
+	Chains() []ChainInfo
+
+	// Returns true iff the chain with ID [chainID] wasn't created because its
+	// subnet isn't whitelisted
+	IsIgnored(chainID ids.ID) bool
+
 	// Return the aliases associated with a chain
 	Aliases(ids.ID) []string
 
@@ -90,6 +119,14 @@ type ChainParameters struct {
 	CustomBeacons validators.Set // Should only be set if the default beacons can't be used.
 }
 
+// ChainInfo describes a chain that this node has been asked to create
+type ChainInfo struct {
+	ChainParameters
+
+	// True iff the chain wasn't created because its subnet isn't whitelisted
+	Ignored bool
+}
+
 type manager struct {
 	// Note: The string representation of a chain's ID is also considered to be an alias of the chain
 	// That is, [chainID].String() is an alias for the chain, too
@@ -115,17 +152,33 @@ type manager struct {
 	keystore        *keystore.Keystore
 	sharedMemory    *atomic.SharedMemory
 
+	// Chains on these subnets, and on the default subnet, are created.
+	// Other chains are ignored.
+	whitelistedSubnets ids.Set
+
+	// IDs or aliases of the chains whose new values are compressed
+	compressedChains []string
+
 	unblocked     bool
 	blockedChains []ChainParameters
+
+	// Protects [chains]
+	chainsLock sync.Mutex
+	// The chains this node has been asked to create, in order
+	chains []ChainInfo
 }
 
 // New returns a new Manager where:
 //     <db> is this node's database
 //     <sender> sends messages to other validators
 //     <validators> validate this chain
+//     <whitelistedSubnets> are the non-default subnets whose chains are created
+//     <compressedChains> are the IDs or aliases of chains whose new values are compressed
 // TODO: Make this function take less arguments
 func New(
 	stakingEnabled bool,
+	whitelistedSubnets ids.Set,
+	compressedChains []string,
 	log logging.Logger,
 	logFactory logging.Factory,
 	vmManager vms.Manager,
@@ -150,24 +203,26 @@ func New(
 	router.Initialize(log, &timeoutManager, gossipFrequency)
 
 	m := &manager{
-		stakingEnabled:  stakingEnabled,
-		log:             log,
-		logFactory:      logFactory,
-		vmManager:       vmManager,
-		decisionEvents:  decisionEvents,
-		consensusEvents: consensusEvents,
-		db:              db,
-		chainRouter:     router,
-		sender:          sender,
-		timeoutManager:  &timeoutManager,
-		consensusParams: consensusParams,
-		validators:      validators,
-		nodeID:          nodeID,
-		networkID:       networkID,
-		awaiter:         awaiter,
-		server:          server,
-		keystore:        keystore,
-		sharedMemory:    sharedMemory,
+		stakingEnabled:     stakingEnabled,
+		whitelistedSubnets: whitelistedSubnets,
+		compressedChains:   compressedChains,
+		log:                log,
+		logFactory:         logFactory,
+		vmManager:          vmManager,
+		decisionEvents:     decisionEvents,
+		consensusEvents:    consensusEvents,
+		db:                 db,
+		chainRouter:        router,
+		sender:             sender,
+		timeoutManager:     &timeoutManager,
+		consensusParams:    consensusParams,
+		validators:         validators,
+		nodeID:             nodeID,
+		networkID:          networkID,
+		awaiter:            awaiter,
+		server:             server,
+		keystore:           keystore,
+		sharedMemory:       sharedMemory,
 	}
 	m.Initialize()
 	return m
@@ -178,6 +233,9 @@ func (m *manager) Router() router.Router { return m.chainRouter }
 
 // Create a chain
 func (m *manager) CreateChain(chain ChainParameters) {
+	if m.ignore(chain) {
+		return
+	}
 	if !m.unblocked {
 		m.blockedChains = append(m.blockedChains, chain)
 	} else {
@@ -187,6 +245,10 @@ func (m *manager) CreateChain(chain ChainParameters) {
 
 // Create a chain
 func (m *manager) ForceCreateChain(chain ChainParameters) {
+	if m.ignore(chain) {
+		return
+	}
+
 	m.log.Info("creating chain:\n"+
 		"    ID: %s\n"+
 		"    VMID:%s",
@@ -324,7 +386,7 @@ func (m *manager) ForceCreateChain(chain ChainParameters) {
 			return
 		}
 	default:
-		m.log.Error("the vm should have type avalanche.DAGVM or snowman.ChainVM. Chain not created")
+		m.log.Error("%s. Chain not created", errUnknownVMType)
 		return
 	}
 
@@ -335,6 +397,54 @@ func (m *manager) ForceCreateChain(chain ChainParameters) {
 	m.notifyRegistrants(ctx, vm)
 }
 
+// ignore returns true if [chain] shouldn't be created because its subnet
+// isn't whitelisted. Otherwise, [chain] is recorded as being created.
+func (m *manager) ignore(chain ChainParameters) bool {
+	// ids.Empty is the default subnet ID
+	ignored := !chain.SubnetID.Equals(ids.Empty) && !m.whitelistedSubnets.Contains(chain.SubnetID)
+
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	for _, info := range m.chains {
+		if info.ID.Equals(chain.ID) {
+			return ignored
+		}
+	}
+	m.chains = append(m.chains, ChainInfo{
+		ChainParameters: chain,
+		Ignored:         ignored,
+	})
+	if ignored {
+		m.log.Info("not creating chain %s because its subnet, %s, isn't whitelisted", chain.ID, chain.SubnetID)
+	}
+	return ignored
+}
+
+// Chains returns the chains this node has been asked to create
+func (m *manager) Chains() []ChainInfo {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	chains := make([]ChainInfo, len(m.chains))
+	copy(chains, m.chains)
+	return chains
+}
+
+// IsIgnored returns true iff the chain with ID [chainID] wasn't created because
+// its subnet isn't whitelisted
+func (m *manager) IsIgnored(chainID ids.ID) bool {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	for _, info := range m.chains {
+		if info.ID.Equals(chainID) {
+			return info.Ignored
+		}
+	}
+	return false
+}
+
 // Implements Manager.AddRegistrant
 func (m *manager) AddRegistrant(r Registrant) { m.registrants = append(m.registrants, r) }
 
@@ -347,6 +457,61 @@ func (m *manager) unblockChains() {
 	}
 }
 
+// isCompressed returns true iff the chain with ID [chainID] was configured to
+// compress its values, by its ID or by one of its aliases
+func (m *manager) isCompressed(chainID ids.ID) bool {
+	aliases := m.Aliases(chainID)
+	for _, compressedChain := range m.compressedChains {
+		// The chain's ID isn't registered as an alias until the chain is created
+		if compressedChain == chainID.String() {
+			return true
+		}
+		for _, alias := range aliases {
+			if compressedChain == alias {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newChainDB returns the database of a chain's [name] subsystem, where [db] is
+// the chain's database. Its use is recorded in metrics registered under the
+// chain's namespace. Values that were compressed are always decompressed, so
+// that a chain can stop compressing its values. If [compress], new values are
+// compressed before being written to [db].
+func newChainDB(params snowball.Parameters, name string, db database.Database, compress bool) (database.Database, error) {
+	namespace := fmt.Sprintf("%s_%s_db", params.Namespace, name)
+	meteredDB, err := meterdb.New(namespace, params.Metrics, prefixdb.New([]byte(name), db))
+	if err != nil {
+		return nil, err
+	}
+	threshold := compressdb.NoCompression
+	if compress {
+		threshold = compressdb.DefaultThreshold
+	}
+	return compressdb.New(threshold, meteredDB), nil
+}
+
+// migrate runs the pending migrations of [schema] on [db], the database of the
+// chain's [name] subsystem.
+func (m *manager) migrate(chainID ids.ID, name string, schema *migration.Schema, db database.Database) error {
+	migrations, err := schema.Migrate(db)
+	if err != nil {
+		return err
+	}
+	for _, completedMigration := range migrations {
+		m.log.Info("ran migration %d of schema %s on chain %s's %s database: %s",
+			completedMigration.Version,
+			schema.Name(),
+			chainID,
+			name,
+			completedMigration.Description,
+		)
+	}
+	return nil
+}
+
 // Create a DAG-based blockchain that uses Avalanche
 func (m *manager) createAvalancheChain(
 	ctx *snow.Context,
@@ -361,16 +526,35 @@ func (m *manager) createAvalancheChain(
 	defer ctx.Lock.Unlock()
 
 	db := prefixdb.New(ctx.ChainID.Bytes(), m.db)
-	vmDB := prefixdb.New([]byte("vm"), db)
-	vertexDB := prefixdb.New([]byte("vertex"), db)
-	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bootstrapping"), db)
-	txBootstrappingDB := prefixdb.New([]byte("tx_bootstrapping"), db)
+	compress := m.isCompressed(ctx.ChainID)
+	vmDB, err := newChainDB(consensusParams.Parameters, "vm", db, compress)
+	if err != nil {
+		return err
+	}
+	vertexDB, err := newChainDB(consensusParams.Parameters, "vertex", db, compress)
+	if err != nil {
+		return err
+	}
+	vertexBootstrappingDB, err := newChainDB(consensusParams.Parameters, "vertex_bootstrapping", db, compress)
+	if err != nil {
+		return err
+	}
+	txBootstrappingDB, err := newChainDB(consensusParams.Parameters, "tx_bootstrapping", db, compress)
+	if err != nil {
+		return err
+	}
 
-	vtxBlocker, err := queue.New(vertexBootstrappingDB)
+	if versioned, ok := vm.(migration.Versioned); ok {
+		if err := m.migrate(ctx.ChainID, "vm", versioned.Schema(), vmDB); err != nil {
+			return err
+		}
+	}
+
+	vtxBlocker, err := queue.New(vertexBootstrappingDB, queueSchema)
 	if err != nil {
 		return err
 	}
-	txBlocker, err := queue.New(txBootstrappingDB)
+	txBlocker, err := queue.New(txBootstrappingDB, queueSchema)
 	if err != nil {
 		return err
 	}
@@ -467,10 +651,23 @@ func (m *manager) createSnowmanChain(
 	defer ctx.Lock.Unlock()
 
 	db := prefixdb.New(ctx.ChainID.Bytes(), m.db)
-	vmDB := prefixdb.New([]byte("vm"), db)
-	bootstrappingDB := prefixdb.New([]byte("bootstrapping"), db)
+	compress := m.isCompressed(ctx.ChainID)
+	vmDB, err := newChainDB(consensusParams, "vm", db, compress)
+	if err != nil {
+		return err
+	}
+	bootstrappingDB, err := newChainDB(consensusParams, "bootstrapping", db, compress)
+	if err != nil {
+		return err
+	}
+
+	if versioned, ok := vm.(migration.Versioned); ok {
+		if err := m.migrate(ctx.ChainID, "vm", versioned.Schema(), vmDB); err != nil {
+			return err
+		}
+	}
 
-	blocked, err := queue.New(bootstrappingDB)
+	blocked, err := queue.New(bootstrappingDB, queueSchema)
 	if err != nil {
 		return err
 	}
@@ -544,6 +741,70 @@ func (m *manager) Shutdown() { m.chainRouter.Shutdown() }
 // LookupVM returns the ID of the VM associated with an alias
 func (m *manager) LookupVM(alias string) (ids.ID, error) { return m.vmManager.Lookup(alias) }
 
+// ValidateGenesis does a dry run of initializing the VM [vmID] with
+// [genesisData]
+func (m *manager) ValidateGenesis(vmID ids.ID, fxIDs []ids.ID, genesisData []byte) error {
+	vmFactory, err := m.vmManager.GetVMFactory(vmID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUnknownVM, vmID)
+	}
+
+	fxs := make([]*common.Fx, len(fxIDs))
+	for i, fxID := range fxIDs {
+		fxFactory, err := m.vmManager.GetVMFactory(fxID)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrUnknownVM, fxID)
+		}
+		fx, err := fxFactory.New()
+		if err != nil {
+			return fmt.Errorf("error while creating fx %s: %w", fxID, err)
+		}
+		fxs[i] = &common.Fx{
+			ID: fxID,
+			Fx: fx,
+		}
+	}
+
+	vmIntf, err := vmFactory.New()
+	if err != nil {
+		return fmt.Errorf("error while creating vm %s: %w", vmID, err)
+	}
+	var vm common.VM
+	switch vmIntf := vmIntf.(type) {
+	case avalanche.DAGVM:
+		vm = vmIntf
+	case smeng.ChainVM:
+		vm = vmIntf
+	default:
+		return errUnknownVMType
+	}
+
+	// The dry run gets its own shared memory so that it can't touch the
+	// shared memory of the chains running on this node
+	sharedMemory := atomic.SharedMemory{}
+	sharedMemory.Initialize(logging.NoLog{}, memdb.New())
+
+	ctx := &snow.Context{
+		NetworkID:    m.networkID,
+		ChainID:      ids.Empty,
+		Log:          logging.NoLog{},
+		NodeID:       m.nodeID,
+		SharedMemory: sharedMemory.NewBlockchainSharedMemory(ids.Empty),
+		BCLookup:     m,
+	}
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	// The VM is shut down even if Initialize fails, as it may have started a
+	// plugin process or timers before failing
+	err = vm.Initialize(ctx, memdb.New(), genesisData, make(chan common.Message, defaultChannelSize), fxs)
+	vm.Shutdown()
+	if err != nil {
+		return fmt.Errorf("vm rejected the genesis data: %w", err)
+	}
+	return nil
+}
+
 // Notify registrants [those who want to know about the creation of chains]
 // that the specified chain has been created
 func (m *manager) notifyRegistrants(ctx *snow.Context, vm interface{}) {
@@ -0,0 +1,165 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/consensus/snowball"
+	"github.com/ava-labs/gecko/utils/logging"
+	"github.com/ava-labs/gecko/vms"
+	"github.com/ava-labs/gecko/vms/timestampvm"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	vmManager := vms.NewManager(nil, logging.NoLog{})
+	if err := vmManager.RegisterVMFactory(timestampvm.ID, &timestampvm.Factory{}); err != nil {
+		t.Fatal(err)
+	}
+	m := &manager{vmManager: vmManager}
+	m.Initialize()
+
+	if err := m.ValidateGenesis(timestampvm.ID, nil, []byte("genesis")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The timestamp VM's genesis data is at most 32 bytes
+	if err := m.ValidateGenesis(timestampvm.ID, nil, make([]byte, 33)); err == nil {
+		t.Fatal("should have failed because the genesis data is too long")
+	}
+
+	unknownVMID := ids.NewID([32]byte{1})
+	if err := m.ValidateGenesis(unknownVMID, nil, nil); !errors.Is(err, ErrUnknownVM) {
+		t.Fatalf("expected ErrUnknownVM but got %v", err)
+	}
+	if err := m.ValidateGenesis(timestampvm.ID, []ids.ID{unknownVMID}, nil); !errors.Is(err, ErrUnknownVM) {
+		t.Fatalf("expected ErrUnknownVM for the fx but got %v", err)
+	}
+}
+
+func TestWhitelistedSubnets(t *testing.T) {
+	whitelistedSubnetID := ids.NewID([32]byte{1})
+	otherSubnetID := ids.NewID([32]byte{2})
+
+	whitelistedSubnets := ids.Set{}
+	whitelistedSubnets.Add(whitelistedSubnetID)
+	m := &manager{
+		log:                logging.NoLog{},
+		whitelistedSubnets: whitelistedSubnets,
+	}
+
+	// Chain creation is blocked, so the tracked chains are only queued
+	defaultChain := ChainParameters{ID: ids.NewID([32]byte{3}), SubnetID: ids.Empty}
+	whitelistedChain := ChainParameters{ID: ids.NewID([32]byte{4}), SubnetID: whitelistedSubnetID}
+	otherChain := ChainParameters{ID: ids.NewID([32]byte{5}), SubnetID: otherSubnetID}
+	m.CreateChain(defaultChain)
+	m.CreateChain(whitelistedChain)
+	m.CreateChain(otherChain)
+
+	if len(m.blockedChains) != 2 {
+		t.Fatalf("expected 2 queued chains but got %d", len(m.blockedChains))
+	}
+	if m.IsIgnored(defaultChain.ID) {
+		t.Fatal("chains on the default subnet shouldn't be ignored")
+	}
+	if m.IsIgnored(whitelistedChain.ID) {
+		t.Fatal("chains on whitelisted subnets shouldn't be ignored")
+	}
+	if !m.IsIgnored(otherChain.ID) {
+		t.Fatal("chains on other subnets should be ignored")
+	}
+	if m.IsIgnored(ids.NewID([32]byte{6})) {
+		t.Fatal("unknown chains shouldn't be ignored")
+	}
+
+	chains := m.Chains()
+	if len(chains) != 3 {
+		t.Fatalf("expected 3 chains but got %d", len(chains))
+	}
+	for i, chain := range []ChainParameters{defaultChain, whitelistedChain, otherChain} {
+		if !chains[i].ID.Equals(chain.ID) {
+			t.Fatalf("expected chain %s at index %d but got %s", chain.ID, i, chains[i].ID)
+		}
+	}
+	if chains[0].Ignored || chains[1].Ignored || !chains[2].Ignored {
+		t.Fatal("wrong chains were ignored")
+	}
+}
+
+func TestCompressedChains(t *testing.T) {
+	aliasedChainID := ids.NewID([32]byte{1})
+	namedChainID := ids.NewID([32]byte{2})
+	otherChainID := ids.NewID([32]byte{3})
+
+	m := &manager{compressedChains: []string{"X", namedChainID.String()}}
+	m.Initialize()
+	if err := m.Alias(aliasedChainID, "X"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !m.isCompressed(aliasedChainID) {
+		t.Fatal("chains configured by alias should be compressed")
+	}
+	if !m.isCompressed(namedChainID) {
+		t.Fatal("chains configured by ID should be compressed")
+	}
+	if m.isCompressed(otherChainID) {
+		t.Fatal("other chains shouldn't be compressed")
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	schema := migration.NewSchema("test")
+	schema.Register("add key", func(db database.Database) error {
+		return db.Put([]byte("migrated"), []byte("value"))
+	})
+
+	db := memdb.New()
+	if err := db.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	chainID := ids.NewID([32]byte{1})
+
+	m := &manager{log: logging.NoLog{}}
+	if err := m.migrate(chainID, "vm", schema, db); err != nil {
+		t.Fatal(err)
+	}
+	if has, err := db.Has([]byte("migrated")); err != nil {
+		t.Fatal(err)
+	} else if !has {
+		t.Fatal("expected the migration to run")
+	}
+}
+
+func TestUncompressedChainDBReadsCompressedValues(t *testing.T) {
+	db := memdb.New()
+	key := []byte("hello")
+	value := bytes.Repeat([]byte("world"), 100)
+
+	compressedDB, err := newChainDB(snowball.Parameters{Namespace: "a", Metrics: prometheus.NewRegistry()}, "vm", db, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := compressedDB.Put(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	uncompressedDB, err := newChainDB(snowball.Parameters{Namespace: "b", Metrics: prometheus.NewRegistry()}, "vm", db, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := uncompressedDB.Get(key); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(v, value) {
+		t.Fatal("values written while the chain was compressed should still be readable")
+	}
+}
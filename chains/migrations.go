@@ -0,0 +1,88 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/compressdb"
+	"github.com/ava-labs/gecko/database/migration"
+	"github.com/ava-labs/gecko/database/prefixdb"
+	"github.com/ava-labs/gecko/ids"
+)
+
+// queueSchema is the layout of the databases of a chain's bootstrapping job
+// queues
+var queueSchema = migration.NewSchema("queue")
+
+// The subsystems of a chain whose databases hold bootstrapping job queues
+var queueDBs = []string{"bootstrapping", "vertex_bootstrapping", "tx_bootstrapping"}
+
+// PendingMigration is a migration that hasn't been run on one of a chain's
+// databases
+type PendingMigration struct {
+	migration.Migration
+
+	// ChainID is the ID of the chain whose database this migration would run on
+	ChainID ids.ID
+	// Database is the name of the chain's subsystem whose database this
+	// migration would run on
+	Database string
+	// Schema is the name of the schema this migration belongs to
+	Schema string
+	// CurrentVersion is the version of the schema the database is at
+	CurrentVersion uint32
+}
+
+// PendingMigrations returns the migrations that haven't been run on the
+// databases of the chain with ID [chainID], where [db] is this node's database.
+// [vmSchema] is the layout of the chain's VM database, or nil if the VM isn't
+// versioned. Nothing is written to [db].
+func PendingMigrations(db database.Database, chainID ids.ID, vmSchema *migration.Schema) ([]PendingMigration, error) {
+	pending := []PendingMigration(nil)
+	if vmSchema != nil {
+		migrations, err := pendingMigrations(db, chainID, "vm", vmSchema)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, migrations...)
+	}
+	for _, name := range queueDBs {
+		migrations, err := pendingMigrations(db, chainID, name, queueSchema)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, migrations...)
+	}
+	return pending, nil
+}
+
+// VMDB returns the database of the VM of the chain with ID [chainID], where
+// [db] is this node's database. Its use isn't recorded in metrics.
+func VMDB(db database.Database, chainID ids.ID) database.Database {
+	return chainDB(db, chainID, "vm")
+}
+
+// chainDB returns the database of the [name] subsystem of the chain with ID
+// [chainID], as laid out by newChainDB, without metering it
+func chainDB(db database.Database, chainID ids.ID, name string) database.Database {
+	return compressdb.New(compressdb.NoCompression, prefixdb.New([]byte(name), prefixdb.New(chainID.Bytes(), db)))
+}
+
+func pendingMigrations(db database.Database, chainID ids.ID, name string, schema *migration.Schema) ([]PendingMigration, error) {
+	version, migrations, err := schema.Pending(chainDB(db, chainID, name))
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]PendingMigration, len(migrations))
+	for i, pendingMigration := range migrations {
+		pending[i] = PendingMigration{
+			Migration:      pendingMigration,
+			ChainID:        chainID,
+			Database:       name,
+			Schema:         schema.Name(),
+			CurrentVersion: version,
+		}
+	}
+	return pending, nil
+}
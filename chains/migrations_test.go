@@ -0,0 +1,79 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
+	"github.com/ava-labs/gecko/database/prefixdb"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/consensus/snowball"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPendingMigrations(t *testing.T) {
+	schema := migration.NewSchema("test")
+	schema.Register("add key", func(db database.Database) error {
+		return db.Put([]byte("migrated"), []byte("value"))
+	})
+
+	db := memdb.New()
+	chainID := ids.NewID([32]byte{1})
+
+	// The databases of a chain that hasn't been created are empty, so they're
+	// at the latest version
+	if pending, err := PendingMigrations(db, chainID, schema); err != nil {
+		t.Fatal(err)
+	} else if len(pending) != 0 {
+		t.Fatalf("expected no pending migrations but got %d", len(pending))
+	}
+
+	vmDB, err := newChainDB(snowball.Parameters{Namespace: "a", Metrics: prometheus.NewRegistry()}, "vm", prefixdb.New(chainID.Bytes(), db), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vmDB.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := PendingMigrations(db, chainID, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("expected 1 pending migration but got %d", len(pending))
+	}
+	if pendingMigration := pending[0]; !pendingMigration.ChainID.Equals(chainID) ||
+		pendingMigration.Database != "vm" ||
+		pendingMigration.Schema != "test" ||
+		pendingMigration.CurrentVersion != 0 ||
+		pendingMigration.Version != 1 ||
+		pendingMigration.Description != "add key" {
+		t.Fatalf("unexpected pending migration %+v", pendingMigration)
+	}
+	if has, err := vmDB.Has([]byte("migrated")); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatal("pending migrations shouldn't be run")
+	}
+
+	// Chains whose VMs aren't versioned only have their job queues checked
+	if pending, err := PendingMigrations(db, chainID, nil); err != nil {
+		t.Fatal(err)
+	} else if len(pending) != 0 {
+		t.Fatalf("expected no pending migrations but got %d", len(pending))
+	}
+
+	if _, err := schema.Migrate(VMDB(db, chainID)); err != nil {
+		t.Fatal(err)
+	}
+	if pending, err := PendingMigrations(db, chainID, schema); err != nil {
+		t.Fatal(err)
+	} else if len(pending) != 0 {
+		t.Fatalf("expected no pending migrations but got %d", len(pending))
+	}
+}
@@ -27,6 +27,15 @@ func (mm MockManager) Lookup(string) (ids.ID, error) { return ids.ID{}, nil }
 // LookupVM ...
 func (mm MockManager) LookupVM(string) (ids.ID, error) { return ids.ID{}, nil }
 
+// ValidateGenesis ...
+func (mm MockManager) ValidateGenesis(ids.ID, []ids.ID, []byte) error { return nil }
+
+// Chains ...
+func (mm MockManager) Chains() []ChainInfo { return nil }
+
+// IsIgnored ...
+func (mm MockManager) IsIgnored(ids.ID) bool { return false }
+
 // Aliases ...
 func (mm MockManager) Aliases(ids.ID) []string { return nil }
 
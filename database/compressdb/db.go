@@ -0,0 +1,373 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compressdb
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"io/ioutil"
+	"sync"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/nodb"
+	"github.com/ava-labs/gecko/utils"
+)
+
+const (
+	// DefaultThreshold is the default size, in bytes, above which values are
+	// compressed
+	DefaultThreshold = 256
+
+	// NoCompression is the threshold at which no values are compressed. Values
+	// that were compressed before are still decompressed when they're read.
+	NoCompression = int(^uint(0) >> 1)
+)
+
+// Values written by this database that aren't stored as is start with [magic]
+// followed by a format byte. Values that don't start with [magic], including
+// values written before the database was compressed, are read as is.
+const (
+	formatRaw byte = iota
+	formatFlate
+)
+
+var (
+	magic     = []byte{0xc0, 0x3d, 0xb0, 0x7a}
+	headerLen = len(magic) + 1
+
+	errUnknownFormat = errors.New("unknown compression format")
+
+	writers = sync.Pool{
+		New: func() interface{} {
+			w, _ := flate.NewWriter(nil, flate.DefaultCompression)
+			return w
+		},
+	}
+)
+
+// Database compresses the values that are larger than a threshold
+type Database struct {
+	lock      sync.RWMutex
+	threshold int
+	db        database.Database
+}
+
+// New returns a new database that compresses values larger than [threshold]
+// bytes before writing them to [db]
+func New(threshold int, db database.Database) *Database {
+	return &Database{
+		threshold: threshold,
+		db:        db,
+	}
+}
+
+// Has implements the Database interface
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return false, database.ErrClosed
+	}
+	return db.db.Has(key)
+}
+
+// Get implements the Database interface
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	value, err := db.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(value)
+}
+
+// Put implements the Database interface
+func (db *Database) Put(key, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+
+	compressedValue, err := db.compress(value)
+	if err != nil {
+		return err
+	}
+	return db.db.Put(key, compressedValue)
+}
+
+// Delete implements the Database interface
+func (db *Database) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.Delete(key)
+}
+
+// NewBatch implements the Database interface
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		Batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+// NewIterator implements the Database interface
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Database interface
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Database interface
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Database interface
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix)}
+}
+
+// NewSnapshot returns a read-only view of the database's current state. The
+// underlying database must support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snap{Snapshot: snapshot}, nil
+}
+
+// Stat implements the Database interface
+func (db *Database) Stat(stat string) (string, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return "", database.ErrClosed
+	}
+	return db.db.Stat(stat)
+}
+
+// Compact implements the Database interface
+func (db *Database) Compact(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.Compact(start, limit)
+}
+
+// Close implements the Database interface
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	db.db = nil
+	return nil
+}
+
+// compress returns the bytes to store for [value]. [value] is compressed if
+// it's larger than the threshold and compressing it saves space.
+func (db *Database) compress(value []byte) ([]byte, error) {
+	if len(value) > db.threshold {
+		buf := bytes.NewBuffer(make([]byte, 0, len(value)))
+		buf.Write(magic)
+		buf.WriteByte(formatFlate)
+
+		w := writers.Get().(*flate.Writer)
+		defer writers.Put(w)
+
+		w.Reset(buf)
+		if _, err := w.Write(value); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		if buf.Len() < len(value) {
+			return buf.Bytes(), nil
+		}
+	}
+
+	// Values that look like they have a header must be given one, so that
+	// they aren't mistaken for a compressed value
+	if !bytes.HasPrefix(value, magic) {
+		return value, nil
+	}
+	rawValue := make([]byte, headerLen+len(value))
+	copy(rawValue, magic)
+	rawValue[len(magic)] = formatRaw
+	copy(rawValue[headerLen:], value)
+	return rawValue, nil
+}
+
+// decompress returns the value that [storedValue] represents
+func decompress(storedValue []byte) ([]byte, error) {
+	if len(storedValue) < headerLen || !bytes.HasPrefix(storedValue, magic) {
+		return storedValue, nil
+	}
+
+	switch storedValue[len(magic)] {
+	case formatRaw:
+		return storedValue[headerLen:], nil
+	case formatFlate:
+		r := flate.NewReader(bytes.NewReader(storedValue[headerLen:]))
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	default:
+		return nil, errUnknownFormat
+	}
+}
+
+type keyValue struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+type batch struct {
+	database.Batch
+
+	db     *Database
+	writes []keyValue
+}
+
+func (b *batch) Put(key, value []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	compressedValue, err := b.db.compress(value)
+	if err != nil {
+		return err
+	}
+	return b.Batch.Put(key, compressedValue)
+}
+
+func (b *batch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	return b.Batch.Delete(key)
+}
+
+func (b *batch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	if b.db.db == nil {
+		return database.ErrClosed
+	}
+
+	return b.Batch.Write()
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	b.writes = b.writes[:0]
+	b.Batch.Reset()
+}
+
+// Replay replays the batch contents.
+func (b *batch) Replay(w database.KeyValueWriter) error {
+	for _, keyvalue := range b.writes {
+		if keyvalue.delete {
+			if err := w.Delete(keyvalue.key); err != nil {
+				return err
+			}
+		} else if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snap is a snapshot of the compressed database
+type snap struct{ database.Snapshot }
+
+// Get implements the Snapshot interface
+func (s *snap) Get(key []byte) ([]byte, error) {
+	value, err := s.Snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(value)
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snap) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix)}
+}
+
+type iterator struct {
+	database.Iterator
+
+	val []byte
+	err error
+}
+
+func (it *iterator) Next() bool {
+	next := it.Iterator.Next()
+	if next {
+		val, err := decompress(it.Iterator.Value())
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.val = val
+	} else {
+		it.val = nil
+	}
+	return next
+}
+
+func (it *iterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
+
+func (it *iterator) Value() []byte { return it.val }
@@ -0,0 +1,167 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compressdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+)
+
+func TestInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		test(t, New(0, memdb.New()))
+		test(t, New(DefaultThreshold, memdb.New()))
+	}
+}
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		test(t, New(0, memdb.New()))
+		test(t, New(DefaultThreshold, memdb.New()))
+	}
+}
+
+func TestUncompressedValues(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(0, baseDB)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	if err := baseDB.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+
+	iterator := db.NewIterator()
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if v := iterator.Value(); !bytes.Equal(value, v) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", v, value)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
+func TestCompressedValues(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(DefaultThreshold, baseDB)
+
+	key := []byte("hello")
+	value := bytes.Repeat([]byte("world"), DefaultThreshold)
+
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	if v, err := baseDB.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if len(v) >= len(value) {
+		t.Fatalf("Stored value should be compressed to fewer than %d bytes, but has %d bytes", len(value), len(v))
+	}
+
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+}
+
+func TestSmallValuesUncompressed(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(DefaultThreshold, baseDB)
+
+	key := []byte("hello")
+	value := bytes.Repeat([]byte{1}, DefaultThreshold)
+
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	if v, err := baseDB.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+}
+
+func TestValueWithHeader(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(DefaultThreshold, baseDB)
+
+	key := []byte("hello")
+	value := append(append([]byte{}, magic...), formatFlate, 1, 2, 3)
+
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(DefaultThreshold, baseDB)
+
+	key := []byte("hello")
+	value := append(append([]byte{}, magic...), 0xff)
+
+	if err := baseDB.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	if _, err := db.Get(key); err != errUnknownFormat {
+		t.Fatalf("db.Get: Returned: %v ; Expected: %s", err, errUnknownFormat)
+	}
+
+	iterator := db.NewIterator()
+	defer iterator.Release()
+
+	if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != errUnknownFormat {
+		t.Fatalf("iterator.Error Returned: %v ; Expected: %s", err, errUnknownFormat)
+	}
+}
+
+func TestNoCompression(t *testing.T) {
+	baseDB := memdb.New()
+
+	key := []byte("hello")
+	value := bytes.Repeat([]byte("world"), 100)
+
+	if err := New(0, baseDB).Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	db := New(NoCompression, baseDB)
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(v, value) {
+		t.Fatalf("Expected a value compressed before to be decompressed")
+	}
+
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+	if v, err := baseDB.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(v, value) {
+		t.Fatalf("Expected the new value to be written as is")
+	}
+}
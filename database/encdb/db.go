@@ -127,6 +127,25 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewSnapshot returns a read-only view of the database's current state. The
+// underlying database must support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snap{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	db.lock.RLock()
@@ -219,6 +238,42 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 	return nil
 }
 
+// snap is a snapshot of the encrypted database
+type snap struct {
+	database.Snapshot
+	db *Database
+}
+
+// Get implements the Snapshot interface
+func (s *snap) Get(key []byte) ([]byte, error) {
+	encVal, err := s.Snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.decrypt(encVal)
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snap) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
@@ -22,3 +22,16 @@ func TestInterface(t *testing.T) {
 		test(t, db)
 	}
 }
+
+func TestSnapshotInterface(t *testing.T) {
+	pw := "lol totally a secure password"
+	for _, test := range database.SnapshotTests {
+		unencryptedDB := memdb.New()
+		db, err := New([]byte(pw), unencryptedDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
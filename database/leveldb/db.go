@@ -110,11 +110,16 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 // over the database starting at start and ignoring keys that do not start with
 // the provided prefix
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
-	iterRange := util.BytesPrefix(prefix)
-	if bytes.Compare(start, prefix) == 1 {
-		iterRange.Start = start
+	return &iter{db.DB.NewIterator(startAndPrefixRange(start, prefix), nil)}
+}
+
+// NewSnapshot returns a read-only view of the database's current state
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	snapshot, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
 	}
-	return &iter{db.DB.NewIterator(iterRange, nil)}
+	return &snap{snapshot}, nil
 }
 
 // Stat returns a particular internal stat of the database.
@@ -207,6 +212,55 @@ func (r *replayer) Delete(key []byte) {
 	r.err = r.writer.Delete(key)
 }
 
+// snap is a wrapper around a levelDB snapshot
+type snap struct{ *leveldb.Snapshot }
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snap) Has(key []byte) (bool, error) {
+	has, err := s.Snapshot.Has(key, nil)
+	return has, updateError(err)
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snap) Get(key []byte) ([]byte, error) {
+	value, err := s.Snapshot.Get(key, nil)
+	return value, updateError(err)
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snap) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iter{s.Snapshot.NewIterator(startAndPrefixRange(start, prefix), nil)}
+}
+
+// Release implements the Snapshot interface
+func (s *snap) Release() error {
+	s.Snapshot.Release()
+	return nil
+}
+
+// startAndPrefixRange returns the range of keys that start with [prefix] and
+// are at least [start]
+func startAndPrefixRange(start, prefix []byte) *util.Range {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return iterRange
+}
+
 type iter struct{ iterator.Iterator }
 
 // Error implements the Iterator interface
@@ -220,7 +274,7 @@ func (it *iter) Value() []byte { return utils.CopyBytes(it.Iterator.Value()) }
 
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
@@ -21,6 +21,10 @@ const DefaultSize = 1 << 10
 type Database struct {
 	lock sync.RWMutex
 	db   map[string][]byte
+
+	// shared is true if [db] may be referenced by a snapshot, in which case it
+	// must be copied before it's modified
+	shared bool
 }
 
 // New returns a map with the Database interface methods implemented.
@@ -76,6 +80,7 @@ func (db *Database) Put(key []byte, value []byte) error {
 	if db.db == nil {
 		return database.ErrClosed
 	}
+	db.unshare()
 	db.db[string(key)] = utils.CopyBytes(value)
 	return nil
 }
@@ -88,6 +93,7 @@ func (db *Database) Delete(key []byte) error {
 	if db.db == nil {
 		return database.ErrClosed
 	}
+	db.unshare()
 	delete(db.db, string(key))
 	return nil
 }
@@ -136,6 +142,36 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewSnapshot returns a read-only view of the database's current state. The
+// snapshot shares the database's contents until the database is next modified.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	db.shared = true
+	return &snapshot{Database: &Database{
+		db:     db.db,
+		shared: true,
+	}}, nil
+}
+
+// unshare copies the database's contents if a snapshot may reference them.
+// Assumes the lock is held.
+func (db *Database) unshare() {
+	if !db.shared {
+		return
+	}
+	newDB := make(map[string][]byte, len(db.db))
+	for key, value := range db.db {
+		newDB[key] = value
+	}
+	db.db = newDB
+	db.shared = false
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(property string) (string, error) { return "", database.ErrNotFound }
 
@@ -178,6 +214,7 @@ func (b *batch) Write() error {
 		return database.ErrClosed
 	}
 
+	b.db.unshare()
 	for _, kv := range b.writes {
 		key := string(kv.key)
 		if kv.delete {
@@ -212,6 +249,18 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 // Inner returns itself
 func (b *batch) Inner() database.Batch { return b }
 
+// snapshot is a read-only view of a memory database. Because the database
+// copies its contents before modifying them, the snapshot never changes.
+type snapshot struct{ *Database }
+
+// Release implements the Snapshot interface
+func (s *snapshot) Release() error {
+	if err := s.Database.Close(); err != database.ErrClosed {
+		return err
+	}
+	return nil
+}
+
 type iterator struct {
 	initialized bool
 	keys        []string
@@ -14,3 +14,9 @@ func TestInterface(t *testing.T) {
 		test(t, New())
 	}
 }
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		test(t, New())
+	}
+}
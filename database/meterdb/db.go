@@ -0,0 +1,228 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package meterdb
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/utils/timer"
+)
+
+// StatProperty is the Stat property that returns the readings of the meters
+const StatProperty = "meterdb.stats"
+
+// Database tracks the number, latency and size of the operations performed on
+// an underlying database.
+type Database struct {
+	metrics
+	clock timer.Clock
+	db    database.Database
+}
+
+// New returns a new database that records metrics about its use of [db]. The
+// metrics are registered with [registerer] under [namespace].
+func New(namespace string, registerer prometheus.Registerer, db database.Database) (*Database, error) {
+	meterDB := &Database{db: db}
+	return meterDB, meterDB.metrics.Initialize(namespace, registerer)
+}
+
+// Has implements the Database interface
+func (db *Database) Has(key []byte) (bool, error) {
+	start := db.clock.Time()
+	has, err := db.db.Has(key)
+	end := db.clock.Time()
+	db.has.Observe(len(key), end.Sub(start))
+	return has, err
+}
+
+// Get implements the Database interface
+func (db *Database) Get(key []byte) ([]byte, error) {
+	start := db.clock.Time()
+	value, err := db.db.Get(key)
+	end := db.clock.Time()
+	db.get.Observe(len(key)+len(value), end.Sub(start))
+	return value, err
+}
+
+// Put implements the Database interface
+func (db *Database) Put(key, value []byte) error {
+	start := db.clock.Time()
+	err := db.db.Put(key, value)
+	end := db.clock.Time()
+	db.put.Observe(len(key)+len(value), end.Sub(start))
+	return err
+}
+
+// Delete implements the Database interface
+func (db *Database) Delete(key []byte) error {
+	start := db.clock.Time()
+	err := db.db.Delete(key)
+	end := db.clock.Time()
+	db.delete.Observe(len(key), end.Sub(start))
+	return err
+}
+
+// NewBatch implements the Database interface
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+// NewIterator implements the Database interface
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Database interface
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Database interface
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Database interface
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+	}
+}
+
+// NewSnapshot returns a read-only view of the database's current state. Reads
+// from the snapshot are metered. The underlying database must support
+// snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snap{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
+// Stat returns the readings of the meters if [property] is StatProperty.
+// Otherwise, the underlying database's stat is returned.
+func (db *Database) Stat(property string) (string, error) {
+	if property == StatProperty {
+		return db.metrics.String(), nil
+	}
+	return db.db.Stat(property)
+}
+
+// Compact implements the Database interface
+func (db *Database) Compact(start, limit []byte) error { return db.db.Compact(start, limit) }
+
+// Close implements the Database interface
+func (db *Database) Close() error { return db.db.Close() }
+
+type batch struct {
+	batch database.Batch
+	db    *Database
+}
+
+// Put implements the Batch interface
+func (b *batch) Put(key, value []byte) error {
+	start := b.db.clock.Time()
+	err := b.batch.Put(key, value)
+	end := b.db.clock.Time()
+	b.db.batchPut.Observe(len(key)+len(value), end.Sub(start))
+	return err
+}
+
+// Delete implements the Batch interface
+func (b *batch) Delete(key []byte) error {
+	start := b.db.clock.Time()
+	err := b.batch.Delete(key)
+	end := b.db.clock.Time()
+	b.db.batchDelete.Observe(len(key), end.Sub(start))
+	return err
+}
+
+// ValueSize implements the Batch interface
+func (b *batch) ValueSize() int { return b.batch.ValueSize() }
+
+// Write implements the Batch interface
+func (b *batch) Write() error {
+	start := b.db.clock.Time()
+	err := b.batch.Write()
+	end := b.db.clock.Time()
+	b.db.batchWrite.Observe(b.batch.ValueSize(), end.Sub(start))
+	return err
+}
+
+// Reset implements the Batch interface
+func (b *batch) Reset() { b.batch.Reset() }
+
+// Replay implements the Batch interface
+func (b *batch) Replay(w database.KeyValueWriter) error { return b.batch.Replay(w) }
+
+// Inner implements the Batch interface
+func (b *batch) Inner() database.Batch { return b.batch.Inner() }
+
+// snap is a snapshot of the metered database
+type snap struct {
+	database.Snapshot
+	db *Database
+}
+
+// Has implements the Snapshot interface
+func (s *snap) Has(key []byte) (bool, error) {
+	start := s.db.clock.Time()
+	has, err := s.Snapshot.Has(key)
+	end := s.db.clock.Time()
+	s.db.has.Observe(len(key), end.Sub(start))
+	return has, err
+}
+
+// Get implements the Snapshot interface
+func (s *snap) Get(key []byte) ([]byte, error) {
+	start := s.db.clock.Time()
+	value, err := s.Snapshot.Get(key)
+	end := s.db.clock.Time()
+	s.db.get.Observe(len(key)+len(value), end.Sub(start))
+	return value, err
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snap) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+}
+
+type iterator struct {
+	database.Iterator
+	db *Database
+}
+
+// Next implements the Iterator interface
+func (it *iterator) Next() bool {
+	start := it.db.clock.Time()
+	next := it.Iterator.Next()
+	end := it.db.clock.Time()
+	it.db.iteratorNext.Observe(len(it.Iterator.Key())+len(it.Iterator.Value()), end.Sub(start))
+	return next
+}
@@ -0,0 +1,136 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package meterdb
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+)
+
+func TestInterface(t *testing.T) {
+	for _, test := range database.Tests {
+		db, err := New("", prometheus.NewRegistry(), memdb.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		db, err := New("", prometheus.NewRegistry(), memdb.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		test(t, db)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	db, err := New("test_db", registry, memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := []byte("hello")
+	value := []byte("world")
+	if err := db.Put(key, value); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Get(key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Get(key); err != nil {
+		t.Fatal(err)
+	}
+
+	batch := db.NewBatch()
+	if err := batch.Delete(key); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]uint64)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			if histogram := metric.GetHistogram(); histogram != nil {
+				counts[family.GetName()] = histogram.GetSampleCount()
+			}
+		}
+	}
+	expected := map[string]uint64{
+		"test_db_put_latency":          1,
+		"test_db_get_latency":          2,
+		"test_db_batch_delete_latency": 1,
+		"test_db_batch_write_latency":  1,
+		"test_db_has_latency":          0,
+	}
+	for name, count := range expected {
+		if counts[name] != count {
+			t.Fatalf("expected %s to have %d samples but has %d", name, count, counts[name])
+		}
+	}
+
+	stats, err := db.Stat(StatProperty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(stats, "get: count=2 ") {
+		t.Fatalf("expected the stats to report 2 gets but got:\n%s", stats)
+	}
+	if !strings.Contains(stats, "put: count=1 ") || !strings.Contains(stats, "bytes=10") {
+		t.Fatalf("expected the stats to report a 10 byte put but got:\n%s", stats)
+	}
+
+	// Other properties are passed through to the underlying database
+	if _, err := db.Stat("leveldb.stats"); err != database.ErrNotFound {
+		t.Fatalf("expected %s but got %v", database.ErrNotFound, err)
+	}
+}
+
+func TestDuplicateNamespace(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	if _, err := New("test_db", registry, memdb.New()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New("test_db", registry, memdb.New()); err == nil {
+		t.Fatal("should have failed because the metrics are already registered")
+	}
+}
+
+func TestLatencyInSeconds(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := newMeter("test_db", "get")
+	if err := registry.Register(m.latency); err != nil {
+		t.Fatal(err)
+	}
+	m.Observe(0, 2*time.Second)
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(families) != 1 || len(families[0].GetMetric()) != 1 {
+		t.Fatalf("expected only the latency to be registered")
+	}
+	if sum := families[0].GetMetric()[0].GetHistogram().GetSampleSum(); sum != 2 {
+		t.Fatalf("expected a 2 second call to be recorded as 2 but it was recorded as %f", sum)
+	}
+}
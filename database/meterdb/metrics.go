@@ -0,0 +1,117 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package meterdb
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Database operations take between microseconds and seconds, so the latency
+// buckets are in seconds and grow by a factor of 10 from 1 microsecond to 10
+// seconds.
+var latencyBuckets = prometheus.ExponentialBuckets(time.Microsecond.Seconds(), 10, 8)
+
+// meter records the number, latency and size of calls to one database
+// operation
+type meter struct {
+	name    string
+	latency prometheus.Histogram
+	size    prometheus.Counter
+
+	// Readings reported by Stat. Accessed atomically.
+	count, duration, bytes uint64
+}
+
+func newMeter(namespace, name string) *meter {
+	return &meter{
+		name: name,
+		latency: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_latency", name),
+				Help:      fmt.Sprintf("Latency of %s calls in seconds", name),
+				Buckets:   latencyBuckets,
+			}),
+		size: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_size", name),
+				Help:      fmt.Sprintf("Number of bytes passed to and returned from %s calls", name),
+			}),
+	}
+}
+
+// Observe records a call that took [duration] and passed or returned [size]
+// bytes
+func (m *meter) Observe(size int, duration time.Duration) {
+	m.latency.Observe(duration.Seconds())
+	m.size.Add(float64(size))
+
+	atomic.AddUint64(&m.count, 1)
+	atomic.AddUint64(&m.duration, uint64(duration))
+	atomic.AddUint64(&m.bytes, uint64(size))
+}
+
+func (m *meter) String() string {
+	return fmt.Sprintf("%s: count=%d duration=%s bytes=%d",
+		m.name,
+		atomic.LoadUint64(&m.count),
+		time.Duration(atomic.LoadUint64(&m.duration)),
+		atomic.LoadUint64(&m.bytes),
+	)
+}
+
+type metrics struct {
+	has, get, put, delete,
+	batchPut, batchDelete, batchWrite,
+	iteratorNext *meter
+
+	meters []*meter
+}
+
+// Initialize the metrics, registering them with [registerer]
+func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer) error {
+	m.has = newMeter(namespace, "has")
+	m.get = newMeter(namespace, "get")
+	m.put = newMeter(namespace, "put")
+	m.delete = newMeter(namespace, "delete")
+	m.batchPut = newMeter(namespace, "batch_put")
+	m.batchDelete = newMeter(namespace, "batch_delete")
+	m.batchWrite = newMeter(namespace, "batch_write")
+	m.iteratorNext = newMeter(namespace, "iterator_next")
+	m.meters = []*meter{
+		m.has,
+		m.get,
+		m.put,
+		m.delete,
+		m.batchPut,
+		m.batchDelete,
+		m.batchWrite,
+		m.iteratorNext,
+	}
+
+	for _, meter := range m.meters {
+		if err := registerer.Register(meter.latency); err != nil {
+			return fmt.Errorf("failed to register %s_latency statistics due to %w", meter.name, err)
+		}
+		if err := registerer.Register(meter.size); err != nil {
+			return fmt.Errorf("failed to register %s_size statistics due to %w", meter.name, err)
+		}
+	}
+	return nil
+}
+
+// String returns the readings of every meter, one per line
+func (m *metrics) String() string {
+	readings := make([]string, len(m.meters))
+	for i, meter := range m.meters {
+		readings[i] = meter.String()
+	}
+	return strings.Join(readings, "\n")
+}
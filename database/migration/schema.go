@@ -0,0 +1,129 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migration
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/versiondb"
+)
+
+// The key, in each versioned namespace, of the namespace's schema version
+var versionKey = []byte("schema version")
+
+var (
+	errInvalidVersion = errors.New("invalid schema version")
+	errFutureVersion  = errors.New("database was written with a newer schema version")
+)
+
+// Versioned is implemented by VMs whose database layout is versioned
+type Versioned interface {
+	// Schema returns the schema of the VM's database
+	Schema() *Schema
+}
+
+// Migration upgrades a database from version [Version]-1 of its schema to
+// version [Version]
+type Migration struct {
+	Version     uint32
+	Description string
+	Migrate     func(db database.Database) error
+}
+
+// Schema is the versioned layout of the databases of a namespace, such as a
+// VM's. Its version is the number of migrations that were registered.
+// A database without a schema version is at version 0, unless it's empty, in
+// which case it's at the schema's latest version.
+type Schema struct {
+	name       string
+	migrations []Migration
+}
+
+// NewSchema returns a new schema, at version 0, named [name]
+func NewSchema(name string) *Schema { return &Schema{name: name} }
+
+// Name returns the name of the schema
+func (s *Schema) Name() string { return s.name }
+
+// Version returns the latest version of the schema
+func (s *Schema) Version() uint32 { return uint32(len(s.migrations)) }
+
+// Register adds a migration, described by [description], that upgrades a
+// database to the next version of the schema. Migrations must be registered
+// in order.
+func (s *Schema) Register(description string, migrate func(db database.Database) error) {
+	s.migrations = append(s.migrations, Migration{
+		Version:     s.Version() + 1,
+		Description: description,
+		Migrate:     migrate,
+	})
+}
+
+// Pending returns the version of the schema that [db] is at and the migrations
+// that haven't been run on [db]
+func (s *Schema) Pending(db database.Database) (uint32, []Migration, error) {
+	version, _, err := s.version(db)
+	if err != nil {
+		return 0, nil, err
+	}
+	return version, s.migrations[version:], nil
+}
+
+// Migrate runs the migrations that haven't been run on [db] and returns them.
+// Either every migration is run, or none of them are.
+func (s *Schema) Migrate(db database.Database) ([]Migration, error) {
+	version, stored, err := s.version(db)
+	if err != nil {
+		return nil, err
+	}
+	pending := s.migrations[version:]
+	if stored && len(pending) == 0 {
+		return nil, nil
+	}
+
+	vdb := versiondb.New(db)
+	for _, migration := range pending {
+		if err := migration.Migrate(vdb); err != nil {
+			return nil, fmt.Errorf("migration %d of schema %s (%s) failed: %w", migration.Version, s.name, migration.Description, err)
+		}
+	}
+	versionBytes := [4]byte{}
+	binary.BigEndian.PutUint32(versionBytes[:], s.Version())
+	if err := vdb.Put(versionKey, versionBytes[:]); err != nil {
+		return nil, err
+	}
+	if err := vdb.Commit(); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// version returns the version of the schema that [db] is at, and whether the
+// version is stored in [db]
+func (s *Schema) version(db database.Database) (uint32, bool, error) {
+	versionBytes, err := db.Get(versionKey)
+	if err == database.ErrNotFound {
+		it := db.NewIterator()
+		defer it.Release()
+
+		if it.Next() {
+			return 0, false, nil
+		}
+		return s.Version(), false, it.Error()
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if len(versionBytes) != 4 {
+		return 0, false, errInvalidVersion
+	}
+	version := binary.BigEndian.Uint32(versionBytes)
+	if version > s.Version() {
+		return 0, false, fmt.Errorf("%w: schema %s is at version %d, but the database is at version %d", errFutureVersion, s.name, s.Version(), version)
+	}
+	return version, true, nil
+}
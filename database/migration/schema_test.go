@@ -0,0 +1,137 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migration
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+)
+
+func newTestSchema() *Schema {
+	s := NewSchema("test")
+	s.Register("rename key", func(db database.Database) error {
+		value, err := db.Get([]byte("old"))
+		if err != nil {
+			return err
+		}
+		if err := db.Delete([]byte("old")); err != nil {
+			return err
+		}
+		return db.Put([]byte("new"), value)
+	})
+	s.Register("add key", func(db database.Database) error {
+		return db.Put([]byte("added"), []byte("value"))
+	})
+	return s
+}
+
+func TestMigrateEmpty(t *testing.T) {
+	s := newTestSchema()
+	db := memdb.New()
+
+	if version, pending, err := s.Pending(db); err != nil {
+		t.Fatal(err)
+	} else if version != 2 || len(pending) != 0 {
+		t.Fatalf("Expected an empty database to be at version 2 without pending migrations, but was at version %d with %d", version, len(pending))
+	}
+
+	if migrations, err := s.Migrate(db); err != nil {
+		t.Fatal(err)
+	} else if len(migrations) != 0 {
+		t.Fatalf("Expected no migrations to run but %d did", len(migrations))
+	}
+
+	// Once the version is stored, the database is no longer empty
+	if has, err := db.Has(versionKey); err != nil {
+		t.Fatal(err)
+	} else if !has {
+		t.Fatal("Expected the schema version to be stored")
+	}
+	if version, _, err := s.Pending(db); err != nil {
+		t.Fatal(err)
+	} else if version != 2 {
+		t.Fatalf("Expected version 2 but got %d", version)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	s := newTestSchema()
+	db := memdb.New()
+	if err := db.Put([]byte("old"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+
+	if version, pending, err := s.Pending(db); err != nil {
+		t.Fatal(err)
+	} else if version != 0 || len(pending) != 2 {
+		t.Fatalf("Expected an unversioned database to be at version 0 with 2 pending migrations, but was at version %d with %d", version, len(pending))
+	} else if has, err := db.Has([]byte("new")); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatal("Listing the pending migrations shouldn't run them")
+	}
+
+	if migrations, err := s.Migrate(db); err != nil {
+		t.Fatal(err)
+	} else if len(migrations) != 2 || migrations[0].Version != 1 || migrations[1].Version != 2 {
+		t.Fatalf("Expected migrations 1 and 2 to run but got %v", migrations)
+	}
+
+	if value, err := db.Get([]byte("new")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(value, []byte("value")) {
+		t.Fatalf("Expected value %q but got %q", "value", value)
+	}
+	if has, err := db.Has([]byte("old")); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatal("Expected the old key to be deleted")
+	}
+
+	if _, pending, err := s.Pending(db); err != nil {
+		t.Fatal(err)
+	} else if len(pending) != 0 {
+		t.Fatalf("Expected no pending migrations but got %d", len(pending))
+	}
+}
+
+func TestMigrateAtomic(t *testing.T) {
+	s := newTestSchema()
+	errMigration := errors.New("migration failed")
+	s.Register("fail", func(db database.Database) error { return errMigration })
+
+	db := memdb.New()
+	if err := db.Put([]byte("old"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Migrate(db); !errors.Is(err, errMigration) {
+		t.Fatalf("Expected %s but got %v", errMigration, err)
+	}
+	if has, err := db.Has([]byte("new")); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatal("A failed migration shouldn't write anything")
+	}
+	if version, _, err := s.Pending(db); err != nil {
+		t.Fatal(err)
+	} else if version != 0 {
+		t.Fatalf("Expected version 0 but got %d", version)
+	}
+}
+
+func TestFutureVersion(t *testing.T) {
+	db := memdb.New()
+	if _, err := newTestSchema().Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := NewSchema("test").Pending(db); !errors.Is(err, errFutureVersion) {
+		t.Fatalf("Expected %s but got %v", errFutureVersion, err)
+	}
+}
@@ -36,11 +36,24 @@ func New(prefix []byte, db database.Database) *Database {
 // prefixes.
 func NewNested(prefix []byte, db database.Database) *Database {
 	return &Database{
-		dbPrefix: hashing.ComputeHash256(prefix),
+		dbPrefix: Prefix(prefix),
 		db:       db,
 	}
 }
 
+// Prefix returns the bytes that prefix the keys of the database created by
+// calling New with each of [prefixes] in turn, starting from a database that
+// isn't a prefixed database.
+func Prefix(prefixes ...[]byte) []byte {
+	// New hashes the prefix of the database it wraps together with the new
+	// prefix
+	var dbPrefix []byte
+	for _, prefix := range prefixes {
+		dbPrefix = hashing.ComputeHash256(append(dbPrefix, prefix...))
+	}
+	return dbPrefix
+}
+
 // Has implements the Database interface
 func (db *Database) Has(key []byte) (bool, error) {
 	db.lock.RLock()
@@ -120,6 +133,25 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewSnapshot returns a read-only view of the database's current state. The
+// underlying database must support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snap{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	db.lock.RLock()
@@ -217,6 +249,39 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 	return nil
 }
 
+// snap is a snapshot of the prefixed database
+type snap struct {
+	database.Snapshot
+	db *Database
+}
+
+// Has implements the Snapshot interface
+func (s *snap) Has(key []byte) (bool, error) { return s.Snapshot.Has(s.db.prefix(key)) }
+
+// Get implements the Snapshot interface
+func (s *snap) Get(key []byte) ([]byte, error) { return s.Snapshot.Get(s.db.prefix(key)) }
+
+// NewIterator implements the Snapshot interface
+func (s *snap) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(s.db.prefix(start), s.db.prefix(prefix)),
+		db:       s.db,
+	}
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
@@ -4,10 +4,12 @@
 package prefixdb
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/nodb"
 )
 
 func TestInterface(t *testing.T) {
@@ -21,3 +23,36 @@ func TestInterface(t *testing.T) {
 		test(t, NewNested([]byte("ld"), New([]byte("wor"), db)))
 	}
 }
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		test(t, New([]byte("hello"), memdb.New()))
+		test(t, New([]byte("wor"), New([]byte("ld"), memdb.New())))
+		test(t, NewNested([]byte("wor"), New([]byte("ld"), memdb.New())))
+	}
+}
+
+func TestSnapshotNotSupported(t *testing.T) {
+	db := New([]byte("hello"), &nodb.Database{})
+	if _, err := db.NewSnapshot(); err != database.ErrNotSupported {
+		t.Fatalf("Expected %s on db.NewSnapshot but got %v", database.ErrNotSupported, err)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	baseDB := memdb.New()
+	db := New([]byte("ld"), New([]byte("wor"), baseDB))
+	if err := db.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedKey := append(Prefix([]byte("wor"), []byte("ld")), []byte("key")...)
+	iterator := baseDB.NewIterator()
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatal("Expected the base database to contain the key")
+	} else if key := iterator.Key(); !bytes.Equal(key, expectedKey) {
+		t.Fatalf("Expected key 0x%x but got 0x%x", expectedKey, key)
+	}
+}
@@ -15,8 +15,9 @@ import (
 )
 
 var (
-	errClosed   = fmt.Sprintf("rpc error: code = Unknown desc = %s", database.ErrClosed)
-	errNotFound = fmt.Sprintf("rpc error: code = Unknown desc = %s", database.ErrNotFound)
+	errClosed       = fmt.Sprintf("rpc error: code = Unknown desc = %s", database.ErrClosed)
+	errNotFound     = fmt.Sprintf("rpc error: code = Unknown desc = %s", database.ErrNotFound)
+	errNotSupported = fmt.Sprintf("rpc error: code = Unknown desc = %s", database.ErrNotSupported)
 )
 
 // DatabaseClient is an implementation of database that talks over RPC.
@@ -28,26 +29,10 @@ func NewClient(client rpcdbproto.DatabaseClient) *DatabaseClient {
 }
 
 // Has returns false, nil
-func (db *DatabaseClient) Has(key []byte) (bool, error) {
-	resp, err := db.client.Has(context.Background(), &rpcdbproto.HasRequest{
-		Key: key,
-	})
-	if err != nil {
-		return false, updateError(err)
-	}
-	return resp.Has, nil
-}
+func (db *DatabaseClient) Has(key []byte) (bool, error) { return db.has(key, 0) }
 
 // Get returns nil, error
-func (db *DatabaseClient) Get(key []byte) ([]byte, error) {
-	resp, err := db.client.Get(context.Background(), &rpcdbproto.GetRequest{
-		Key: key,
-	})
-	if err != nil {
-		return nil, updateError(err)
-	}
-	return resp.Value, nil
-}
+func (db *DatabaseClient) Get(key []byte) ([]byte, error) { return db.get(key, 0) }
 
 // Put returns nil
 func (db *DatabaseClient) Put(key, value []byte) error {
@@ -86,17 +71,7 @@ func (db *DatabaseClient) NewIteratorWithPrefix(prefix []byte) database.Iterator
 
 // NewIteratorWithStartAndPrefix returns a new empty iterator
 func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
-	resp, err := db.client.NewIteratorWithStartAndPrefix(context.Background(), &rpcdbproto.NewIteratorWithStartAndPrefixRequest{
-		Start:  start,
-		Prefix: prefix,
-	})
-	if err != nil {
-		return &nodb.Iterator{Err: updateError(err)}
-	}
-	return &iterator{
-		db: db,
-		id: resp.Id,
-	}
+	return db.newIterator(start, prefix, 0)
 }
 
 // Stat returns an error
@@ -125,6 +100,61 @@ func (db *DatabaseClient) Close() error {
 	return updateError(err)
 }
 
+// NewSnapshot returns a read-only view of the remote database's current state
+func (db *DatabaseClient) NewSnapshot() (database.Snapshot, error) {
+	resp, err := db.client.NewSnapshot(context.Background(), &rpcdbproto.NewSnapshotRequest{})
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db: db,
+		id: resp.Id,
+	}, nil
+}
+
+// has reads from the snapshot with ID [snapshotID], or from the live database
+// if [snapshotID] is 0
+func (db *DatabaseClient) has(key []byte, snapshotID uint64) (bool, error) {
+	resp, err := db.client.Has(context.Background(), &rpcdbproto.HasRequest{
+		Key:        key,
+		SnapshotID: snapshotID,
+	})
+	if err != nil {
+		return false, updateError(err)
+	}
+	return resp.Has, nil
+}
+
+// get reads from the snapshot with ID [snapshotID], or from the live database
+// if [snapshotID] is 0
+func (db *DatabaseClient) get(key []byte, snapshotID uint64) ([]byte, error) {
+	resp, err := db.client.Get(context.Background(), &rpcdbproto.GetRequest{
+		Key:        key,
+		SnapshotID: snapshotID,
+	})
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return resp.Value, nil
+}
+
+// newIterator iterates over the snapshot with ID [snapshotID], or over the live
+// database if [snapshotID] is 0
+func (db *DatabaseClient) newIterator(start, prefix []byte, snapshotID uint64) database.Iterator {
+	resp, err := db.client.NewIteratorWithStartAndPrefix(context.Background(), &rpcdbproto.NewIteratorWithStartAndPrefixRequest{
+		Start:      start,
+		Prefix:     prefix,
+		SnapshotID: snapshotID,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: updateError(err)}
+	}
+	return &iterator{
+		db: db,
+		id: resp.Id,
+	}
+}
+
 type keyValue struct {
 	key    []byte
 	value  []byte
@@ -199,6 +229,61 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 
 func (b *batch) Inner() database.Batch { return b }
 
+type snapshot struct {
+	db       *DatabaseClient
+	id       uint64
+	released bool
+}
+
+// Has implements the Snapshot interface
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.released {
+		return false, database.ErrClosed
+	}
+	return s.db.has(key, s.id)
+}
+
+// Get implements the Snapshot interface
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.released {
+		return nil, database.ErrClosed
+	}
+	return s.db.get(key, s.id)
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snapshot) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.released {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return s.db.newIterator(start, prefix, s.id)
+}
+
+// Release implements the Snapshot interface
+func (s *snapshot) Release() error {
+	if s.released {
+		return nil
+	}
+	s.released = true
+	_, err := s.db.client.SnapshotRelease(context.Background(), &rpcdbproto.SnapshotReleaseRequest{
+		Id: s.id,
+	})
+	return updateError(err)
+}
+
 type iterator struct {
 	db    *DatabaseClient
 	id    uint64
@@ -257,6 +342,8 @@ func updateError(err error) error {
 		return database.ErrClosed
 	case errNotFound:
 		return database.ErrNotFound
+	case errNotSupported:
+		return database.ErrNotSupported
 	default:
 		return err
 	}
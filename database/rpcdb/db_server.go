@@ -5,17 +5,26 @@ package rpcdb
 
 import (
 	"errors"
+	"sync"
 
 	"golang.org/x/net/context"
 
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/database/rpcdb/rpcdbproto"
+	"github.com/ava-labs/gecko/utils/wrappers"
 )
 
 var (
 	errUnknownIterator = errors.New("unknown iterator")
+	errUnknownSnapshot = errors.New("unknown snapshot")
 )
 
+// view is the part of a database that a snapshot can stand in for
+type view interface {
+	database.KeyValueReader
+	database.Iteratee
+}
+
 // DatabaseServer is a database that is managed over RPC.
 type DatabaseServer struct {
 	db    database.Database
@@ -23,6 +32,11 @@ type DatabaseServer struct {
 
 	nextIteratorID uint64
 	iterators      map[uint64]database.Iterator
+
+	// Snapshot IDs start at 1. A snapshot ID of 0 refers to the live database.
+	snapshotLock   sync.Mutex
+	nextSnapshotID uint64
+	snapshots      map[uint64]database.Snapshot
 }
 
 // NewServer returns a database instance that is managed remotely
@@ -31,12 +45,19 @@ func NewServer(db database.Database) *DatabaseServer {
 		db:        db,
 		batch:     db.NewBatch(),
 		iterators: make(map[uint64]database.Iterator),
+
+		nextSnapshotID: 1,
+		snapshots:      make(map[uint64]database.Snapshot),
 	}
 }
 
 // Has ...
 func (db *DatabaseServer) Has(_ context.Context, req *rpcdbproto.HasRequest) (*rpcdbproto.HasResponse, error) {
-	has, err := db.db.Has(req.Key)
+	reader, err := db.reader(req.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+	has, err := reader.Has(req.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +66,11 @@ func (db *DatabaseServer) Has(_ context.Context, req *rpcdbproto.HasRequest) (*r
 
 // Get ...
 func (db *DatabaseServer) Get(_ context.Context, req *rpcdbproto.GetRequest) (*rpcdbproto.GetResponse, error) {
-	value, err := db.db.Get(req.Key)
+	reader, err := db.reader(req.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+	value, err := reader.Get(req.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -76,9 +101,19 @@ func (db *DatabaseServer) Compact(_ context.Context, req *rpcdbproto.CompactRequ
 	return &rpcdbproto.CompactResponse{}, db.db.Compact(req.Start, req.Limit)
 }
 
-// Close ...
+// Close releases the snapshots that are still held and closes the database
 func (db *DatabaseServer) Close(_ context.Context, _ *rpcdbproto.CloseRequest) (*rpcdbproto.CloseResponse, error) {
-	return &rpcdbproto.CloseResponse{}, db.db.Close()
+	db.snapshotLock.Lock()
+	snapshots := db.snapshots
+	db.snapshots = make(map[uint64]database.Snapshot)
+	db.snapshotLock.Unlock()
+
+	errs := wrappers.Errs{}
+	for _, snapshot := range snapshots {
+		errs.Add(snapshot.Release())
+	}
+	errs.Add(db.db.Close())
+	return &rpcdbproto.CloseResponse{}, errs.Err
 }
 
 // WriteBatch ...
@@ -102,8 +137,13 @@ func (db *DatabaseServer) WriteBatch(_ context.Context, req *rpcdbproto.WriteBat
 
 // NewIteratorWithStartAndPrefix ...
 func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbproto.NewIteratorWithStartAndPrefixRequest) (*rpcdbproto.NewIteratorWithStartAndPrefixResponse, error) {
+	iteratee, err := db.reader(req.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
 	id := db.nextIteratorID
-	it := db.db.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	it := iteratee.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
 	db.iterators[id] = it
 
 	db.nextIteratorID++
@@ -141,3 +181,50 @@ func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbproto.Ite
 	}
 	return &rpcdbproto.IteratorReleaseResponse{}, nil
 }
+
+// NewSnapshot ...
+func (db *DatabaseServer) NewSnapshot(_ context.Context, _ *rpcdbproto.NewSnapshotRequest) (*rpcdbproto.NewSnapshotResponse, error) {
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+
+	db.snapshotLock.Lock()
+	defer db.snapshotLock.Unlock()
+
+	id := db.nextSnapshotID
+	db.snapshots[id] = snapshot
+
+	db.nextSnapshotID++
+	return &rpcdbproto.NewSnapshotResponse{Id: id}, nil
+}
+
+// SnapshotRelease ...
+func (db *DatabaseServer) SnapshotRelease(_ context.Context, req *rpcdbproto.SnapshotReleaseRequest) (*rpcdbproto.SnapshotReleaseResponse, error) {
+	db.snapshotLock.Lock()
+	snapshot, exists := db.snapshots[req.Id]
+	delete(db.snapshots, req.Id)
+	db.snapshotLock.Unlock()
+
+	if !exists {
+		return &rpcdbproto.SnapshotReleaseResponse{}, nil
+	}
+	return &rpcdbproto.SnapshotReleaseResponse{}, snapshot.Release()
+}
+
+// reader returns the snapshot with ID [snapshotID], or the live database if
+// [snapshotID] is 0
+func (db *DatabaseServer) reader(snapshotID uint64) (view, error) {
+	if snapshotID == 0 {
+		return db.db, nil
+	}
+
+	db.snapshotLock.Lock()
+	defer db.snapshotLock.Unlock()
+
+	snapshot, exists := db.snapshots[snapshotID]
+	if !exists {
+		return nil, errUnknownSnapshot
+	}
+	return snapshot, nil
+}
@@ -4,6 +4,8 @@
 package rpcdb
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net"
 	"testing"
@@ -49,3 +51,91 @@ func TestInterface(t *testing.T) {
 		conn.Close()
 	}
 }
+
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		db, conn := newTestClient(t, NewServer(memdb.New()))
+		test(t, db)
+		conn.Close()
+	}
+}
+
+// errSnapshot is a snapshot that fails to be released
+type errSnapshot struct {
+	database.Snapshot
+	released bool
+}
+
+var errRelease = errors.New("release failed")
+
+func (s *errSnapshot) Release() error {
+	s.released = true
+	return errRelease
+}
+
+func TestSnapshotReleaseError(t *testing.T) {
+	server := NewServer(memdb.New())
+	db, conn := newTestClient(t, server)
+	defer conn.Close()
+
+	snap, err := db.NewSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := snap.(*snapshot).id
+	server.snapshots[id] = &errSnapshot{Snapshot: server.snapshots[id]}
+
+	if err := snap.Release(); err == nil || err.Error() != fmt.Sprintf("rpc error: code = Unknown desc = %s", errRelease) {
+		t.Fatalf("Expected the release error to be returned but got %v", err)
+	}
+}
+
+func TestCloseReleasesSnapshots(t *testing.T) {
+	server := NewServer(memdb.New())
+	db, conn := newTestClient(t, server)
+	defer conn.Close()
+
+	if _, err := db.NewSnapshot(); err != nil {
+		t.Fatal(err)
+	}
+	held := &errSnapshot{}
+	for id, snapshot := range server.snapshots {
+		held.Snapshot = snapshot
+		server.snapshots[id] = held
+	}
+
+	if err := db.Close(); err == nil {
+		t.Fatalf("Expected the snapshot's release error to be returned")
+	}
+	if !held.released {
+		t.Fatalf("Closing the database should have released the snapshot")
+	}
+	if len(server.snapshots) != 0 {
+		t.Fatalf("Closing the database should have removed %d snapshots", len(server.snapshots))
+	}
+}
+
+// newTestClient returns a client of [server], which is served over RPC
+func newTestClient(t *testing.T, server *DatabaseServer) (*DatabaseClient, *grpc.ClientConn) {
+	listener := bufconn.Listen(bufSize)
+	grpcServer := grpc.NewServer()
+	rpcdbproto.RegisterDatabaseServer(grpcServer, server)
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("Server exited with error: %v", err)
+		}
+	}()
+
+	dialer := grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		})
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "", dialer, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+	return NewClient(rpcdbproto.NewDatabaseClient(conn)), conn
+}
@@ -26,6 +26,7 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type HasRequest struct {
 	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	SnapshotID           uint64   `protobuf:"varint,2,opt,name=snapshotID,proto3" json:"snapshotID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -63,6 +64,13 @@ func (m *HasRequest) GetKey() []byte {
 	return nil
 }
 
+func (m *HasRequest) GetSnapshotID() uint64 {
+	if m != nil {
+		return m.SnapshotID
+	}
+	return 0
+}
+
 type HasResponse struct {
 	Has                  bool     `protobuf:"varint,1,opt,name=has,proto3" json:"has,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -104,6 +112,7 @@ func (m *HasResponse) GetHas() bool {
 
 type GetRequest struct {
 	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	SnapshotID           uint64   `protobuf:"varint,2,opt,name=snapshotID,proto3" json:"snapshotID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -141,6 +150,13 @@ func (m *GetRequest) GetKey() []byte {
 	return nil
 }
 
+func (m *GetRequest) GetSnapshotID() uint64 {
+	if m != nil {
+		return m.SnapshotID
+	}
+	return 0
+}
+
 type GetResponse struct {
 	Value                []byte   `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -658,6 +674,7 @@ var xxx_messageInfo_NewIteratorRequest proto.InternalMessageInfo
 type NewIteratorWithStartAndPrefixRequest struct {
 	Start                []byte   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
 	Prefix               []byte   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	SnapshotID           uint64   `protobuf:"varint,3,opt,name=snapshotID,proto3" json:"snapshotID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -702,6 +719,13 @@ func (m *NewIteratorWithStartAndPrefixRequest) GetPrefix() []byte {
 	return nil
 }
 
+func (m *NewIteratorWithStartAndPrefixRequest) GetSnapshotID() uint64 {
+	if m != nil {
+		return m.SnapshotID
+	}
+	return 0
+}
+
 type NewIteratorWithStartAndPrefixResponse struct {
 	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -975,6 +999,146 @@ func (m *IteratorReleaseResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_IteratorReleaseResponse proto.InternalMessageInfo
 
+type NewSnapshotRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewSnapshotRequest) Reset()         { *m = NewSnapshotRequest{} }
+func (m *NewSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*NewSnapshotRequest) ProtoMessage()    {}
+func (*NewSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{25}
+}
+
+func (m *NewSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewSnapshotRequest.Unmarshal(m, b)
+}
+func (m *NewSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *NewSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewSnapshotRequest.Merge(m, src)
+}
+func (m *NewSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_NewSnapshotRequest.Size(m)
+}
+func (m *NewSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewSnapshotRequest proto.InternalMessageInfo
+
+type NewSnapshotResponse struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewSnapshotResponse) Reset()         { *m = NewSnapshotResponse{} }
+func (m *NewSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*NewSnapshotResponse) ProtoMessage()    {}
+func (*NewSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{26}
+}
+
+func (m *NewSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewSnapshotResponse.Unmarshal(m, b)
+}
+func (m *NewSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (m *NewSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewSnapshotResponse.Merge(m, src)
+}
+func (m *NewSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_NewSnapshotResponse.Size(m)
+}
+func (m *NewSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewSnapshotResponse proto.InternalMessageInfo
+
+func (m *NewSnapshotResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SnapshotReleaseRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotReleaseRequest) Reset()         { *m = SnapshotReleaseRequest{} }
+func (m *SnapshotReleaseRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReleaseRequest) ProtoMessage()    {}
+func (*SnapshotReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{27}
+}
+
+func (m *SnapshotReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotReleaseRequest.Unmarshal(m, b)
+}
+func (m *SnapshotReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotReleaseRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReleaseRequest.Merge(m, src)
+}
+func (m *SnapshotReleaseRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotReleaseRequest.Size(m)
+}
+func (m *SnapshotReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReleaseRequest proto.InternalMessageInfo
+
+func (m *SnapshotReleaseRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SnapshotReleaseResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotReleaseResponse) Reset()         { *m = SnapshotReleaseResponse{} }
+func (m *SnapshotReleaseResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReleaseResponse) ProtoMessage()    {}
+func (*SnapshotReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{28}
+}
+
+func (m *SnapshotReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotReleaseResponse.Unmarshal(m, b)
+}
+func (m *SnapshotReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotReleaseResponse.Marshal(b, m, deterministic)
+}
+func (m *SnapshotReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReleaseResponse.Merge(m, src)
+}
+func (m *SnapshotReleaseResponse) XXX_Size() int {
+	return xxx_messageInfo_SnapshotReleaseResponse.Size(m)
+}
+func (m *SnapshotReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReleaseResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*HasRequest)(nil), "rpcdbproto.HasRequest")
 	proto.RegisterType((*HasResponse)(nil), "rpcdbproto.HasResponse")
@@ -1001,53 +1165,62 @@ func init() {
 	proto.RegisterType((*IteratorErrorResponse)(nil), "rpcdbproto.IteratorErrorResponse")
 	proto.RegisterType((*IteratorReleaseRequest)(nil), "rpcdbproto.IteratorReleaseRequest")
 	proto.RegisterType((*IteratorReleaseResponse)(nil), "rpcdbproto.IteratorReleaseResponse")
+	proto.RegisterType((*NewSnapshotRequest)(nil), "rpcdbproto.NewSnapshotRequest")
+	proto.RegisterType((*NewSnapshotResponse)(nil), "rpcdbproto.NewSnapshotResponse")
+	proto.RegisterType((*SnapshotReleaseRequest)(nil), "rpcdbproto.SnapshotReleaseRequest")
+	proto.RegisterType((*SnapshotReleaseResponse)(nil), "rpcdbproto.SnapshotReleaseResponse")
 }
 
 func init() { proto.RegisterFile("rpcdb.proto", fileDescriptor_af52f4b90339c3f4) }
 
 var fileDescriptor_af52f4b90339c3f4 = []byte{
-	// 654 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5d, 0x4f, 0xd4, 0x40,
-	0x14, 0x0d, 0xbb, 0xcb, 0xd7, 0xd9, 0x0f, 0x60, 0x5c, 0xf9, 0xa8, 0xf2, 0x35, 0x88, 0x41, 0x1f,
-	0x88, 0x82, 0xc1, 0x98, 0x90, 0x18, 0x01, 0x03, 0xc6, 0x84, 0x60, 0x21, 0x21, 0x31, 0xbe, 0x0c,
-	0xec, 0x10, 0x1a, 0x97, 0x6d, 0x6d, 0x67, 0x15, 0xdf, 0x7d, 0xf1, 0x5f, 0x9b, 0x4e, 0x6f, 0xdb,
-	0x99, 0xdd, 0x76, 0xd5, 0xb7, 0xb9, 0x73, 0xcf, 0x39, 0x73, 0xe7, 0xce, 0x3d, 0x83, 0x7a, 0x18,
-	0x5c, 0x77, 0xae, 0xb6, 0x83, 0xd0, 0x57, 0x3e, 0x83, 0x0e, 0xf4, 0x9a, 0xaf, 0x00, 0x27, 0x22,
-	0x72, 0xe5, 0xb7, 0xbe, 0x8c, 0x14, 0x9b, 0x45, 0xf5, 0xab, 0xfc, 0xb9, 0x38, 0xb6, 0x36, 0xb6,
-	0xd5, 0x70, 0xe3, 0x25, 0x5f, 0x45, 0x5d, 0xe7, 0xa3, 0xc0, 0xef, 0x45, 0x32, 0x06, 0xdc, 0x8a,
-	0x48, 0x03, 0xa6, 0xdc, 0x78, 0x19, 0x0b, 0x1c, 0x4b, 0x55, 0x2e, 0xb0, 0x81, 0xba, 0xce, 0x93,
-	0x40, 0x1b, 0xe3, 0xdf, 0x45, 0xb7, 0x2f, 0x09, 0x92, 0x04, 0xfc, 0x15, 0x70, 0xd6, 0x2f, 0x17,
-	0xc9, 0x59, 0x15, 0x93, 0xd5, 0x44, 0x5d, 0xb3, 0x12, 0x69, 0xbe, 0x8e, 0xe6, 0x91, 0xec, 0x4a,
-	0x25, 0xcb, 0x8b, 0x99, 0x45, 0x2b, 0x85, 0x10, 0xe9, 0x19, 0xea, 0xe7, 0x4a, 0x64, 0x47, 0x3b,
-	0x98, 0x0a, 0x42, 0x3f, 0x90, 0xa1, 0x4a, 0x78, 0xd3, 0x6e, 0x16, 0x73, 0x8e, 0x46, 0x02, 0xa5,
-	0xab, 0x30, 0xd4, 0x22, 0x25, 0x14, 0xe1, 0xf4, 0x9a, 0xef, 0xa3, 0x75, 0xe8, 0xdf, 0x05, 0xe2,
-	0x3a, 0x53, 0x6c, 0x63, 0x3c, 0x52, 0x22, 0x54, 0xe9, 0x85, 0x75, 0x10, 0xef, 0x76, 0xbd, 0x3b,
-	0x4f, 0xa5, 0x17, 0xd2, 0x01, 0x9f, 0xc3, 0x4c, 0xc6, 0xa6, 0xfa, 0x5a, 0x68, 0x1c, 0x76, 0xfd,
-	0x28, 0xbd, 0x13, 0x9f, 0x41, 0x93, 0x62, 0x02, 0x28, 0xcc, 0x5d, 0x86, 0x9e, 0x92, 0x07, 0x42,
-	0x5d, 0xdf, 0xa6, 0x87, 0x3e, 0x47, 0x2d, 0xe8, 0xab, 0xf8, 0x9d, 0xaa, 0x5b, 0xf5, 0x9d, 0xf9,
-	0xed, 0xfc, 0xc1, 0xb7, 0xf3, 0x3e, 0xbb, 0x1a, 0xc3, 0x76, 0x31, 0xd9, 0xd1, 0x3d, 0x89, 0x16,
-	0x2b, 0x1a, 0xbe, 0x64, 0xc2, 0xad, 0x8e, 0xba, 0x29, 0x92, 0xb7, 0xc1, 0xcc, 0x53, 0xa9, 0x96,
-	0x36, 0xd8, 0xa9, 0xfc, 0xf1, 0x41, 0xc9, 0x50, 0x28, 0x3f, 0x4c, 0x4b, 0xbe, 0xc0, 0x13, 0x63,
-	0xf7, 0xd2, 0x53, 0xb7, 0xe7, 0x71, 0x0f, 0xde, 0xf5, 0x3a, 0x67, 0xa1, 0xbc, 0xf1, 0xee, 0x47,
-	0x77, 0x6a, 0x1e, 0x13, 0x81, 0x86, 0x51, 0xab, 0x28, 0xe2, 0xaf, 0xb1, 0xf9, 0x17, 0x55, 0x7a,
-	0xa6, 0x16, 0x2a, 0x5e, 0x47, 0x6b, 0xd6, 0xdc, 0x8a, 0xd7, 0xe1, 0x9b, 0x78, 0x90, 0xb2, 0x4e,
-	0xe5, 0x7d, 0xf6, 0x4e, 0x83, 0xb0, 0x2f, 0x68, 0xdb, 0x30, 0x92, 0x7b, 0x8c, 0xe9, 0x1b, 0xbf,
-	0xdf, 0xeb, 0xc4, 0x9b, 0xe4, 0x83, 0x7c, 0x23, 0x1d, 0xb9, 0x4a, 0xc1, 0xe8, 0x56, 0xcd, 0xd1,
-	0x7d, 0x9a, 0xab, 0xbf, 0x0f, 0xc3, 0xac, 0x57, 0x43, 0x55, 0x2c, 0xe0, 0xe1, 0x00, 0x8e, 0x5a,
-	0xbd, 0x85, 0xf9, 0xbc, 0xcf, 0x5d, 0x29, 0xb2, 0x09, 0x19, 0x92, 0x58, 0xc2, 0xc2, 0x10, 0x32,
-	0x11, 0xd9, 0xf9, 0x3d, 0x89, 0xa9, 0x23, 0xa1, 0xc4, 0x95, 0x88, 0x24, 0xdb, 0x43, 0xf5, 0x44,
-	0x44, 0xcc, 0x1a, 0x96, 0xfc, 0x6b, 0x70, 0x16, 0x86, 0xf6, 0xa9, 0x21, 0x7b, 0xa8, 0x1e, 0x4b,
-	0x65, 0xf3, 0xf2, 0x1f, 0xc1, 0xe6, 0x99, 0x3f, 0xc1, 0x1e, 0xaa, 0x67, 0xfd, 0x01, 0x5e, 0x3e,
-	0x9c, 0x36, 0xcf, 0xb0, 0x39, 0x7b, 0x8b, 0x89, 0x64, 0x28, 0x59, 0xf9, 0xa0, 0x3a, 0x4e, 0x51,
-	0x8a, 0x04, 0xde, 0xa0, 0x16, 0xfb, 0x98, 0x59, 0x27, 0x18, 0x9f, 0x80, 0xb3, 0x38, 0x9c, 0x20,
-	0xea, 0x01, 0x26, 0xc9, 0xa0, 0xcc, 0x3a, 0xc1, 0xf6, 0xbc, 0xf3, 0xa8, 0x30, 0x47, 0x1a, 0xfb,
-	0x18, 0xd7, 0x0e, 0x66, 0xd6, 0x31, 0xa6, 0xc9, 0x9d, 0xa5, 0x82, 0x0c, 0xb1, 0x3f, 0x02, 0xb9,
-	0xf1, 0xd8, 0xb2, 0x09, 0x1c, 0xfa, 0x06, 0x9c, 0x95, 0xb2, 0x34, 0x89, 0xfd, 0x1a, 0xc3, 0xf2,
-	0x48, 0x13, 0xb1, 0x17, 0xa6, 0xc2, 0xbf, 0xb8, 0xd8, 0x79, 0xf9, 0x1f, 0x0c, 0x2a, 0xe3, 0x13,
-	0x1a, 0xa6, 0xd5, 0xd8, 0xaa, 0x29, 0x51, 0xe0, 0x55, 0x67, 0xad, 0x1c, 0x40, 0x92, 0x17, 0x68,
-	0x5a, 0xbe, 0x61, 0x85, 0x14, 0xd3, 0x7a, 0xce, 0xfa, 0x08, 0x04, 0xa9, 0x7e, 0xc6, 0xcc, 0x80,
-	0x95, 0x18, 0x2f, 0x62, 0xd9, 0x8e, 0x74, 0x36, 0x46, 0x62, 0x12, 0xed, 0xab, 0x09, 0x9d, 0xde,
-	0xfd, 0x13, 0x00, 0x00, 0xff, 0xff, 0x91, 0xec, 0x29, 0xb2, 0xaa, 0x07, 0x00, 0x00,
+	// 728 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x5d, 0x4f, 0x13, 0x41,
+	0x14, 0x4d, 0x3f, 0x80, 0x72, 0xb7, 0x2d, 0x30, 0xd4, 0xb6, 0x8c, 0xf2, 0xb5, 0x88, 0xa9, 0x3e,
+	0x10, 0x05, 0x83, 0x31, 0x21, 0x1a, 0x01, 0x03, 0xc4, 0x84, 0xd4, 0xc5, 0x84, 0xc4, 0xf8, 0x32,
+	0xd0, 0x21, 0xdd, 0x58, 0xba, 0xeb, 0xce, 0xac, 0xe2, 0xbb, 0x7f, 0xc9, 0xff, 0x67, 0x76, 0x7a,
+	0x77, 0x77, 0xf6, 0xab, 0xa8, 0x6f, 0x33, 0x77, 0xce, 0x39, 0xf7, 0xce, 0x9d, 0xb9, 0x07, 0x0c,
+	0xcf, 0xbd, 0x1e, 0x5c, 0xed, 0xb8, 0x9e, 0x23, 0x1d, 0x02, 0x6a, 0xa3, 0xd6, 0xe6, 0x1b, 0x80,
+	0x53, 0x26, 0x2c, 0xfe, 0xcd, 0xe7, 0x42, 0x92, 0x45, 0xa8, 0x7c, 0xe5, 0x3f, 0xbb, 0xa5, 0x8d,
+	0x52, 0xaf, 0x6e, 0x05, 0x4b, 0xb2, 0x06, 0x20, 0xc6, 0xcc, 0x15, 0x43, 0x47, 0x9e, 0x1d, 0x77,
+	0xcb, 0x1b, 0xa5, 0x5e, 0xd5, 0xd2, 0x22, 0xe6, 0x3a, 0x18, 0x8a, 0x2f, 0x5c, 0x67, 0x2c, 0x78,
+	0x20, 0x30, 0x64, 0x42, 0x09, 0xd4, 0xac, 0x60, 0x19, 0x24, 0x38, 0xe1, 0xf2, 0xff, 0x13, 0x6c,
+	0x81, 0xa1, 0xf8, 0x98, 0xa0, 0x05, 0x33, 0xdf, 0xd9, 0xc8, 0xe7, 0x28, 0x31, 0xd9, 0x98, 0x2f,
+	0x01, 0xfa, 0xfe, 0x94, 0x24, 0x11, 0xab, 0xac, 0xb3, 0x1a, 0x60, 0xf4, 0xfd, 0x48, 0xda, 0xdc,
+	0x84, 0xc6, 0x31, 0x1f, 0x71, 0xc9, 0x0b, 0x75, 0xcc, 0x45, 0x68, 0x86, 0x10, 0x24, 0x3d, 0x05,
+	0xe3, 0x42, 0xb2, 0x28, 0x35, 0x85, 0x9a, 0xeb, 0x39, 0x2e, 0xf7, 0xe4, 0x84, 0x37, 0x6f, 0x45,
+	0x7b, 0xd3, 0x84, 0xfa, 0x04, 0x8a, 0x57, 0x21, 0x50, 0x15, 0x92, 0x49, 0xc4, 0xa9, 0xb5, 0x79,
+	0x00, 0xcd, 0x23, 0xe7, 0xd6, 0x65, 0xd7, 0x91, 0x62, 0x0b, 0x66, 0x84, 0x64, 0x9e, 0x0c, 0x2f,
+	0xac, 0x36, 0x41, 0x74, 0x64, 0xdf, 0xda, 0x32, 0xbc, 0x90, 0xda, 0x98, 0x4b, 0xb0, 0x10, 0xb1,
+	0xb1, 0xbe, 0x26, 0xd4, 0x8f, 0x46, 0x8e, 0x08, 0xef, 0x64, 0x2e, 0x40, 0x03, 0xf7, 0x08, 0x90,
+	0xb0, 0x74, 0xe9, 0xd9, 0x92, 0x1f, 0x32, 0x79, 0x3d, 0x0c, 0x93, 0x3e, 0x83, 0xaa, 0xeb, 0xcb,
+	0xe0, 0x1d, 0x2b, 0x3d, 0x63, 0xb7, 0xbd, 0x13, 0x7f, 0x98, 0x9d, 0xb8, 0xcf, 0x96, 0xc2, 0x90,
+	0x3d, 0x98, 0x1b, 0xa8, 0x9e, 0x88, 0x6e, 0x59, 0xc1, 0x57, 0x74, 0x78, 0xa2, 0xa3, 0x56, 0x88,
+	0x34, 0x5b, 0x40, 0xf4, 0xac, 0x58, 0x4b, 0x0b, 0xc8, 0x39, 0xff, 0x71, 0x26, 0xb9, 0xc7, 0xa4,
+	0xe3, 0x85, 0x25, 0x4b, 0x78, 0xac, 0x45, 0x2f, 0x6d, 0x39, 0xbc, 0x08, 0x7a, 0xf0, 0x6e, 0x3c,
+	0xe8, 0x7b, 0xfc, 0xc6, 0xbe, 0x9b, 0xde, 0xa9, 0x36, 0xcc, 0xba, 0x0a, 0x86, 0xad, 0xc2, 0x5d,
+	0xea, 0xdf, 0x55, 0x32, 0xff, 0xee, 0x15, 0x6c, 0xdf, 0x93, 0x15, 0x9f, 0xb1, 0x09, 0x65, 0x7b,
+	0xa0, 0x72, 0x56, 0xad, 0xb2, 0x3d, 0x30, 0xb7, 0x61, 0x39, 0x64, 0x9d, 0xf3, 0xbb, 0xe8, 0x1d,
+	0xd3, 0xb0, 0x2f, 0xd0, 0x4a, 0xc2, 0x50, 0xee, 0x11, 0xcc, 0xdf, 0x38, 0xfe, 0x78, 0x10, 0x04,
+	0x71, 0x8e, 0xe2, 0x40, 0xf8, 0x25, 0xcb, 0x39, 0x5f, 0xbb, 0xa2, 0x7f, 0xed, 0x27, 0xb1, 0xfa,
+	0x7b, 0xcf, 0x73, 0xbc, 0xa2, 0x2a, 0x3a, 0xf0, 0x20, 0x85, 0xc3, 0xa7, 0xe8, 0x41, 0x3b, 0x7e,
+	0x87, 0x11, 0x67, 0x82, 0x17, 0x49, 0xac, 0x40, 0x27, 0x83, 0x4c, 0xbc, 0xe7, 0x05, 0x36, 0x35,
+	0x7c, 0xcf, 0x6d, 0x58, 0x4e, 0x44, 0x0b, 0xfa, 0xd8, 0x83, 0x76, 0x8c, 0xb9, 0xaf, 0x82, 0x0c,
+	0x72, 0x22, 0xba, 0xfb, 0xbb, 0x06, 0xb5, 0x63, 0x26, 0xd9, 0x15, 0x13, 0x9c, 0xec, 0x43, 0xe5,
+	0x94, 0x09, 0x92, 0xf8, 0xce, 0xb1, 0xf9, 0xd1, 0x4e, 0x26, 0x8e, 0x95, 0xed, 0x43, 0xe5, 0x84,
+	0xcb, 0x24, 0x2f, 0xf6, 0x34, 0xda, 0xc9, 0xc4, 0x63, 0x5e, 0xdf, 0x97, 0xa4, 0x60, 0x7c, 0x68,
+	0x27, 0x13, 0x47, 0xde, 0x5b, 0x98, 0x9d, 0x8c, 0x0d, 0x29, 0x1e, 0x25, 0x4a, 0xf3, 0x8e, 0x50,
+	0xe0, 0x35, 0x54, 0x03, 0xa7, 0x21, 0x89, 0x0c, 0x9a, 0x4d, 0xd1, 0x6e, 0xf6, 0x00, 0xa9, 0x87,
+	0x30, 0x87, 0x16, 0x42, 0x12, 0x19, 0x92, 0xae, 0x44, 0x1f, 0xe6, 0x9e, 0xa1, 0xc6, 0x01, 0xcc,
+	0x28, 0x8f, 0x21, 0x89, 0x34, 0xba, 0x0d, 0xd1, 0x95, 0x9c, 0x13, 0x64, 0x7f, 0x00, 0x88, 0xad,
+	0x81, 0xac, 0xea, 0xc0, 0x8c, 0x51, 0xd1, 0xb5, 0xa2, 0x63, 0x14, 0xfb, 0x55, 0x82, 0xd5, 0xa9,
+	0x63, 0x4c, 0x9e, 0xeb, 0x0a, 0x7f, 0xe3, 0x33, 0xf4, 0xc5, 0x3f, 0x30, 0xb0, 0x8c, 0x8f, 0x50,
+	0xd7, 0x87, 0x9d, 0xac, 0xeb, 0x12, 0x39, 0x6e, 0x41, 0x37, 0x8a, 0x01, 0x28, 0xf9, 0x09, 0x1a,
+	0x89, 0xc9, 0x25, 0xb9, 0x14, 0x7d, 0xf8, 0xe9, 0xe6, 0x14, 0x04, 0xaa, 0x7e, 0x86, 0x85, 0xd4,
+	0x30, 0x13, 0x33, 0x8f, 0x95, 0x9c, 0x48, 0xba, 0x35, 0x15, 0x83, 0xda, 0xe7, 0x60, 0x68, 0x73,
+	0x4f, 0xd6, 0x52, 0x6d, 0x4c, 0xd9, 0x04, 0x5d, 0x2f, 0x3c, 0x8f, 0x6b, 0x4d, 0x8d, 0x7d, 0xb2,
+	0xd6, 0x7c, 0xf7, 0xa0, 0x5b, 0x53, 0x31, 0x13, 0xed, 0xab, 0x59, 0x75, 0xbc, 0xf7, 0x67, 0x00,
+	0x0e, 0xcb, 0x53, 0xf8, 0x38, 0x09, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1074,6 +1247,8 @@ type DatabaseClient interface {
 	IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error)
 	IteratorError(ctx context.Context, in *IteratorErrorRequest, opts ...grpc.CallOption) (*IteratorErrorResponse, error)
 	IteratorRelease(ctx context.Context, in *IteratorReleaseRequest, opts ...grpc.CallOption) (*IteratorReleaseResponse, error)
+	NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error)
+	SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error)
 }
 
 type databaseClient struct {
@@ -1192,6 +1367,24 @@ func (c *databaseClient) IteratorRelease(ctx context.Context, in *IteratorReleas
 	return out, nil
 }
 
+func (c *databaseClient) NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error) {
+	out := new(NewSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/NewSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error) {
+	out := new(SnapshotReleaseResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DatabaseServer is the server API for Database service.
 type DatabaseServer interface {
 	Has(context.Context, *HasRequest) (*HasResponse, error)
@@ -1206,6 +1399,8 @@ type DatabaseServer interface {
 	IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error)
 	IteratorError(context.Context, *IteratorErrorRequest) (*IteratorErrorResponse, error)
 	IteratorRelease(context.Context, *IteratorReleaseRequest) (*IteratorReleaseResponse, error)
+	NewSnapshot(context.Context, *NewSnapshotRequest) (*NewSnapshotResponse, error)
+	SnapshotRelease(context.Context, *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error)
 }
 
 // UnimplementedDatabaseServer can be embedded to have forward compatible implementations.
@@ -1248,6 +1443,12 @@ func (*UnimplementedDatabaseServer) IteratorError(ctx context.Context, req *Iter
 func (*UnimplementedDatabaseServer) IteratorRelease(ctx context.Context, req *IteratorReleaseRequest) (*IteratorReleaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorRelease not implemented")
 }
+func (*UnimplementedDatabaseServer) NewSnapshot(ctx context.Context, req *NewSnapshotRequest) (*NewSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewSnapshot not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotRelease(ctx context.Context, req *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotRelease not implemented")
+}
 
 func RegisterDatabaseServer(s *grpc.Server, srv DatabaseServer) {
 	s.RegisterService(&_Database_serviceDesc, srv)
@@ -1469,6 +1670,42 @@ func _Database_IteratorRelease_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/NewSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewSnapshot(ctx, req.(*NewSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, req.(*SnapshotReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Database_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcdbproto.Database",
 	HandlerType: (*DatabaseServer)(nil),
@@ -1521,6 +1758,14 @@ var _Database_serviceDesc = grpc.ServiceDesc{
 			MethodName: "IteratorRelease",
 			Handler:    _Database_IteratorRelease_Handler,
 		},
+		{
+			MethodName: "NewSnapshot",
+			Handler:    _Database_NewSnapshot_Handler,
+		},
+		{
+			MethodName: "SnapshotRelease",
+			Handler:    _Database_SnapshotRelease_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpcdb.proto",
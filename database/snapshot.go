@@ -0,0 +1,36 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+// Snapshot is a read-only view of a database as of the moment the snapshot was
+// taken. Writes made to the database after the snapshot was taken aren't
+// visible through it.
+//
+// A snapshot must be released after use. Once released, reads return
+// ErrClosed.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases the resources held by the snapshot. Release can be
+	// called multiple times; calls after the first return nil.
+	Release() error
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot returns a consistent, read-only view of the current state of
+	// the key-value data store.
+	NewSnapshot() (Snapshot, error)
+}
+
+// NewSnapshot returns a snapshot of [db]. If [db] doesn't support snapshots,
+// ErrNotSupported is returned.
+func NewSnapshot(db Database) (Snapshot, error) {
+	snapshotter, ok := db.(Snapshotter)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return snapshotter.NewSnapshot()
+}
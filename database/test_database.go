@@ -29,6 +29,14 @@ var (
 		TestStatNoPanic,
 		TestCompactNoPanic,
 	}
+
+	// SnapshotTests is a list of tests for databases that support snapshots
+	SnapshotTests = []func(t *testing.T, db Database){
+		TestSnapshotKeyValue,
+		TestSnapshotBatch,
+		TestSnapshotIterator,
+		TestSnapshotReleased,
+	}
 )
 
 // TestSimpleKeyValue ...
@@ -765,3 +773,219 @@ func TestCompactNoPanic(t *testing.T, db Database) {
 
 	db.Compact(nil, nil)
 }
+
+// TestSnapshotKeyValue ...
+func TestSnapshotKeyValue(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+	value1Updated := []byte("world1 updated")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := NewSnapshot(db)
+	if err != nil {
+		t.Fatalf("Unexpected error on NewSnapshot: %s", err)
+	}
+	defer snapshot.Release()
+
+	if err := db.Put(key1, value1Updated); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	if v, err := snapshot.Get(key1); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(value1, v) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value1)
+	} else if has, err := snapshot.Has(key2); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Has: %s", err)
+	} else if has {
+		t.Fatalf("snapshot.Has unexpectedly returned true on key %s", key2)
+	} else if v, err := snapshot.Get(key2); err != ErrNotFound {
+		t.Fatalf("Expected %s on snapshot.Get for missing key %s. Returned 0x%x", ErrNotFound, key2, v)
+	}
+
+	if v, err := db.Get(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value1Updated, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value1Updated)
+	}
+
+	if err := db.Delete(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	} else if has, err := snapshot.Has(key1); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Has: %s", err)
+	} else if !has {
+		t.Fatalf("snapshot.Has unexpectedly returned false on key %s", key1)
+	}
+}
+
+// TestSnapshotBatch ...
+func TestSnapshotBatch(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := NewSnapshot(db)
+	if err != nil {
+		t.Fatalf("Unexpected error on NewSnapshot: %s", err)
+	}
+	defer snapshot.Release()
+
+	batch := db.NewBatch()
+	if batch == nil {
+		t.Fatalf("db.NewBatch returned nil")
+	}
+
+	if err := batch.Delete(key1); err != nil {
+		t.Fatalf("Unexpected error on batch.Delete: %s", err)
+	} else if err := batch.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on batch.Put: %s", err)
+	} else if err := batch.Write(); err != nil {
+		t.Fatalf("Unexpected error on batch.Write: %s", err)
+	}
+
+	if v, err := snapshot.Get(key1); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(value1, v) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value1)
+	} else if has, err := snapshot.Has(key2); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Has: %s", err)
+	} else if has {
+		t.Fatalf("snapshot.Has unexpectedly returned true on key %s", key2)
+	}
+}
+
+// TestSnapshotIterator ...
+func TestSnapshotIterator(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	key4 := []byte("z")
+	value4 := []byte("world4")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key4, value4); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := NewSnapshot(db)
+	if err != nil {
+		t.Fatalf("Unexpected error on NewSnapshot: %s", err)
+	}
+	defer snapshot.Release()
+
+	if err := db.Delete(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	tests := []struct {
+		iterator Iterator
+		keys     [][]byte
+		values   [][]byte
+	}{
+		{
+			iterator: snapshot.NewIterator(),
+			keys:     [][]byte{key1, key2, key4},
+			values:   [][]byte{value1, value2, value4},
+		},
+		{
+			iterator: snapshot.NewIteratorWithStart(key2),
+			keys:     [][]byte{key2, key4},
+			values:   [][]byte{value2, value4},
+		},
+		{
+			iterator: snapshot.NewIteratorWithPrefix([]byte("h")),
+			keys:     [][]byte{key1, key2},
+			values:   [][]byte{value1, value2},
+		},
+		{
+			iterator: snapshot.NewIteratorWithStartAndPrefix(key2, []byte("h")),
+			keys:     [][]byte{key2},
+			values:   [][]byte{value2},
+		},
+	}
+	for _, test := range tests {
+		iterator := test.iterator
+		for i, key := range test.keys {
+			if !iterator.Next() {
+				t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+			} else if k := iterator.Key(); !bytes.Equal(k, key) {
+				t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", k, key)
+			} else if v := iterator.Value(); !bytes.Equal(v, test.values[i]) {
+				t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", v, test.values[i])
+			}
+		}
+		if iterator.Next() {
+			t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+		} else if err := iterator.Error(); err != nil {
+			t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+		}
+		iterator.Release()
+	}
+}
+
+// TestSnapshotReleased ...
+func TestSnapshotReleased(t *testing.T, db Database) {
+	key := []byte("hello")
+	value := []byte("world")
+
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := NewSnapshot(db)
+	if err != nil {
+		t.Fatalf("Unexpected error on NewSnapshot: %s", err)
+	}
+	if err := snapshot.Release(); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Release: %s", err)
+	} else if err := snapshot.Release(); err != nil {
+		t.Fatalf("Unexpected error on releasing a snapshot twice: %s", err)
+	}
+
+	if _, err := snapshot.Has(key); err != ErrClosed {
+		t.Fatalf("Expected %s on snapshot.Has after release", ErrClosed)
+	} else if _, err := snapshot.Get(key); err != ErrClosed {
+		t.Fatalf("Expected %s on snapshot.Get after release", ErrClosed)
+	}
+
+	iterator := snapshot.NewIterator()
+	defer iterator.Release()
+
+	if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != ErrClosed {
+		t.Fatalf("Expected %s on iterator.Error", ErrClosed)
+	}
+
+	// The database is unaffected by releasing the snapshot
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+}
@@ -115,26 +115,31 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	if db.mem == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return newIterator(db.mem, db.db, start, prefix)
+}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
+// NewSnapshot returns a read-only view of the database's current state,
+// including the changes that haven't been committed. The underlying database
+// must support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
 	}
-	sort.Strings(keys) // Keys need to be in sorted order
-	values := make([]valueDelete, 0, len(keys))
-	for _, key := range keys {
-		values = append(values, db.mem[key])
+	snapshot, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
 	}
-
-	return &iterator{
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
-		keys:     keys,
-		values:   values,
+	mem := make(map[string]valueDelete, len(db.mem))
+	for key, value := range db.mem {
+		mem[key] = value
 	}
+	return &snap{
+		mem:      mem,
+		snapshot: snapshot,
+	}, nil
 }
 
 // Stat implements the database.Database interface
@@ -319,6 +324,106 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 // Inner returns itself
 func (b *batch) Inner() database.Batch { return b }
 
+// snap is a snapshot of the version database. It holds a copy of the changes
+// that hadn't been committed when it was taken, on top of a snapshot of the
+// underlying database.
+type snap struct {
+	lock     sync.RWMutex
+	mem      map[string]valueDelete
+	snapshot database.Snapshot
+}
+
+// Has implements the Snapshot interface
+func (s *snap) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	return s.snapshot.Has(key)
+}
+
+// Get implements the Snapshot interface
+func (s *snap) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return utils.CopyBytes(val.value), nil
+	}
+	return s.snapshot.Get(key)
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snap) NewIterator() database.Iterator { return s.NewIteratorWithStartAndPrefix(nil, nil) }
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(s.mem, s.snapshot, start, prefix)
+}
+
+// Release implements the Snapshot interface
+func (s *snap) Release() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.mem == nil {
+		return nil
+	}
+	s.mem = nil
+	return s.snapshot.Release()
+}
+
+// newIterator returns an iterator over the keys in [mem] and [db] that start
+// with [prefix] and are at least [start]. The values in [mem] take precedence.
+func newIterator(mem map[string]valueDelete, db database.Iteratee, start, prefix []byte) *iterator {
+	startString := string(start)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if strings.HasPrefix(key, prefixString) && key >= startString {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys) // Keys need to be in sorted order
+	values := make([]valueDelete, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, mem[key])
+	}
+
+	return &iterator{
+		Iterator: db.NewIteratorWithStartAndPrefix(start, prefix),
+		keys:     keys,
+		values:   values,
+	}
+}
+
 // iterator walks over both the in memory database and the underlying database
 // at the same time.
 type iterator struct {
@@ -18,6 +18,13 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestSnapshotInterface(t *testing.T) {
+	for _, test := range database.SnapshotTests {
+		baseDB := memdb.New()
+		test(t, New(baseDB))
+	}
+}
+
 func TestIterate(t *testing.T) {
 	baseDB := memdb.New()
 	db := New(baseDB)
@@ -363,3 +370,46 @@ func TestSetDatabaseClosed(t *testing.T) {
 		t.Fatalf("Unexpected database from db.GetDatabase")
 	}
 }
+
+func TestSnapshotUncommitted(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Commit(); err != nil {
+		t.Fatalf("Unexpected error on db.Commit: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := db.NewSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.NewSnapshot: %s", err)
+	}
+	defer snapshot.Release()
+
+	// Committing and aborting changes doesn't affect the snapshot
+	if err := db.Delete(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	} else if err := db.Commit(); err != nil {
+		t.Fatalf("Unexpected error on db.Commit: %s", err)
+	}
+	db.Abort()
+
+	if v, err := snapshot.Get(key1); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(value1, v) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value1)
+	} else if v, err := snapshot.Get(key2); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(value2, v) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value2)
+	}
+}
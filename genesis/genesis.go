@@ -120,7 +120,7 @@ func FromConfig(networkID uint32, config *Config) ([]byte, error) {
 	for _, addr := range config.ParsedFundedAddresses {
 		platformvmArgs.Accounts = append(platformvmArgs.Accounts,
 			platformvm.APIAccount{
-				Address: addr,
+				Address: addr.String(),
 				Balance: json.Uint64(20 * units.KiloAva),
 			},
 		)
@@ -149,7 +149,7 @@ func FromConfig(networkID uint32, config *Config) ([]byte, error) {
 					Weight:    &weight,
 					ID:        validatorID,
 				},
-				Destination: config.ParsedFundedAddresses[i%len(config.ParsedFundedAddresses)],
+				Destination: config.ParsedFundedAddresses[i%len(config.ParsedFundedAddresses)].String(),
 			},
 		)
 	}
@@ -28,6 +28,24 @@ func TestNetworkName(t *testing.T) {
 	}
 }
 
+func TestGetHRP(t *testing.T) {
+	if hrp := GetHRP(MainnetID); hrp != MainnetHRP {
+		t.Fatalf("Wrong HRP. Result: %s ; Expected: %s", hrp, MainnetHRP)
+	}
+	if hrp := GetHRP(CascadeID); hrp != CascadeHRP {
+		t.Fatalf("Wrong HRP. Result: %s ; Expected: %s", hrp, CascadeHRP)
+	}
+	if hrp := GetHRP(LocalID); hrp != LocalHRP {
+		t.Fatalf("Wrong HRP. Result: %s ; Expected: %s", hrp, LocalHRP)
+	}
+	if hrp := GetHRP(4294967295); hrp != "custom4294967295" {
+		t.Fatalf("Wrong HRP. Result: %s ; Expected: %s", hrp, "custom4294967295")
+	}
+	if GetHRP(12345) == GetHRP(12346) {
+		t.Fatalf("Different custom networks should have different HRPs")
+	}
+}
+
 func TestNetworkID(t *testing.T) {
 	id, err := NetworkID(MainnetName)
 	if err != nil {
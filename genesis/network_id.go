@@ -23,6 +23,11 @@ var (
 	CascadeName = "cascade"
 	LocalName   = "local"
 
+	MainnetHRP  = "ava"
+	CascadeHRP  = "cascade"
+	LocalHRP    = "local"
+	FallbackHRP = "custom"
+
 	NetworkIDToNetworkName = map[uint32]string{
 		MainnetID: MainnetName,
 		TestnetID: CascadeName,
@@ -35,6 +40,12 @@ var (
 		LocalName:   LocalID,
 	}
 
+	NetworkIDToHRP = map[uint32]string{
+		MainnetID: MainnetHRP,
+		TestnetID: CascadeHRP,
+		LocalID:   LocalHRP,
+	}
+
 	validNetworkName = regexp.MustCompile(`network-[0-9]+`)
 )
 
@@ -47,6 +58,16 @@ func NetworkName(networkID uint32) string {
 	return fmt.Sprintf("network-%d", networkID)
 }
 
+// GetHRP returns the human readable part of bech32 addresses on the network
+// with ID [networkID]. Networks without their own HRP use FallbackHRP followed
+// by their ID, so that an address on one such network isn't valid on another.
+func GetHRP(networkID uint32) string {
+	if hrp, exists := NetworkIDToHRP[networkID]; exists {
+		return hrp
+	}
+	return FallbackHRP + strconv.FormatUint(uint64(networkID), 10)
+}
+
 // NetworkID returns the ID of the network with name [networkName]
 func NetworkID(networkName string) (uint32, error) {
 	networkName = strings.ToLower(networkName)
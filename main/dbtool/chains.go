@@ -0,0 +1,165 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/gecko/chains"
+	"github.com/ava-labs/gecko/chains/atomic"
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/prefixdb"
+	"github.com/ava-labs/gecko/genesis"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/logging"
+	"github.com/ava-labs/gecko/vms/platformvm"
+)
+
+// The databases the chain manager creates for each chain, prefixed by the
+// chain's ID. See chains.manager.
+var subsystems = []string{
+	"vm",
+	"vertex",
+	"vertex_bootstrapping",
+	"tx_bootstrapping",
+	"bootstrapping",
+}
+
+// The prefix of the node's shared memory database. The memory shared by each
+// pair of chains is prefixed by the pair's shared ID. See node.initSharedMemory.
+const sharedMemory = "shared memory"
+
+// chain is a blockchain whose databases may be in the node's database
+type chain struct {
+	ID      ids.ID
+	Aliases []string
+
+	// The VM and feature extensions the chain runs. Empty if they're unknown.
+	VMID  ids.ID
+	FxIDs []ids.ID
+}
+
+// Name returns the chain's primary alias, or its ID if it has no aliases
+func (c *chain) Name() string {
+	if len(c.Aliases) > 0 {
+		return c.Aliases[0]
+	}
+	return c.ID.String()
+}
+
+// Prefix returns the prefix of the keys of the chain's [subsystem] database
+func (c *chain) Prefix(subsystem string) []byte {
+	return prefixdb.Prefix(c.ID.Bytes(), []byte(subsystem))
+}
+
+// newSharedMemory returns the shared memory of the node whose database is [db]
+func newSharedMemory(db database.Database) *atomic.SharedMemory {
+	sm := &atomic.SharedMemory{}
+	sm.Initialize(logging.NoLog{}, prefixdb.New([]byte(sharedMemory), db))
+	return sm
+}
+
+// genesisChains returns the chains that exist at the genesis of [networkID]
+func genesisChains(networkID uint32) ([]*chain, error) {
+	genesisBytes, err := genesis.Genesis(networkID)
+	if err != nil {
+		return nil, err
+	}
+	g := &platformvm.Genesis{}
+	if err := platformvm.Codec.Unmarshal(genesisBytes, g); err != nil {
+		return nil, err
+	}
+	if err := g.Initialize(); err != nil {
+		return nil, err
+	}
+	_, chainAliases, _, err := genesis.Aliases(networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	// ids.Empty is the platform chain's ID
+	chains := []*chain{{
+		ID:      ids.Empty,
+		Aliases: chainAliases[ids.Empty.Key()],
+		VMID:    platformvm.ID,
+	}}
+	for _, tx := range g.Chains {
+		chains = append(chains, &chain{
+			ID:      tx.ID(),
+			Aliases: chainAliases[tx.ID().Key()],
+			VMID:    tx.VMID,
+			FxIDs:   tx.FxIDs,
+		})
+	}
+	return chains, nil
+}
+
+// createdChains returns [known] followed by the chains the platform chain has
+// created in the node's database [db] that aren't in [known]
+func createdChains(db database.Database, known []*chain) ([]*chain, error) {
+	stored, err := platformvm.StoredChains(chains.VMDB(db, ids.Empty))
+	if err != nil {
+		return nil, err
+	}
+	knownIDs := ids.Set{}
+	for _, c := range known {
+		knownIDs.Add(c.ID)
+	}
+	all := known
+	for _, tx := range stored {
+		if knownIDs.Contains(tx.ID()) {
+			continue
+		}
+		knownIDs.Add(tx.ID())
+		all = append(all, &chain{
+			ID:    tx.ID(),
+			VMID:  tx.VMID,
+			FxIDs: tx.FxIDs,
+		})
+	}
+	return all, nil
+}
+
+// lookupChain returns the chain in [chains] with the ID or alias [name]. If
+// there is no such chain, but [name] is a chain ID, a chain running an unknown
+// VM is returned.
+func lookupChain(chains []*chain, name string) (*chain, error) {
+	for _, c := range chains {
+		if c.ID.String() == name {
+			return c, nil
+		}
+		for _, alias := range c.Aliases {
+			if alias == name {
+				return c, nil
+			}
+		}
+	}
+	chainID, err := ids.FromString(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown chain %q", name)
+	}
+	return &chain{ID: chainID}, nil
+}
+
+// prefixLabels returns the names of the known prefixes of the node's
+// database, keyed by prefix
+func prefixLabels(chains []*chain) map[string]string {
+	labels := map[string]string{
+		string(prefixdb.Prefix([]byte("keystore"), []byte("users"))): "keystore/users",
+		string(prefixdb.Prefix([]byte("keystore"), []byte("bcs"))):   "keystore/bcs",
+		string(prefixdb.Prefix([]byte("uptime"))):                    "uptime",
+		string(prefixdb.Prefix([]byte("mempool"))):                   "mempool",
+	}
+	sm := newSharedMemory(nil)
+	for i, c := range chains {
+		for _, subsystem := range subsystems {
+			labels[string(c.Prefix(subsystem))] = fmt.Sprintf("%s/%s", c.Name(), subsystem)
+		}
+		for _, peer := range chains[i+1:] {
+			sharedID := sm.SharedID(c.ID, peer.ID)
+			labels[string(prefixdb.Prefix([]byte(sharedMemory), sharedID.Bytes()))] = fmt.Sprintf("%s/%s-%s", sharedMemory, c.Name(), peer.Name())
+		}
+	}
+	return labels
+}
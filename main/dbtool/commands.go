@@ -0,0 +1,213 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/compressdb"
+	"github.com/ava-labs/gecko/database/prefixdb"
+	"github.com/ava-labs/gecko/utils/hashing"
+)
+
+// The number of value bytes written to an import batch before it's flushed
+const importBatchSize = 1 << 20
+
+var (
+	errUnknownSubsystem = errors.New("unknown subsystem")
+	errKeyExists        = errors.New("database already contains an imported key")
+)
+
+// prefixStats is the number and size of the keys with a prefix
+type prefixStats struct {
+	prefix  string
+	numKeys int
+	size    int
+}
+
+// list writes the prefixes of the keys in [db], along with the number and size
+// of the key/value pairs with each prefix. Keys that are too short to have a
+// prefix are listed as top-level.
+func list(w io.Writer, db database.Database, labels map[string]string) error {
+	it := db.NewIterator()
+	defer it.Release()
+
+	topLevel := &prefixStats{}
+	prefixes := []*prefixStats{}
+	for it.Next() {
+		key := it.Key()
+		stats := topLevel
+		if len(key) > hashing.HashLen {
+			prefix := string(key[:hashing.HashLen])
+			// Keys are sorted, so keys with the same prefix are adjacent
+			if len(prefixes) == 0 || prefixes[len(prefixes)-1].prefix != prefix {
+				prefixes = append(prefixes, &prefixStats{prefix: prefix})
+			}
+			stats = prefixes[len(prefixes)-1]
+		}
+		stats.numKeys++
+		stats.size += len(key) + len(it.Value())
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	if topLevel.numKeys > 0 {
+		fmt.Fprintf(w, "%-64s %10d keys %14d bytes\n", "top-level", topLevel.numKeys, topLevel.size)
+	}
+	for _, stats := range prefixes {
+		label, ok := labels[stats.prefix]
+		if !ok {
+			label = "unknown"
+		}
+		fmt.Fprintf(w, "%x %10d keys %14d bytes  %s\n", stats.prefix, stats.numKeys, stats.size, label)
+	}
+	return nil
+}
+
+// dump writes the keys and values of [c]'s [subsystem] database, or of all its
+// databases if [subsystem] is empty. Values written by a compressing database
+// are decompressed, and values of the VM's database are decoded if the VM's
+// codec is known. The memory [c] shares with each of [chains] is dumped as the
+// "shared memory" subsystem.
+func dump(w io.Writer, db database.Database, chains []*chain, c *chain, subsystem string) error {
+	names := subsystems
+	switch {
+	case subsystem == sharedMemory:
+		return dumpSharedMemory(w, db, chains, c)
+	case subsystem != "":
+		names = []string{subsystem}
+		if !contains(subsystems, subsystem) {
+			return fmt.Errorf("%w %q", errUnknownSubsystem, subsystem)
+		}
+	}
+	d, err := newDecoder(c)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		subsystemDB := compressdb.New(0, prefixdb.New([]byte(name), prefixdb.New(c.ID.Bytes(), db)))
+		it := subsystemDB.NewIterator()
+		for it.Next() {
+			fmt.Fprintf(w, "%s/%s %x\n", c.Name(), name, it.Key())
+			if d != nil && name == "vm" {
+				if decoded, ok := d.Decode(it.Value()); ok {
+					fmt.Fprintf(w, "\t%s\n", decoded)
+					continue
+				}
+			}
+			fmt.Fprintf(w, "\t%x\n", it.Value())
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+	}
+	if subsystem == "" {
+		return dumpSharedMemory(w, db, chains, c)
+	}
+	return nil
+}
+
+// dumpSharedMemory writes the keys and values of the memory [c] shares with
+// each of [chains]
+func dumpSharedMemory(w io.Writer, db database.Database, chains []*chain, c *chain) error {
+	sm := newSharedMemory(db)
+	for _, peer := range chains {
+		if peer.ID.Equals(c.ID) {
+			continue
+		}
+		sharedID := sm.SharedID(c.ID, peer.ID)
+		it := sm.GetDatabase(sharedID).NewIterator()
+		for it.Next() {
+			fmt.Fprintf(w, "%s/%s/%s %x\n\t%x\n", c.Name(), sharedMemory, peer.Name(), it.Key(), it.Value())
+		}
+		err := it.Error()
+		it.Release()
+		sm.ReleaseDatabase(sharedID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// export writes the key/value pairs of [c]'s databases to an export file
+// written to [w], and returns how many were written
+func export(w io.Writer, db database.Database, c *chain) (int, error) {
+	e, err := newExporter(w)
+	if err != nil {
+		return 0, err
+	}
+	numExported := 0
+	for _, name := range subsystems {
+		it := db.NewIteratorWithPrefix(c.Prefix(name))
+		n, err := e.Export(it)
+		it.Release()
+		numExported += n
+		if err != nil {
+			return numExported, err
+		}
+	}
+	return numExported, e.Flush()
+}
+
+// importExport writes the key/value pairs of the export file [r] to [db], and
+// returns how many were written. Nothing is written if the file is invalid or
+// if [db] already contains any of its keys. The keys are written in batches of
+// about importBatchSize bytes, so if a write fails, the keys written before it
+// stay in [db].
+func importExport(r io.ReadSeeker, db database.Database) (int, error) {
+	err := readExport(r, func(key, _ []byte) error {
+		has, err := db.Has(key)
+		if err != nil {
+			return err
+		}
+		if has {
+			return fmt.Errorf("%w: 0x%x", errKeyExists, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	numImported := 0
+	batch := db.NewBatch()
+	err = readExport(r, func(key, value []byte) error {
+		if err := batch.Put(key, value); err != nil {
+			return err
+		}
+		numImported++
+		if batch.ValueSize() < importBatchSize {
+			return nil
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		return nil
+	})
+	if err != nil {
+		return numImported, err
+	}
+	return numImported, batch.Write()
+}
+
+func contains(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
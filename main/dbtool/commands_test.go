@@ -0,0 +1,296 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/gecko/chains"
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/prefixdb"
+	"github.com/ava-labs/gecko/genesis"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/vms/avm"
+	"github.com/ava-labs/gecko/vms/components/state"
+	"github.com/ava-labs/gecko/vms/platformvm"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+// newChainDB returns the database of [c]'s [subsystem], laid out as the chain
+// manager lays it out in [db]
+func newChainDB(db database.Database, c *chain, subsystem string) database.Database {
+	return prefixdb.New([]byte(subsystem), prefixdb.New(c.ID.Bytes(), db))
+}
+
+func TestExportImport(t *testing.T) {
+	c := &chain{ID: ids.NewID([32]byte{1})}
+	other := &chain{ID: ids.NewID([32]byte{2})}
+
+	db := memdb.New()
+	if err := newChainDB(db, c, "vm").Put([]byte("vm key"), []byte("vm value")); err != nil {
+		t.Fatal(err)
+	}
+	if err := newChainDB(db, c, "bootstrapping").Put([]byte("job"), []byte("job value")); err != nil {
+		t.Fatal(err)
+	}
+	if err := newChainDB(db, other, "vm").Put([]byte("other key"), []byte("other value")); err != nil {
+		t.Fatal(err)
+	}
+
+	exportFile := &bytes.Buffer{}
+	if numExported, err := export(exportFile, db, c); err != nil {
+		t.Fatal(err)
+	} else if numExported != 2 {
+		t.Fatalf("Expected 2 keys to be exported but got %d", numExported)
+	}
+
+	importedDB := memdb.New()
+	if numImported, err := importExport(bytes.NewReader(exportFile.Bytes()), importedDB); err != nil {
+		t.Fatal(err)
+	} else if numImported != 2 {
+		t.Fatalf("Expected 2 keys to be imported but got %d", numImported)
+	}
+
+	if value, err := newChainDB(importedDB, c, "vm").Get([]byte("vm key")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(value, []byte("vm value")) {
+		t.Fatalf("Expected value %q but got %q", "vm value", value)
+	}
+	if value, err := newChainDB(importedDB, c, "bootstrapping").Get([]byte("job")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(value, []byte("job value")) {
+		t.Fatalf("Expected value %q but got %q", "job value", value)
+	}
+	if has, err := newChainDB(importedDB, other, "vm").Has([]byte("other key")); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatal("Other chains shouldn't be exported")
+	}
+
+	// Importing again would overwrite the imported keys
+	if _, err := importExport(bytes.NewReader(exportFile.Bytes()), importedDB); !errors.Is(err, errKeyExists) {
+		t.Fatalf("Expected %s but got %v", errKeyExists, err)
+	}
+}
+
+func TestImportInvalid(t *testing.T) {
+	db := memdb.New()
+	if _, err := importExport(strings.NewReader("not an export"), db); err != errInvalidHeader {
+		t.Fatalf("Expected %s but got %v", errInvalidHeader, err)
+	}
+
+	exportFile := &bytes.Buffer{}
+	e, err := newExporter(exportFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.write([]byte("key")); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := importExport(bytes.NewReader(exportFile.Bytes()), db); err != errTruncated {
+		t.Fatalf("Expected %s but got %v", errTruncated, err)
+	}
+	if has, err := db.Has([]byte("key")); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatal("Nothing should be imported from an invalid export")
+	}
+}
+
+func TestList(t *testing.T) {
+	chains, err := genesisChains(genesis.LocalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := lookupChain(chains, "X")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := memdb.New()
+	if err := newChainDB(db, x, "vm").Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put([]byte("genesisID"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	if err := prefixdb.New([]byte("uptime"), db).Put([]byte("validator"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	putShared(t, db, chains, "X", "P")
+
+	output := &strings.Builder{}
+	if err := list(output, db, prefixLabels(chains)); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(output.String(), "unknown") {
+		t.Fatalf("Expected every prefix to be labeled:\n%s", output)
+	}
+	if !strings.Contains(output.String(), "  uptime\n") {
+		t.Fatalf("Expected the uptimes to be listed:\n%s", output)
+	}
+	if !strings.Contains(output.String(), "shared memory/") {
+		t.Fatalf("Expected the shared memory to be listed:\n%s", output)
+	}
+	if !strings.Contains(output.String(), "top-level") {
+		t.Fatalf("Expected the top-level keys to be listed:\n%s", output)
+	}
+	if !strings.Contains(output.String(), "X/vm") {
+		t.Fatalf("Expected the X chain's vm database to be listed:\n%s", output)
+	}
+}
+
+func TestCreatedChains(t *testing.T) {
+	known, err := genesisChains(genesis.LocalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := memdb.New()
+	if found, err := createdChains(db, known); err != nil {
+		t.Fatal(err)
+	} else if len(found) != len(known) {
+		t.Fatalf("Expected %d chains but got %d", len(known), len(found))
+	}
+
+	putStoredChains(t, db, &platformvm.CreateChainTx{
+		UnsignedCreateChainTx: platformvm.UnsignedCreateChainTx{
+			NetworkID:   genesis.LocalID,
+			SubnetID:    ids.Empty,
+			ChainName:   "created",
+			VMID:        avm.ID,
+			FxIDs:       []ids.ID{secp256k1fx.ID},
+			GenesisData: []byte{},
+		},
+		ControlSigs: [][65]byte{},
+	})
+	found, err := createdChains(db, known)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != len(known)+1 {
+		t.Fatalf("Expected %d chains but got %d", len(known)+1, len(found))
+	}
+	created := found[len(found)-1]
+	if !created.VMID.Equals(avm.ID) || len(created.FxIDs) != 1 || !created.FxIDs[0].Equals(secp256k1fx.ID) {
+		t.Fatalf("Expected the created chain to run the AVM but got %+v", created)
+	}
+	if _, ok := prefixLabels(found)[string(created.Prefix("vm"))]; !ok {
+		t.Fatal("Expected the created chain's databases to be labeled")
+	}
+
+	// Chains that are already known aren't added again
+	if refound, err := createdChains(db, found); err != nil {
+		t.Fatal(err)
+	} else if len(refound) != len(found) {
+		t.Fatalf("Expected %d chains but got %d", len(found), len(refound))
+	}
+}
+
+func TestDump(t *testing.T) {
+	chains, err := genesisChains(genesis.LocalID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, err := lookupChain(chains, "X")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := newAVMCodec(x.FxIDs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txBytes, err := c.Marshal(&avm.Tx{UnsignedTx: &avm.BaseTx{NetID: genesis.LocalID, BCID: x.ID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := memdb.New()
+	if err := newChainDB(db, x, "vm").Put([]byte("tx"), txBytes); err != nil {
+		t.Fatal(err)
+	}
+	if err := newChainDB(db, x, "vertex").Put([]byte("vertex"), []byte{0xff}); err != nil {
+		t.Fatal(err)
+	}
+
+	output := &strings.Builder{}
+	if err := dump(output, db, chains, x, ""); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output.String(), x.ID.String()) {
+		t.Fatalf("Expected the tx to be decoded:\n%s", output)
+	}
+	if !strings.Contains(output.String(), "X/vertex 766572746578\n\tff\n") {
+		t.Fatalf("Expected the vertex to be dumped as hex:\n%s", output)
+	}
+
+	putShared(t, db, chains, "P", "X")
+	output.Reset()
+	if err := dump(output, db, chains, x, sharedMemory); err != nil {
+		t.Fatal(err)
+	}
+	if output.String() != "X/shared memory/P 736861726564\n\tff\n" {
+		t.Fatalf("Expected only the memory shared with P to be dumped:\n%s", output)
+	}
+
+	if err := dump(output, db, chains, x, "unknown"); !errors.Is(err, errUnknownSubsystem) {
+		t.Fatalf("Expected %s but got %v", errUnknownSubsystem, err)
+	}
+	if _, err := lookupChain(chains, "not a chain"); err == nil {
+		t.Fatal("Expected unknown chains to fail the lookup")
+	}
+}
+
+// storedChainList is the list of chains the platform chain stores
+type storedChainList []*platformvm.CreateChainTx
+
+func (l storedChainList) Bytes() []byte {
+	bytes, _ := platformvm.Codec.Marshal(l)
+	return bytes
+}
+
+// putStoredChains stores [txs] as the chains the platform chain has created,
+// as the platform chain would
+func putStoredChains(t *testing.T, db database.Database, txs ...*platformvm.CreateChainTx) {
+	// The type ID and key the platform chain stores its chains under
+	const chainsTypeID uint64 = 2
+	chainsKey := ids.NewID([32]byte{'c', 'h', 'a', 'i', 'n', 's'})
+
+	s := state.NewState()
+	if err := s.RegisterType(chainsTypeID, func([]byte) (interface{}, error) { return nil, nil }); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(chains.VMDB(db, ids.Empty), chainsTypeID, chainsKey, storedChainList(txs)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// putShared puts a value in the memory shared by the chains named [from] and
+// [to], as chain [from] would
+func putShared(t *testing.T, db database.Database, chains []*chain, from, to string) {
+	fromChain, err := lookupChain(chains, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toChain, err := lookupChain(chains, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sm := newSharedMemory(db)
+	bsm := sm.NewBlockchainSharedMemory(fromChain.ID)
+	sharedDB := bsm.GetDatabase(toChain.ID)
+	defer bsm.ReleaseDatabase(toChain.ID)
+	if err := sharedDB.Put([]byte("shared"), []byte{0xff}); err != nil {
+		t.Fatal(err)
+	}
+}
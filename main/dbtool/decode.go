@@ -0,0 +1,117 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/logging"
+	"github.com/ava-labs/gecko/utils/timer"
+	"github.com/ava-labs/gecko/utils/wrappers"
+	"github.com/ava-labs/gecko/vms/avm"
+	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/components/codec"
+	"github.com/ava-labs/gecko/vms/nftfx"
+	"github.com/ava-labs/gecko/vms/platformvm"
+	"github.com/ava-labs/gecko/vms/propertyfx"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+// fx is a feature extension whose types can be registered with a codec
+type fx interface {
+	Initialize(vm interface{}) error
+}
+
+var fxs = map[[32]byte]func() fx{
+	secp256k1fx.ID.Key(): func() fx { return &secp256k1fx.Fx{} },
+	nftfx.ID.Key():       func() fx { return &nftfx.Fx{} },
+	propertyfx.ID.Key():  func() fx { return &propertyfx.Fx{} },
+}
+
+// decoder decodes the values a VM stores in its database
+type decoder struct {
+	codec codec.Codec
+	// Returns a new value to unmarshal a stored value into. Tried in order.
+	types []func() interface{}
+}
+
+// newDecoder returns a decoder for the values stored by [c]'s VM, or nil if
+// the VM's codec isn't known
+func newDecoder(c *chain) (*decoder, error) {
+	switch {
+	case c.VMID.Equals(platformvm.ID):
+		return &decoder{
+			codec: platformvm.Codec,
+			types: []func() interface{}{
+				func() interface{} { return new(interface{}) },
+			},
+		}, nil
+	case c.VMID.Equals(avm.ID):
+		avmCodec, err := newAVMCodec(c.FxIDs)
+		if err != nil {
+			return nil, err
+		}
+		return &decoder{
+			codec: avmCodec,
+			types: []func() interface{}{
+				func() interface{} { return &avm.Tx{} },
+				func() interface{} { return &ava.UTXO{} },
+			},
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// newAVMCodec returns a codec with the types registered by an AVM running the
+// feature extensions [fxIDs]
+func newAVMCodec(fxIDs []ids.ID) (codec.Codec, error) {
+	c := codec.NewDefault()
+	errs := wrappers.Errs{}
+	errs.Add(
+		c.RegisterType(&avm.BaseTx{}),
+		c.RegisterType(&avm.CreateAssetTx{}),
+		c.RegisterType(&avm.OperationTx{}),
+		c.RegisterType(&avm.ImportTx{}),
+		c.RegisterType(&avm.ExportTx{}),
+	)
+	if errs.Errored() {
+		return nil, errs.Err
+	}
+
+	vm := &secp256k1fx.TestVM{
+		CLK:  &timer.Clock{},
+		Code: c,
+		Log:  logging.NoLog{},
+	}
+	for _, fxID := range fxIDs {
+		newFx, ok := fxs[fxID.Key()]
+		if !ok {
+			return nil, fmt.Errorf("unknown feature extension %s", fxID)
+		}
+		if err := newFx().Initialize(vm); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Decode returns the JSON representation of [value], or false if it can't be
+// decoded
+func (d *decoder) Decode(value []byte) (string, bool) {
+	for _, newValue := range d.types {
+		v := newValue()
+		if err := d.codec.Unmarshal(value, v); err != nil {
+			continue
+		}
+		jsonValue, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		return string(jsonValue), true
+	}
+	return "", false
+}
@@ -0,0 +1,111 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/ava-labs/gecko/database"
+)
+
+// An export file starts with [exportHeader], followed by the exported key/value
+// pairs. Each key and value is prefixed by its length, as a 4 byte big endian
+// integer. Keys are exported as they're stored in the node's database.
+var exportHeader = []byte("gecko db export v1\n")
+
+var (
+	errInvalidHeader = errors.New("file isn't a database export")
+	errTruncated     = errors.New("database export is truncated")
+)
+
+// exporter writes key/value pairs to an export file
+type exporter struct {
+	w *bufio.Writer
+}
+
+// newExporter writes the header of an export file to [w]
+func newExporter(w io.Writer) (*exporter, error) {
+	e := &exporter{w: bufio.NewWriter(w)}
+	_, err := e.w.Write(exportHeader)
+	return e, err
+}
+
+// Export writes the key/value pairs of [it] to the export file and returns how
+// many were written
+func (e *exporter) Export(it database.Iterator) (int, error) {
+	numExported := 0
+	for it.Next() {
+		if err := e.write(it.Key()); err != nil {
+			return numExported, err
+		}
+		if err := e.write(it.Value()); err != nil {
+			return numExported, err
+		}
+		numExported++
+	}
+	return numExported, it.Error()
+}
+
+// Flush writes any buffered data to the underlying writer
+func (e *exporter) Flush() error { return e.w.Flush() }
+
+func (e *exporter) write(b []byte) error {
+	size := [4]byte{}
+	binary.BigEndian.PutUint32(size[:], uint32(len(b)))
+	if _, err := e.w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := e.w.Write(b)
+	return err
+}
+
+// readExport calls [f] with each key/value pair in the export file [r]
+func readExport(r io.Reader, f func(key, value []byte) error) error {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(exportHeader))
+	if _, err := io.ReadFull(br, header); err != nil || !bytes.Equal(header, exportHeader) {
+		return errInvalidHeader
+	}
+	for {
+		key, err := read(br)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		value, err := read(br)
+		if err == io.EOF {
+			return errTruncated
+		}
+		if err != nil {
+			return err
+		}
+		if err := f(key, value); err != nil {
+			return err
+		}
+	}
+}
+
+// read returns the next length prefixed byte slice in [r]. io.EOF is returned
+// if [r] has no more data.
+func read(r io.Reader) ([]byte, error) {
+	size := [4]byte{}
+	if _, err := io.ReadFull(r, size[:]); err == io.ErrUnexpectedEOF {
+		return nil, errTruncated
+	} else if err != nil {
+		return nil, err
+	}
+	b := make([]byte, binary.BigEndian.Uint32(size[:]))
+	if _, err := io.ReadFull(r, b); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, errTruncated
+	} else if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,138 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// dbtool inspects, exports and imports the database of a stopped node.
+//
+// Usage:
+//
+//	dbtool [flags] list
+//	dbtool [flags] dump <chain> [subsystem]
+//	dbtool [flags] export <chain> <file>
+//	dbtool [flags] import <file>
+//
+// Chains are named by ID or alias. Chains created after genesis are read from
+// the platform chain's database and named by ID. The "shared memory" subsystem
+// is the memory a chain shares with each of the other chains.
+//
+// An import is checked before anything is written, but it's written in
+// several batches, so if writing fails, the keys written so far stay in the
+// database.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/ava-labs/gecko/database/leveldb"
+	"github.com/ava-labs/gecko/genesis"
+	"github.com/ava-labs/gecko/utils/constants"
+)
+
+var (
+	errUsage = errors.New("usage: dbtool [flags] list | dump <chain> [subsystem] | export <chain> <file> | import <file>")
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("dbtool", flag.ContinueOnError)
+	networkName := fs.String("network-id", genesis.CascadeName, "Network ID of the node's database")
+	dbDir := fs.String("db-dir", constants.DefaultDBDir, "Database directory of the node")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	networkID, err := genesis.NetworkID(*networkName)
+	if err != nil {
+		return err
+	}
+	chains, err := genesisChains(networkID)
+	if err != nil {
+		return fmt.Errorf("couldn't parse the genesis chains: %w", err)
+	}
+
+	*dbDir = os.ExpandEnv(*dbDir) // parse any env variables
+	dbPath := path.Join(*dbDir, genesis.NetworkName(networkID), constants.DBVersion)
+
+	cmdArgs := fs.Args()
+	if len(cmdArgs) == 0 {
+		return errUsage
+	}
+	cmd, cmdArgs := cmdArgs[0], cmdArgs[1:]
+
+	// Only an import may create the database
+	if cmd != "import" {
+		if _, err := os.Stat(dbPath); err != nil {
+			return fmt.Errorf("couldn't find a database at %s: %w", dbPath, err)
+		}
+	}
+	db, err := leveldb.New(dbPath, 0, 0, 0)
+	if err != nil {
+		return fmt.Errorf("couldn't open the database at %s. Is the node stopped? %w", dbPath, err)
+	}
+	defer db.Close()
+
+	chains, err = createdChains(db, chains)
+	if err != nil {
+		return fmt.Errorf("couldn't read the chains created after genesis: %w", err)
+	}
+
+	switch {
+	case cmd == "list" && len(cmdArgs) == 0:
+		return list(os.Stdout, db, prefixLabels(chains))
+	case cmd == "dump" && (len(cmdArgs) == 1 || len(cmdArgs) == 2):
+		c, err := lookupChain(chains, cmdArgs[0])
+		if err != nil {
+			return err
+		}
+		subsystem := ""
+		if len(cmdArgs) == 2 {
+			subsystem = cmdArgs[1]
+		}
+		return dump(os.Stdout, db, chains, c, subsystem)
+	case cmd == "export" && len(cmdArgs) == 2:
+		c, err := lookupChain(chains, cmdArgs[0])
+		if err != nil {
+			return err
+		}
+		f, err := os.OpenFile(cmdArgs[1], os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return err
+		}
+		numExported, err := export(f, db, c)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("exported %d keys of chain %s to %s\n", numExported, c.Name(), cmdArgs[1])
+		return nil
+	case cmd == "import" && len(cmdArgs) == 1:
+		f, err := os.Open(cmdArgs[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		numImported, err := importExport(f, db)
+		if err != nil {
+			if numImported > 0 {
+				return fmt.Errorf("import failed after writing %d keys into %s: %w", numImported, dbPath, err)
+			}
+			return err
+		}
+		fmt.Printf("imported %d keys into %s\n", numImported, dbPath)
+		return nil
+	default:
+		return errUsage
+	}
+}
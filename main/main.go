@@ -65,12 +65,6 @@ func main() {
 		log.Warn("assertions are enabled. This may slow down execution")
 	}
 
-	mapper := nat.NewDefaultMapper(log, Config.Nat, nat.TCP, "gecko")
-	defer mapper.UnmapAllPorts()
-
-	mapper.MapPort(Config.StakingIP.Port, Config.StakingIP.Port)
-	mapper.MapPort(Config.HTTPPort, Config.HTTPPort)
-
 	log.Debug("initializing node state")
 	// MainNode is a global variable in the node.go file
 	if err := node.MainNode.Initialize(&Config, log, factory); err != nil {
@@ -78,6 +72,17 @@ func main() {
 		return
 	}
 
+	// In a dry run, the node only reports the pending database migrations
+	if Config.DBMigrateDryRun {
+		return
+	}
+
+	mapper := nat.NewDefaultMapper(log, Config.Nat, nat.TCP, "gecko")
+	defer mapper.UnmapAllPorts()
+
+	mapper.MapPort(Config.StakingIP.Port, Config.StakingIP.Port)
+	mapper.MapPort(Config.HTTPPort, Config.HTTPPort)
+
 	log.Debug("Starting servers")
 	if err := node.MainNode.StartConsensusServer(); err != nil {
 		log.Fatal("problem starting servers: %s", err)
@@ -22,21 +22,17 @@ import (
 	"github.com/ava-labs/gecko/snow/networking/router"
 	"github.com/ava-labs/gecko/staking"
 	"github.com/ava-labs/gecko/utils"
+	"github.com/ava-labs/gecko/utils/constants"
 	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/utils/hashing"
 	"github.com/ava-labs/gecko/utils/logging"
 	"github.com/ava-labs/gecko/utils/wrappers"
 )
 
-const (
-	dbVersion = "v0.2.0"
-)
-
 // Results of parsing the CLI
 var (
 	Config                 = node.Config{}
 	Err                    error
-	defaultDbDir           = os.ExpandEnv(filepath.Join("$HOME", ".gecko", "db"))
 	defaultStakingKeyPath  = os.ExpandEnv(filepath.Join("$HOME", ".gecko", "staking", "staker.key"))
 	defaultStakingCertPath = os.ExpandEnv(filepath.Join("$HOME", ".gecko", "staking", "staker.crt"))
 )
@@ -87,7 +83,9 @@ func init() {
 
 	// Database:
 	db := fs.Bool("db-enabled", true, "Turn on persistent storage")
-	dbDir := fs.String("db-dir", defaultDbDir, "Database directory for Ava state")
+	dbDir := fs.String("db-dir", constants.DefaultDBDir, "Database directory for Ava state")
+	dbMigrate := fs.String("db-migrate", "auto", "Database migration mode. auto runs a chain's pending migrations when it's created. dry-run reports the pending migrations of every chain and exits without starting the node. Should be one of {auto, dry-run}")
+	compressedChains := fs.String("db-compressed-chains", "", "Comma separated list of chains, by ID or alias, whose new database values are compressed. Values compressed before are always readable. Example: X,P")
 
 	// IP:
 	consensusIP := fs.String("public-ip", "", "Public IP of this node")
@@ -108,6 +106,10 @@ func init() {
 	fs.BoolVar(&Config.EnableStaking, "staking-tls-enabled", true, "Require TLS to authenticate staking connections")
 	fs.StringVar(&Config.StakingKeyFile, "staking-tls-key-file", defaultStakingKeyPath, "TLS private key for staking")
 	fs.StringVar(&Config.StakingCertFile, "staking-tls-cert-file", defaultStakingCertPath, "TLS certificate for staking")
+	fs.Float64Var(&Config.UptimeRequirement, "uptime-requirement", .6, "Fraction of its staking period a validator must be connected to this node for this node to initially prefer rewarding it")
+
+	// Subnets:
+	whitelistedSubnets := fs.String("whitelisted-subnets", "", "Comma separated list of subnets, other than the default subnet, whose chains this node runs")
 
 	// Plugins:
 	fs.StringVar(&Config.PluginDir, "plugin-dir", "./build/plugins", "Plugin directory for Ava VMs")
@@ -131,6 +133,10 @@ func init() {
 	fs.BoolVar(&Config.MetricsAPIEnabled, "api-metrics-enabled", true, "If true, this node exposes the Metrics API")
 	fs.BoolVar(&Config.HealthAPIEnabled, "api-health-enabled", true, "If true, this node exposes the Health API")
 	fs.BoolVar(&Config.IPCEnabled, "api-ipcs-enabled", false, "If true, IPCs can be opened")
+	addressFormat := fs.String("api-address-format", "cb58", "Format of addresses returned by the AVM and Platform APIs. Should be one of {cb58, bech32}")
+
+	// Indexing:
+	fs.BoolVar(&Config.IndexTxsEnabled, "index-txs-enabled", false, "If true, the AVM indexes accepted transactions by the addresses and assets they reference")
 
 	// Throughput Server
 	throughputPort := fs.Uint("xput-server-port", 9652, "Port of the deprecated throughput test server")
@@ -155,10 +161,53 @@ func init() {
 
 	Config.NetworkID = networkID
 
+	if Config.UptimeRequirement < 0 || Config.UptimeRequirement > 1 {
+		errs.Add(fmt.Errorf("uptime requirement must be in [0, 1] but is %f", Config.UptimeRequirement))
+		return
+	}
+
+	// Address format:
+	switch strings.ToLower(*addressFormat) {
+	case "cb58":
+		Config.Bech32Addresses = false
+	case "bech32":
+		Config.Bech32Addresses = true
+	default:
+		errs.Add(fmt.Errorf("unknown address format %s", *addressFormat))
+		return
+	}
+
+	// Subnets:
+	for _, subnet := range strings.Split(*whitelistedSubnets, ",") {
+		if subnet == "" {
+			continue
+		}
+		subnetID, err := ids.FromString(subnet)
+		if err != nil {
+			errs.Add(fmt.Errorf("couldn't parse whitelisted subnet %q: %w", subnet, err))
+			return
+		}
+		Config.WhitelistedSubnets.Add(subnetID)
+	}
+
 	// DB:
+	switch strings.ToLower(*dbMigrate) {
+	case "auto":
+		Config.DBMigrateDryRun = false
+	case "dry-run":
+		Config.DBMigrateDryRun = true
+	default:
+		errs.Add(fmt.Errorf("unknown db-migrate mode %s", *dbMigrate))
+		return
+	}
+	for _, chain := range strings.Split(*compressedChains, ",") {
+		if chain != "" {
+			Config.CompressedChains = append(Config.CompressedChains, chain)
+		}
+	}
 	if *db {
 		*dbDir = os.ExpandEnv(*dbDir) // parse any env variables
-		dbPath := path.Join(*dbDir, genesis.NetworkName(Config.NetworkID), dbVersion)
+		dbPath := path.Join(*dbDir, genesis.NetworkName(Config.NetworkID), constants.DBVersion)
 		db, err := leveldb.New(dbPath, 0, 0, 0)
 		if err != nil {
 			errs.Add(fmt.Errorf("couldn't create db at %s: %w", dbPath, err))
@@ -149,6 +149,10 @@ type Handshake struct {
 	awaitingLock sync.Mutex
 	awaiting     []*networking.AwaitingConnections
 
+	// Notified when a peer connects or disconnects
+	connectorsLock sync.Mutex
+	connectors     []networking.Connector
+
 	lastHeartbeat int64
 }
 
@@ -317,6 +321,19 @@ func (nm *Handshake) AwaitConnections(awaiting *networking.AwaitingConnections)
 	}
 }
 
+// RegisterConnector notifies [connector] of each peer that finishes or loses its
+// connection to this node. [connector] is immediately notified of the peers
+// that are already connected.
+func (nm *Handshake) RegisterConnector(connector networking.Connector) {
+	nm.connectorsLock.Lock()
+	defer nm.connectorsLock.Unlock()
+
+	nm.connectors = append(nm.connectors, connector)
+	for _, cert := range nm.connections.IDs().List() {
+		connector.Connected(cert)
+	}
+}
+
 func (nm *Handshake) gossipPeerList() {
 	stakers := []ids.ShortID{}
 	nonStakers := []ids.ShortID{}
@@ -503,8 +520,10 @@ func (nm *Handshake) connectedToPeer(conn *C.struct_peernetwork_conn_t, peer sal
 // assumes peer is autofreed
 func (nm *Handshake) disconnectedFromPeer(peer salticidae.PeerID) {
 	cert := ids.ShortID{}
+	isPending := false
 	if pendingCert, exists := nm.pending.GetID(peer); exists {
 		cert = pendingCert
+		isPending = true
 		nm.log.Debug("disconnected from pending peer %s", cert)
 	} else if connectedCert, exists := nm.connections.GetID(peer); exists {
 		cert = connectedCert
@@ -520,6 +539,14 @@ func (nm *Handshake) disconnectedFromPeer(peer salticidae.PeerID) {
 	nm.connections.Remove(peer, cert)
 	nm.numPeers.Set(float64(nm.connections.Len()))
 
+	if !isPending {
+		nm.connectorsLock.Lock()
+		for _, connector := range nm.connectors {
+			connector.Disconnected(cert)
+		}
+		nm.connectorsLock.Unlock()
+	}
+
 	if !nm.enableStaking || nm.vdrs.Contains(cert) {
 		nm.reconnectTimeout.Put(peerID, func() {
 			nm.pending.Remove(peer, cert)
@@ -756,6 +783,12 @@ func version(_msg *C.struct_msg_t, _conn *C.struct_msgnetwork_conn_t, _ unsafe.P
 		HandshakeNet.vdrs.Add(validators.NewValidator(id, 1))
 	}
 
+	HandshakeNet.connectorsLock.Lock()
+	for _, connector := range HandshakeNet.connectors {
+		connector.Connected(id)
+	}
+	HandshakeNet.connectorsLock.Unlock()
+
 	HandshakeNet.awaitingLock.Lock()
 	defer HandshakeNet.awaitingLock.Unlock()
 
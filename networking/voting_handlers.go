@@ -18,7 +18,6 @@ import "C"
 import (
 	"errors"
 	"fmt"
-	"math"
 	"unsafe"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -26,6 +25,7 @@ import (
 	"github.com/ava-labs/salticidae-go"
 
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/engine/common"
 	"github.com/ava-labs/gecko/snow/networking/router"
 	"github.com/ava-labs/gecko/snow/validators"
 	"github.com/ava-labs/gecko/utils/formatting"
@@ -431,7 +431,7 @@ func (s *Voting) gossip(chainID, containerID ids.ID, container []byte) error {
 	}
 
 	build := Builder{}
-	msg, err := build.Put(chainID, math.MaxUint32, containerID, container)
+	msg, err := build.Put(chainID, common.GossipRequestID, containerID, container)
 	if err != nil {
 		return fmt.Errorf("attempted to pack too large of a Put message.\nContainer length: %d", len(container))
 	}
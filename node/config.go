@@ -5,6 +5,7 @@ package node
 
 import (
 	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/nat"
 	"github.com/ava-labs/gecko/snow/consensus/avalanche"
 	"github.com/ava-labs/gecko/snow/networking/router"
@@ -38,9 +39,23 @@ type Config struct {
 	StakingKeyFile  string
 	StakingCertFile string
 
+	// Fraction of its staking period a validator must be connected to this node
+	// for this node to initially prefer rewarding it
+	UptimeRequirement float64
+
 	// Bootstrapping configuration
 	BootstrapPeers []*Peer
 
+	// Subnets, other than the default subnet, whose chains this node runs
+	WhitelistedSubnets ids.Set
+
+	// IDs or aliases of the chains whose new database values are compressed
+	CompressedChains []string
+
+	// If true, the pending database migrations of every chain are reported,
+	// and the node isn't started
+	DBMigrateDryRun bool
+
 	// HTTP configuration
 	HTTPHost      string
 	HTTPPort      uint16
@@ -70,6 +85,12 @@ type Config struct {
 	// IPCEnabled configuration
 	IPCEnabled bool
 
+	// Indexing configuration
+	IndexTxsEnabled bool
+
+	// If true, the APIs return addresses in bech32 rather than CB58
+	Bech32Addresses bool
+
 	// Router that is used to handle incoming consensus messages
 	ConsensusRouter router.Router
 }
@@ -31,12 +31,14 @@ import (
 	"github.com/ava-labs/gecko/chains"
 	"github.com/ava-labs/gecko/chains/atomic"
 	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/database/prefixdb"
 	"github.com/ava-labs/gecko/genesis"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/networking"
 	"github.com/ava-labs/gecko/networking/xputtest"
 	"github.com/ava-labs/gecko/snow/triggers"
+	"github.com/ava-labs/gecko/snow/uptime"
 	"github.com/ava-labs/gecko/snow/validators"
 	"github.com/ava-labs/gecko/utils"
 	"github.com/ava-labs/gecko/utils/hashing"
@@ -113,6 +115,9 @@ type Node struct {
 	// current validators of the network
 	vdrs validators.Manager
 
+	// records when this node was connected to each of its peers
+	uptimes *uptime.Tracker
+
 	// APIs that handle client messages
 	// TODO: Remove
 	Issuer     *xputtest.Issuer
@@ -225,6 +230,8 @@ func (n *Node) initValidatorNet() error {
 		/*networkID=*/ n.Config.NetworkID,
 	)
 
+	n.uptimes = uptime.NewTracker()
+	n.ValidatorAPI.RegisterConnector(n.uptimes)
 	return nil
 }
 
@@ -390,6 +397,10 @@ func (n *Node) initVMManager() error {
 		n.vmManager.RegisterVMFactory(avm.ID, &avm.Factory{
 			AVA:      avaAssetID,
 			Platform: ids.Empty,
+			IndexTxs: n.Config.IndexTxsEnabled,
+
+			HRP:             genesis.GetHRP(n.Config.NetworkID),
+			Bech32Addresses: n.Config.Bech32Addresses,
 		}),
 		n.vmManager.RegisterVMFactory(genesis.EVMID, &rpcchainvm.Factory{Path: path.Join(n.Config.PluginDir, "evm")}),
 		n.vmManager.RegisterVMFactory(spdagvm.ID, &spdagvm.Factory{TxFee: n.Config.AvaTxFee}),
@@ -447,6 +458,14 @@ func (n *Node) initChains() error {
 			StakingEnabled: n.Config.EnableStaking,
 			AVA:            avaAssetID,
 			AVM:            createAVMTx.ID(),
+
+			HRP:             genesis.GetHRP(n.Config.NetworkID),
+			Bech32Addresses: n.Config.Bech32Addresses,
+
+			Uptimes:           n.uptimes,
+			UptimeDB:          prefixdb.New([]byte("uptime"), n.DB),
+			MempoolDB:         prefixdb.New([]byte("mempool"), n.DB),
+			UptimeRequirement: n.Config.UptimeRequirement,
 		},
 	)
 	if err != nil {
@@ -500,6 +519,8 @@ func (n *Node) initAPIServer() {
 func (n *Node) initChainManager() {
 	n.chainManager = chains.New(
 		n.Config.EnableStaking,
+		n.Config.WhitelistedSubnets,
+		n.Config.CompressedChains,
 		n.Log,
 		n.LogFactory,
 		n.vmManager,
@@ -521,6 +542,47 @@ func (n *Node) initChainManager() {
 	n.chainManager.AddRegistrant(&n.APIServer)
 }
 
+// reportMigrations logs the database migrations that haven't been run on the
+// platform chain and the chains it has created. Nothing is migrated.
+func (n *Node) reportMigrations() error {
+	vmSchemas := map[[32]byte]*migration.Schema{
+		platformvm.ID.Key(): (&platformvm.VM{}).Schema(),
+		avm.ID.Key():        (&avm.VM{}).Schema(),
+	}
+
+	chainIDs := []ids.ID{ids.Empty}
+	chainVMs := []ids.ID{platformvm.ID}
+	createdChains, err := platformvm.StoredChains(chains.VMDB(n.DB, ids.Empty))
+	if err != nil {
+		return fmt.Errorf("problem reading the platform chain's chains: %w", err)
+	}
+	for _, chain := range createdChains {
+		chainIDs = append(chainIDs, chain.ID())
+		chainVMs = append(chainVMs, chain.VMID)
+	}
+
+	numPending := 0
+	for i, chainID := range chainIDs {
+		pending, err := chains.PendingMigrations(n.DB, chainID, vmSchemas[chainVMs[i].Key()])
+		if err != nil {
+			return fmt.Errorf("problem checking chain %s's migrations: %w", chainID, err)
+		}
+		for _, pendingMigration := range pending {
+			n.Log.Info("migration %d of schema %s would run on chain %s's %s database, which is at version %d: %s",
+				pendingMigration.Version,
+				pendingMigration.Schema,
+				pendingMigration.ChainID,
+				pendingMigration.Database,
+				pendingMigration.CurrentVersion,
+				pendingMigration.Description,
+			)
+		}
+		numPending += len(pending)
+	}
+	n.Log.Info("%d database migrations are pending on %d chains", numPending, len(chainIDs))
+	return nil
+}
+
 // initSharedMemory initializes the shared memory for cross chain interation
 func (n *Node) initSharedMemory() {
 	n.Log.Info("initializing SharedMemory")
@@ -632,6 +694,10 @@ func (n *Node) Initialize(Config *Config, logger logging.Logger, logFactory logg
 		return fmt.Errorf("problem initializing database: %w", err)
 	}
 
+	if n.Config.DBMigrateDryRun { // Report the pending database migrations
+		return n.reportMigrations()
+	}
+
 	if err = n.initNodeID(); err != nil { // Derive this node's ID
 		return fmt.Errorf("problem initializing staker ID: %w", err)
 	}
@@ -37,8 +37,9 @@ fi
 
 go build -o "$PREFIX/ava" "$GECKO_PATH/main/"*.go
 go build -o "$PREFIX/xputtest" "$GECKO_PATH/xputtest/"*.go
+go build -o "$PREFIX/dbtool" "$GECKO_PATH/main/dbtool/"*.go
 go build -o "$PLUGIN_PREFIX/evm" "$CORETH_PATH/plugin/"*.go
-if [[ -f "$PREFIX/ava" && -f "$PREFIX/xputtest" && -f "$PLUGIN_PREFIX/evm" ]]; then
+if [[ -f "$PREFIX/ava" && -f "$PREFIX/xputtest" && -f "$PREFIX/dbtool" && -f "$PLUGIN_PREFIX/evm" ]]; then
         echo "Build Successful" 
 else
         echo "Build failure" 
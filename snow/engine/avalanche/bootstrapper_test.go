@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/database/prefixdb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
@@ -63,8 +64,8 @@ func newConfig(t *testing.T) (BootstrapConfig, ids.ShortID, *common.SenderTest,
 	timeouts.Initialize(0)
 	router.Initialize(ctx.Log, timeouts, time.Hour)
 
-	vtxBlocker, _ := queue.New(prefixdb.New([]byte("vtx"), db))
-	txBlocker, _ := queue.New(prefixdb.New([]byte("tx"), db))
+	vtxBlocker, _ := queue.New(prefixdb.New([]byte("vtx"), db), migration.NewSchema("queue"))
+	txBlocker, _ := queue.New(prefixdb.New([]byte("tx"), db), migration.NewSchema("queue"))
 
 	commonConfig := common.Config{
 		Context:    ctx,
@@ -7,6 +7,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/snow/consensus/avalanche"
 	"github.com/ava-labs/gecko/snow/consensus/snowball"
 	"github.com/ava-labs/gecko/snow/engine/common"
@@ -14,8 +15,8 @@ import (
 )
 
 func DefaultConfig() Config {
-	vtxBlocked, _ := queue.New(memdb.New())
-	txBlocked, _ := queue.New(memdb.New())
+	vtxBlocked, _ := queue.New(memdb.New(), migration.NewSchema("queue"))
+	txBlocked, _ := queue.New(memdb.New(), migration.NewSchema("queue"))
 	return Config{
 		BootstrapConfig: BootstrapConfig{
 			Config:     common.DefaultConfigTest(),
@@ -48,6 +48,10 @@ func (t *Transitive) Initialize(config Config) {
 	t.polls.log = config.Context.Log
 	t.polls.numPolls = t.numPolls
 	t.polls.m = make(map[uint32]poll)
+
+	if vm, ok := config.VM.(GossipingDAGVM); ok {
+		vm.SetGossiper(config.Sender)
+	}
 }
 
 func (t *Transitive) finishBootstrapping() {
@@ -114,6 +118,14 @@ func (t *Transitive) Put(vdr ids.ShortID, requestID uint32, vtxID ids.ID, vtxByt
 
 	vtx, err := t.Config.State.ParseVertex(vtxBytes)
 	if err != nil {
+		// Gossiped containers may be transactions rather than vertices
+		if vm, ok := t.Config.VM.(GossipingDAGVM); ok && requestID == common.GossipRequestID {
+			if err := vm.IssueGossipedTx(vtxBytes); err != nil {
+				t.Config.Context.Log.Debug("Dropping gossiped container %s from %s due to %s", vtxID, vdr, err)
+			}
+			return
+		}
+
 		t.Config.Context.Log.Debug("ParseVertex failed due to %s for block:\n%s",
 			err,
 			formatting.DumpBytes{Bytes: vtxBytes})
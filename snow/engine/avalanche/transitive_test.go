@@ -2975,3 +2975,69 @@ func TestEngineAggressivePolling(t *testing.T) {
 		t.Fatalf("should have issued one pull query")
 	}
 }
+
+type gossipingVMTest struct {
+	*VMTest
+
+	gossiper        common.Gossiper
+	issueGossipedTx func([]byte) error
+}
+
+func (vm *gossipingVMTest) SetGossiper(gossiper common.Gossiper) { vm.gossiper = gossiper }
+func (vm *gossipingVMTest) IssueGossipedTx(tx []byte) error      { return vm.issueGossipedTx(tx) }
+
+func TestEngineGossipedTx(t *testing.T) {
+	config := DefaultConfig()
+
+	sender := &common.SenderTest{}
+	sender.T = t
+	config.Sender = sender
+
+	sender.Default(true)
+
+	st := &stateTest{t: t}
+	config.State = st
+
+	vm := &gossipingVMTest{VMTest: &VMTest{}}
+	vm.T = t
+	config.VM = vm
+
+	vm.Default(true)
+
+	te := &Transitive{}
+	te.Initialize(config)
+	te.finishBootstrapping()
+
+	if vm.gossiper != sender {
+		t.Fatalf("Should have provided the sender as the VM's gossiper")
+	}
+
+	vdr := validators.GenerateRandomValidator(1)
+	txID := GenerateID()
+	txBytes := []byte{1, 2, 3}
+
+	st.parseVertex = func(b []byte) (avalanche.Vertex, error) { return nil, errFailedParsing }
+
+	issued := new(bool)
+	vm.issueGossipedTx = func(b []byte) error {
+		*issued = true
+		if !bytes.Equal(b, txBytes) {
+			t.Fatalf("Wrong tx bytes")
+		}
+		return nil
+	}
+
+	te.Put(vdr.ID(), common.GossipRequestID, txID, txBytes)
+
+	if !*issued {
+		t.Fatalf("Should have issued the gossiped tx")
+	}
+
+	// Containers that were requested must not be treated as transactions
+	*issued = false
+	te.Put(vdr.ID(), 0, txID, txBytes)
+
+	if *issued {
+		t.Fatalf("Shouldn't have issued a requested container as a tx")
+	}
+}
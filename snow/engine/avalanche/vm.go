@@ -23,3 +23,15 @@ type DAGVM interface {
 	// Retrieve a transaction that was submitted previously
 	GetTx(ids.ID) (snowstorm.Tx, error)
 }
+
+// GossipingDAGVM defines a DAGVM that gossips the transactions issued to it,
+// and accepts transactions that are gossiped to it by peers
+type GossipingDAGVM interface {
+	DAGVM
+
+	// Provide the gossiper that the VM should send transactions with
+	SetGossiper(common.Gossiper)
+
+	// Issue a transaction that was gossiped by a peer
+	IssueGossipedTx(tx []byte) error
+}
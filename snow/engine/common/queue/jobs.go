@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/database/versiondb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/utils/wrappers"
@@ -27,8 +28,14 @@ type Jobs struct {
 	state prefixedState
 }
 
-// New ...
-func New(db database.Database) (*Jobs, error) {
+// New returns a job queue stored in [db]. [schema] is the versioned layout of
+// the queue's database. Its migrations that haven't been run on [db] are run
+// first.
+func New(db database.Database, schema *migration.Schema) (*Jobs, error) {
+	if _, err := schema.Migrate(db); err != nil {
+		return nil, err
+	}
+
 	jobs := &Jobs{
 		baseDB: db,
 		db:     versiondb.New(db),
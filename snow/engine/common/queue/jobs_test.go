@@ -7,7 +7,9 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/ids"
 )
 
@@ -15,7 +17,7 @@ func TestNew(t *testing.T) {
 	parser := &TestParser{T: t}
 	db := memdb.New()
 
-	jobs, err := New(db)
+	jobs, err := New(db, migration.NewSchema("queue"))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -33,7 +35,7 @@ func TestPushPop(t *testing.T) {
 	parser := &TestParser{T: t}
 	db := memdb.New()
 
-	jobs, err := New(db)
+	jobs, err := New(db, migration.NewSchema("queue"))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -58,7 +60,7 @@ func TestPushPop(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	jobs, err = New(db)
+	jobs, err = New(db, migration.NewSchema("queue"))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -98,7 +100,7 @@ func TestExecute(t *testing.T) {
 	parser := &TestParser{T: t}
 	db := memdb.New()
 
-	jobs, err := New(db)
+	jobs, err := New(db, migration.NewSchema("queue"))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -186,7 +188,7 @@ func TestDuplicatedPush(t *testing.T) {
 	parser := &TestParser{T: t}
 	db := memdb.New()
 
-	jobs, err := New(db)
+	jobs, err := New(db, migration.NewSchema("queue"))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -215,7 +217,7 @@ func TestDuplicatedPush(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	jobs, err = New(db)
+	jobs, err = New(db, migration.NewSchema("queue"))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -250,3 +252,24 @@ func TestDuplicatedPush(t *testing.T) {
 		t.Fatalf("Shouldn't have a container ready to pop")
 	}
 }
+
+func TestNewMigrates(t *testing.T) {
+	db := memdb.New()
+	if err := db.Put([]byte("old"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := migration.NewSchema("queue")
+	schema.Register("drop the old key", func(db database.Database) error {
+		return db.Delete([]byte("old"))
+	})
+
+	if _, err := New(db, schema); err != nil {
+		t.Fatal(err)
+	}
+	if has, err := db.Has([]byte("old")); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("Creating the queue should have run the schema's migrations")
+	}
+}
@@ -4,9 +4,14 @@
 package common
 
 import (
+	"math"
+
 	"github.com/ava-labs/gecko/ids"
 )
 
+// GossipRequestID is the request ID that gossiped containers are sent with
+const GossipRequestID = math.MaxUint32
+
 // Sender defines how a consensus engine sends messages and requests to other
 // validators
 type Sender interface {
@@ -35,7 +35,7 @@ type bootstrapper struct {
 
 	pending    ids.Set
 	finished   bool
-	onFinished func()
+	onFinished func() error
 }
 
 // Initialize this engine.
@@ -79,7 +79,9 @@ func (b *bootstrapper) ForceAccepted(acceptedContainerIDs ids.Set) {
 	if numPending := b.pending.Len(); numPending == 0 {
 		// TODO: This typically indicates bootstrapping has failed, so this
 		// should be handled appropriately
-		b.finish()
+		if err := b.finish(); err != nil {
+			b.BootstrapConfig.Context.Log.Error("Bootstrapping couldn't finish due to: %s", err)
+		}
 	}
 }
 
@@ -155,7 +157,9 @@ func (b *bootstrapper) addBlock(blk snowman.Block) {
 	b.storeBlock(blk)
 
 	if numPending := b.pending.Len(); numPending == 0 {
-		b.finish()
+		if err := b.finish(); err != nil {
+			b.BootstrapConfig.Context.Log.Error("Bootstrapping couldn't finish due to: %s", err)
+		}
 	}
 }
 
@@ -191,20 +195,25 @@ func (b *bootstrapper) storeBlock(blk snowman.Block) {
 	b.numPendingRequests.Set(float64(numPending))
 }
 
-func (b *bootstrapper) finish() {
+// finish accepts the fetched blocks and starts consensus. If consensus can't
+// be started, bootstrapping isn't finished.
+func (b *bootstrapper) finish() error {
 	if b.finished {
-		return
+		return nil
 	}
 
 	b.executeAll(b.Blocked, b.numBlocked)
 
 	// Start consensus
-	b.onFinished()
+	if err := b.onFinished(); err != nil {
+		return err
+	}
 	b.finished = true
 
 	if b.Bootstrapped != nil {
 		b.Bootstrapped()
 	}
+	return nil
 }
 
 func (b *bootstrapper) executeAll(jobs *queue.Jobs, numBlocked prometheus.Gauge) {
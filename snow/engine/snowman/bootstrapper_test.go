@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
 	"github.com/ava-labs/gecko/snow/choices"
@@ -57,7 +58,7 @@ func newConfig(t *testing.T) (BootstrapConfig, ids.ShortID, *common.SenderTest,
 	timeouts.Initialize(0)
 	router.Initialize(ctx.Log, timeouts, time.Hour)
 
-	blocker, _ := queue.New(db)
+	blocker, _ := queue.New(db, migration.NewSchema("queue"))
 
 	commonConfig := common.Config{
 		Context:    ctx,
@@ -142,7 +143,7 @@ func TestBootstrapperSingleFrontier(t *testing.T) {
 	}
 
 	finished := new(bool)
-	bs.onFinished = func() { *finished = true }
+	bs.onFinished = func() error { *finished = true; return nil }
 
 	bs.Put(peerID, *reqID, blkID1, blkBytes1)
 
@@ -236,7 +237,7 @@ func TestBootstrapperUnknownByzantineResponse(t *testing.T) {
 	}
 
 	finished := new(bool)
-	bs.onFinished = func() { *finished = true }
+	bs.onFinished = func() error { *finished = true; return nil }
 
 	bs.Put(peerID, *requestID, blkID2, blkBytes2)
 	bs.Put(peerID, *requestID, blkID1, blkBytes1)
@@ -336,7 +337,7 @@ func TestBootstrapperDependency(t *testing.T) {
 	blk1.status = choices.Processing
 
 	finished := new(bool)
-	bs.onFinished = func() { *finished = true }
+	bs.onFinished = func() error { *finished = true; return nil }
 
 	bs.Put(peerID, *requestID, blkID1, blkBytes1)
 
@@ -466,7 +467,7 @@ func TestBootstrapperPartialFetch(t *testing.T) {
 	}
 
 	sender.CantGet = false
-	bs.onFinished = func() {}
+	bs.onFinished = func() error { return nil }
 
 	bs.ForceAccepted(acceptedIDs)
 
@@ -572,7 +573,7 @@ func TestBootstrapperWrongIDByzantineResponse(t *testing.T) {
 	}
 
 	finished := new(bool)
-	bs.onFinished = func() { *finished = true }
+	bs.onFinished = func() error { *finished = true; return nil }
 
 	bs.Put(peerID, *requestID, blkID1, blkBytes1)
 
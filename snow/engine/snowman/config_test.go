@@ -7,6 +7,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/snow/consensus/snowball"
 	"github.com/ava-labs/gecko/snow/consensus/snowman"
 	"github.com/ava-labs/gecko/snow/engine/common"
@@ -14,7 +15,7 @@ import (
 )
 
 func DefaultConfig() Config {
-	blocked, _ := queue.New(memdb.New())
+	blocked, _ := queue.New(memdb.New(), migration.NewSchema("queue"))
 	return Config{
 		BootstrapConfig: BootstrapConfig{
 			Config:  common.DefaultConfigTest(),
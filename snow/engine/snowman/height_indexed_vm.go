@@ -0,0 +1,37 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"errors"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+var (
+	// ErrHeightIndexedVMNotImplemented is returned by VMs that look like a
+	// HeightIndexedChainVM but can't index their blocks by height. For example,
+	// the client of a plugin VM that doesn't implement HeightIndexedChainVM.
+	ErrHeightIndexedVMNotImplemented = errors.New("vm doesn't implement the HeightIndexedChainVM interface")
+
+	// ErrIndexIncomplete is returned if the height index doesn't yet contain
+	// every accepted block
+	ErrIndexIncomplete = errors.New("height index is incomplete")
+)
+
+// HeightIndexedChainVM is implemented by ChainVMs that index their accepted
+// blocks by height. The genesis block has height 0.
+type HeightIndexedChainVM interface {
+	// VerifyHeightIndex returns nil if the height index can be queried.
+	//
+	// Returns ErrHeightIndexedVMNotImplemented if the VM doesn't index its
+	// blocks by height, or ErrIndexIncomplete if the index isn't yet complete.
+	VerifyHeightIndex() error
+
+	// GetBlockIDAtHeight returns the ID of the block that was accepted at
+	// [height].
+	//
+	// If no block has been accepted at [height], an error should be returned.
+	GetBlockIDAtHeight(height uint64) (ids.ID, error)
+}
@@ -56,11 +56,23 @@ func (t *Transitive) Initialize(config Config) {
 	t.polls.numPolls = t.numPolls
 	t.polls.alpha = t.Params.Alpha
 	t.polls.m = make(map[uint32]poll)
+
+	if vm, ok := config.VM.(GossipingChainVM); ok {
+		vm.SetGossiper(config.Sender)
+	}
 }
 
 // when bootstrapping is finished, this will be called. This initializes the
 // consensus engine with the last accepted block.
-func (t *Transitive) finishBootstrapping() {
+func (t *Transitive) finishBootstrapping() error {
+	// if the VM indexes its blocks by height, report whether the index can be
+	// used. The index is optional, so consensus starts either way.
+	if vm, ok := t.Config.VM.(HeightIndexedChainVM); ok {
+		if err := vm.VerifyHeightIndex(); err != nil && err != ErrHeightIndexedVMNotImplemented {
+			t.Config.Context.Log.Warn("accepted blocks can't be looked up by height due to: %s", err)
+		}
+	}
+
 	// set the bootstrapped mark to switch consensus modes
 	t.bootstrapped = true
 
@@ -74,7 +86,7 @@ func (t *Transitive) finishBootstrapping() {
 	tail, err := t.Config.VM.GetBlock(tailID)
 	if err != nil {
 		t.Config.Context.Log.Error("Failed to get last accepted block due to: %s", err)
-		return
+		return nil
 	}
 
 	switch blk := tail.(type) {
@@ -90,6 +102,7 @@ func (t *Transitive) finishBootstrapping() {
 	}
 
 	t.Config.Context.Log.Info("Bootstrapping finished with %s as the last accepted block", tailID)
+	return nil
 }
 
 // Gossip implements the Engine interface
@@ -144,6 +157,14 @@ func (t *Transitive) Put(vdr ids.ShortID, requestID uint32, blkID ids.ID, blkByt
 
 	blk, err := t.Config.VM.ParseBlock(blkBytes)
 	if err != nil {
+		// Gossiped containers may be transactions rather than blocks
+		if vm, ok := t.Config.VM.(GossipingChainVM); ok && requestID == common.GossipRequestID {
+			if err := vm.IssueGossipedTx(blkBytes); err != nil {
+				t.Config.Context.Log.Debug("Dropping gossiped container %s from %s due to %s", blkID, vdr, err)
+			}
+			return
+		}
+
 		t.Config.Context.Log.Debug("ParseBlock failed due to %s for block:\n%s",
 			err,
 			formatting.DumpBytes{Bytes: blkBytes})
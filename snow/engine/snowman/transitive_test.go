@@ -1522,3 +1522,131 @@ func TestEngineAggressivePolling(t *testing.T) {
 		t.Fatalf("Should have sent an additional pull query")
 	}
 }
+
+// heightIndexedVMTest is a VMTest whose height index can be made unusable
+type heightIndexedVMTest struct {
+	*VMTest
+
+	heightIndexErr error
+}
+
+func (vm *heightIndexedVMTest) VerifyHeightIndex() error { return vm.heightIndexErr }
+
+func (vm *heightIndexedVMTest) GetBlockIDAtHeight(uint64) (ids.ID, error) {
+	return ids.ID{}, vm.heightIndexErr
+}
+
+func TestEngineIncompleteHeightIndex(t *testing.T) {
+	config := DefaultConfig()
+
+	vals := validators.NewSet()
+	vals.Add(validators.GenerateRandomValidator(1))
+	config.Validators = vals
+
+	sender := &common.SenderTest{}
+	sender.T = t
+	sender.Default(true)
+	sender.CantGetAcceptedFrontier = false
+	config.Sender = sender
+
+	vmTest := &VMTest{}
+	vmTest.T = t
+	vmTest.Default(true)
+	vmTest.CantSetPreference = false
+	vm := &heightIndexedVMTest{
+		VMTest:         vmTest,
+		heightIndexErr: ErrIndexIncomplete,
+	}
+	config.VM = vm
+
+	gBlk := &Blk{
+		id:     GenerateID(),
+		status: choices.Accepted,
+	}
+	vmTest.LastAcceptedF = func() ids.ID { return gBlk.ID() }
+	vmTest.GetBlockF = func(ids.ID) (snowman.Block, error) { return gBlk, nil }
+
+	te := &Transitive{}
+	te.Initialize(config)
+
+	// The height index is optional, so an incomplete index doesn't stop
+	// consensus from starting
+	if err := te.finishBootstrapping(); err != nil {
+		t.Fatal(err)
+	}
+	if !te.bootstrapped {
+		t.Fatal("should have started consensus")
+	}
+}
+
+type gossipingVMTest struct {
+	*VMTest
+
+	gossiper        common.Gossiper
+	issueGossipedTx func([]byte) error
+}
+
+func (vm *gossipingVMTest) SetGossiper(gossiper common.Gossiper) { vm.gossiper = gossiper }
+func (vm *gossipingVMTest) IssueGossipedTx(tx []byte) error      { return vm.issueGossipedTx(tx) }
+
+func TestEngineGossipedTx(t *testing.T) {
+	config := DefaultConfig()
+
+	sender := &common.SenderTest{}
+	sender.T = t
+	sender.Default(true)
+	config.Sender = sender
+
+	vmTest := &VMTest{}
+	vmTest.T = t
+	vmTest.Default(true)
+	vmTest.CantSetPreference = false
+	vm := &gossipingVMTest{VMTest: vmTest}
+	config.VM = vm
+
+	gBlk := &Blk{
+		id:     GenerateID(),
+		status: choices.Accepted,
+	}
+	vmTest.LastAcceptedF = func() ids.ID { return gBlk.ID() }
+	vmTest.GetBlockF = func(ids.ID) (snowman.Block, error) { return gBlk, nil }
+
+	te := &Transitive{}
+	te.Initialize(config)
+	if err := te.finishBootstrapping(); err != nil {
+		t.Fatal(err)
+	}
+
+	if vm.gossiper != sender {
+		t.Fatalf("Should have provided the sender as the VM's gossiper")
+	}
+
+	vdr := validators.GenerateRandomValidator(1)
+	txID := GenerateID()
+	txBytes := []byte{1, 2, 3}
+
+	vmTest.ParseBlockF = func([]byte) (snowman.Block, error) { return nil, errUnknownBytes }
+
+	issued := new(bool)
+	vm.issueGossipedTx = func(b []byte) error {
+		*issued = true
+		if !bytes.Equal(b, txBytes) {
+			t.Fatalf("Wrong tx bytes")
+		}
+		return nil
+	}
+
+	te.Put(vdr.ID(), common.GossipRequestID, txID, txBytes)
+
+	if !*issued {
+		t.Fatalf("Should have issued the gossiped tx")
+	}
+
+	// Containers that were requested must not be treated as transactions
+	*issued = false
+	te.Put(vdr.ID(), 0, txID, txBytes)
+
+	if *issued {
+		t.Fatalf("Shouldn't have issued a requested container as a tx")
+	}
+}
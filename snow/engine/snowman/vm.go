@@ -55,3 +55,15 @@ type ChainVM interface {
 	// returned.
 	LastAccepted() ids.ID
 }
+
+// GossipingChainVM defines a ChainVM that gossips the transactions issued to
+// it, and accepts transactions that are gossiped to it by peers
+type GossipingChainVM interface {
+	ChainVM
+
+	// Provide the gossiper that the VM should send transactions with
+	SetGossiper(common.Gossiper)
+
+	// Issue a transaction that was gossiped by a peer
+	IssueGossipedTx(tx []byte) error
+}
@@ -0,0 +1,19 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package networking
+
+import (
+	"github.com/ava-labs/gecko/ids"
+)
+
+// Connector is notified when this node connects to or disconnects from a peer
+type Connector interface {
+	// Connected is called when the handshake with the peer [validatorID]
+	// completes
+	Connected(validatorID ids.ShortID)
+
+	// Disconnected is called when the connection to the peer [validatorID],
+	// which was previously passed to Connected, is lost
+	Disconnected(validatorID ids.ShortID)
+}
@@ -0,0 +1,128 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/timer"
+)
+
+// interval of time during which a peer was connected
+type interval struct {
+	start, end time.Time
+}
+
+// Tracker records when this node was connected to each of its peers. It's safe
+// for concurrent use, so it can be notified by the networking layer while the
+// chains that read from it hold their own locks.
+type Tracker struct {
+	// Clock is used to timestamp connections and disconnections
+	Clock timer.Clock
+
+	lock sync.Mutex
+	// peer ID --> time the current connection was established
+	connected map[[20]byte]time.Time
+	// peer ID --> connections that have ended since the last call to Prune
+	closed map[[20]byte][]interval
+}
+
+// NewTracker returns a new, empty tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		connected: make(map[[20]byte]time.Time),
+		closed:    make(map[[20]byte][]interval),
+	}
+}
+
+// Connected implements the networking.Connector interface
+func (t *Tracker) Connected(id ids.ShortID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := id.Key()
+	if _, ok := t.connected[key]; !ok {
+		t.connected[key] = t.Clock.Time()
+	}
+}
+
+// Disconnected implements the networking.Connector interface
+func (t *Tracker) Disconnected(id ids.ShortID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := id.Key()
+	start, ok := t.connected[key]
+	if !ok {
+		return
+	}
+	delete(t.connected, key)
+	t.closed[key] = append(t.closed[key], interval{
+		start: start,
+		end:   t.Clock.Time(),
+	})
+}
+
+// IsConnected returns true if the peer [id] is currently connected
+func (t *Tracker) IsConnected(id ids.ShortID) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	_, ok := t.connected[id.Key()]
+	return ok
+}
+
+// UpDuration returns how long the peer [id] was connected between [start] and
+// [end]. Connections that ended before the last call to Prune aren't counted.
+func (t *Tracker) UpDuration(id ids.ShortID, start, end time.Time) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := id.Key()
+	upDuration := time.Duration(0)
+	for _, conn := range t.closed[key] {
+		upDuration += overlap(conn.start, conn.end, start, end)
+	}
+	if connStart, ok := t.connected[key]; ok {
+		upDuration += overlap(connStart, t.Clock.Time(), start, end)
+	}
+	return upDuration
+}
+
+// Prune forgets the connections that ended before [time]
+func (t *Tracker) Prune(time time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for key, conns := range t.closed {
+		remaining := conns[:0]
+		for _, conn := range conns {
+			if !conn.end.Before(time) {
+				remaining = append(remaining, conn)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(t.closed, key)
+		} else {
+			t.closed[key] = remaining
+		}
+	}
+}
+
+// overlap returns the length of the intersection of [start0, end0] and
+// [start1, end1]
+func overlap(start0, end0, start1, end1 time.Time) time.Duration {
+	if start1.After(start0) {
+		start0 = start1
+	}
+	if end1.Before(end0) {
+		end0 = end1
+	}
+	if !end0.After(start0) {
+		return 0
+	}
+	return end0.Sub(start0)
+}
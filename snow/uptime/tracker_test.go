@@ -0,0 +1,70 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+func TestTrackerUpDuration(t *testing.T) {
+	id := ids.NewShortID([20]byte{1})
+	start := time.Unix(1000, 0)
+
+	tracker := NewTracker()
+	tracker.Clock.Set(start)
+
+	if upDuration := tracker.UpDuration(id, start, start.Add(time.Hour)); upDuration != 0 {
+		t.Fatalf("expected no up time but got %s", upDuration)
+	}
+
+	// Connected for [+10m, +20m]
+	tracker.Clock.Set(start.Add(10 * time.Minute))
+	tracker.Connected(id)
+	tracker.Clock.Set(start.Add(20 * time.Minute))
+	tracker.Disconnected(id)
+
+	if tracker.IsConnected(id) {
+		t.Fatal("shouldn't be connected")
+	}
+
+	// Connected since +30m
+	tracker.Clock.Set(start.Add(30 * time.Minute))
+	tracker.Connected(id)
+	tracker.Connected(id) // Shouldn't reset the start of the connection
+
+	if !tracker.IsConnected(id) {
+		t.Fatal("should be connected")
+	}
+
+	tracker.Clock.Set(start.Add(45 * time.Minute))
+	if upDuration := tracker.UpDuration(id, start, start.Add(time.Hour)); upDuration != 25*time.Minute {
+		t.Fatalf("expected 25m of up time but got %s", upDuration)
+	}
+	if upDuration := tracker.UpDuration(id, start.Add(15*time.Minute), start.Add(40*time.Minute)); upDuration != 15*time.Minute {
+		t.Fatalf("expected 15m of up time but got %s", upDuration)
+	}
+	if upDuration := tracker.UpDuration(ids.NewShortID([20]byte{2}), start, start.Add(time.Hour)); upDuration != 0 {
+		t.Fatalf("expected an unknown peer to have no up time but got %s", upDuration)
+	}
+
+	// Only the open connection remains
+	tracker.Prune(start.Add(45 * time.Minute))
+	if upDuration := tracker.UpDuration(id, start, start.Add(time.Hour)); upDuration != 15*time.Minute {
+		t.Fatalf("expected 15m of up time but got %s", upDuration)
+	}
+}
+
+func TestTrackerDisconnectedUnknown(t *testing.T) {
+	id := ids.NewShortID([20]byte{1})
+
+	tracker := NewTracker()
+	tracker.Disconnected(id)
+
+	if upDuration := tracker.UpDuration(id, time.Unix(0, 0), time.Unix(1000, 0)); upDuration != 0 {
+		t.Fatalf("expected no up time but got %s", upDuration)
+	}
+}
@@ -0,0 +1,16 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package constants
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DBVersion is the version of the node's database. A node's database is kept
+// under [db-dir]/[network name]/[DBVersion].
+const DBVersion = "v0.2.0"
+
+// DefaultDBDir is the directory the node keeps its database in by default
+var DefaultDBDir = os.ExpandEnv(filepath.Join("$HOME", ".gecko", "db"))
@@ -0,0 +1,187 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package formatting
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	bech32Charset   = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	bech32Separator = '1'
+
+	// bech32 strings are limited to 90 characters by BIP-173
+	bech32MaxLen      = 90
+	bech32ChecksumLen = 6
+)
+
+var (
+	errBech32TooLong       = errors.New("bech32 string is too long")
+	errBech32MixedCase     = errors.New("bech32 string contains mixed case characters")
+	errBech32NoSeparator   = errors.New("bech32 string is missing the separator")
+	errBech32EmptyHRP      = errors.New("bech32 human readable part is empty")
+	errBech32InvalidHRP    = errors.New("bech32 human readable part contains an invalid character")
+	errBech32InvalidChar   = errors.New("bech32 data contains an invalid character")
+	errBech32ShortChecksum = errors.New("bech32 string is smaller than the checksum size")
+	errBech32BadChecksum   = errors.New("invalid bech32 checksum")
+	errBech32BadPadding    = errors.New("invalid padding when converting bech32 data")
+
+	bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+)
+
+// FormatBech32 returns the bech32 encoding of [payload] with the human readable
+// part [hrp], as described by BIP-173
+func FormatBech32(hrp string, payload []byte) (string, error) {
+	if err := verifyHRP(hrp); err != nil {
+		return "", err
+	}
+	hrp = strings.ToLower(hrp)
+
+	data, err := convertBits(payload, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	if len(hrp)+1+len(data)+bech32ChecksumLen > bech32MaxLen {
+		return "", errBech32TooLong
+	}
+
+	checksum := bech32Checksum(hrp, data)
+
+	sb := strings.Builder{}
+	sb.Grow(len(hrp) + 1 + len(data) + len(checksum))
+	sb.WriteString(hrp)
+	sb.WriteByte(bech32Separator)
+	for _, b := range data {
+		sb.WriteByte(bech32Charset[b])
+	}
+	for _, b := range checksum {
+		sb.WriteByte(bech32Charset[b])
+	}
+	return sb.String(), nil
+}
+
+// ParseBech32 decodes the bech32 string [str] and returns its human readable
+// part and payload
+func ParseBech32(str string) (string, []byte, error) {
+	if len(str) > bech32MaxLen {
+		return "", nil, errBech32TooLong
+	}
+	lower := strings.ToLower(str)
+	if lower != str && strings.ToUpper(str) != str {
+		return "", nil, errBech32MixedCase
+	}
+
+	sepIndex := strings.LastIndexByte(lower, bech32Separator)
+	switch {
+	case sepIndex == -1:
+		return "", nil, errBech32NoSeparator
+	case sepIndex == 0:
+		return "", nil, errBech32EmptyHRP
+	case len(lower)-sepIndex-1 < bech32ChecksumLen:
+		return "", nil, errBech32ShortChecksum
+	}
+
+	hrp := lower[:sepIndex]
+	if err := verifyHRP(hrp); err != nil {
+		return "", nil, err
+	}
+
+	encoded := lower[sepIndex+1:]
+	data := make([]byte, len(encoded))
+	for i := 0; i < len(encoded); i++ {
+		index := strings.IndexByte(bech32Charset, encoded[i])
+		if index == -1 {
+			return "", nil, errBech32InvalidChar
+		}
+		data[i] = byte(index)
+	}
+
+	if bech32Polymod(append(hrpExpand(hrp), data...)) != 1 {
+		return "", nil, errBech32BadChecksum
+	}
+
+	payload, err := convertBits(data[:len(data)-bech32ChecksumLen], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return hrp, payload, nil
+}
+
+func verifyHRP(hrp string) error {
+	if len(hrp) == 0 {
+		return errBech32EmptyHRP
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return errBech32InvalidHRP
+		}
+	}
+	return nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, gen := range bech32Generator {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	expanded := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+func bech32Checksum(hrp string, data []byte) []byte {
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, make([]byte, bech32ChecksumLen)...)
+	mod := bech32Polymod(values) ^ 1
+
+	checksum := make([]byte, bech32ChecksumLen)
+	for i := range checksum {
+		checksum[i] = byte(mod>>uint(5*(5-i))) & 31
+	}
+	return checksum
+}
+
+// convertBits regroups [data] from [fromBits] bit groups to [toBits] bit groups
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxVal := uint32(1)<<toBits - 1
+	converted := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, b := range data {
+		if uint32(b)>>fromBits != 0 {
+			return nil, errBech32InvalidChar
+		}
+		acc = acc<<fromBits | uint32(b)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			converted = append(converted, byte(acc>>bits&maxVal))
+		}
+	}
+
+	switch {
+	case pad && bits > 0:
+		converted = append(converted, byte(acc<<(toBits-bits)&maxVal))
+	case !pad && (bits >= fromBits || acc<<(toBits-bits)&maxVal != 0):
+		return nil, errBech32BadPadding
+	}
+	return converted, nil
+}
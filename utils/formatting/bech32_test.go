@@ -0,0 +1,93 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package formatting
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBech32Valid(t *testing.T) {
+	tests := []struct {
+		str string
+		hrp string
+	}{
+		{str: "A12UEL5L", hrp: "a"},
+		{str: "a12uel5l", hrp: "a"},
+		{str: "?1ezyfcl", hrp: "?"},
+		{str: "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", hrp: "abcdef"},
+		{str: "split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", hrp: "split"},
+	}
+	for _, test := range tests {
+		hrp, payload, err := ParseBech32(test.str)
+		if err != nil {
+			t.Fatalf("ParseBech32(%s) unexpected error: %s", test.str, err)
+		}
+		if hrp != test.hrp {
+			t.Fatalf("ParseBech32(%s) returned hrp %s, expected %s", test.str, hrp, test.hrp)
+		}
+
+		formatted, err := FormatBech32(hrp, payload)
+		if err != nil {
+			t.Fatalf("FormatBech32(%s) unexpected error: %s", test.str, err)
+		}
+		if formatted != strings.ToLower(test.str) {
+			t.Fatalf("FormatBech32 returned %s, expected %s", formatted, strings.ToLower(test.str))
+		}
+	}
+}
+
+func TestBech32Invalid(t *testing.T) {
+	tests := []string{
+		"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx",
+		"pzry9x0s0muk",
+		"1pzry9x0s0muk",
+		"x1b4n0q5v",
+		"li1dgmt3",
+		"A1G7SGD8",
+		"10a06t8",
+		"1qzzfhee",
+		"Abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxx",
+		string([]byte{0x20}) + "1nwldj5",
+	}
+	for _, str := range tests {
+		if _, _, err := ParseBech32(str); err == nil {
+			t.Fatalf("ParseBech32(%s) should have errored", str)
+		}
+	}
+}
+
+func TestBech32RoundTrip(t *testing.T) {
+	payload := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 255}
+	str, err := FormatBech32("local", payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(str, "local1") {
+		t.Fatalf("expected %s to start with the hrp", str)
+	}
+
+	hrp, parsed, err := ParseBech32(str)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hrp != "local" {
+		t.Fatalf("expected hrp local, got %s", hrp)
+	}
+	if !bytes.Equal(parsed, payload) {
+		t.Fatalf("expected payload 0x%x, got 0x%x", payload, parsed)
+	}
+
+	if _, _, err := ParseBech32("cascade" + str[len("local"):]); err == nil {
+		t.Fatalf("changing the hrp should invalidate the checksum")
+	}
+}
+
+func TestBech32FormatInvalidHRP(t *testing.T) {
+	if _, err := FormatBech32("", []byte{1}); err == nil {
+		t.Fatalf("should have errored with an empty hrp")
+	}
+}
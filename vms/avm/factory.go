@@ -16,6 +16,15 @@ var (
 type Factory struct {
 	AVA      ids.ID
 	Platform ids.ID
+
+	// If true, accepted transactions are indexed by address and asset
+	IndexTxs bool
+
+	// Human readable part of bech32 addresses on this network
+	HRP string
+
+	// If true, addresses are returned in bech32 rather than CB58
+	Bech32Addresses bool
 }
 
 // New ...
@@ -23,5 +32,9 @@ func (f *Factory) New() (interface{}, error) {
 	return &VM{
 		ava:      f.AVA,
 		platform: f.Platform,
+		indexTxs: f.IndexTxs,
+
+		hrp:             f.HRP,
+		bech32Addresses: f.Bech32Addresses,
 	}, nil
 }
@@ -0,0 +1,343 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/utils/json"
+	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/components/verify"
+	"github.com/ava-labs/gecko/vms/nftfx"
+	"github.com/ava-labs/gecko/vms/propertyfx"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+var (
+	errUnknownTxType        = errors.New("unknown transaction type")
+	errUnknownOperationType = errors.New("unknown operation type")
+)
+
+// FormattedTx is the human readable representation of a transaction
+type FormattedTx struct {
+	ID           ids.ID                        `json:"id"`
+	Type         string                        `json:"type"`
+	NetworkID    json.Uint32                   `json:"networkID"`
+	BlockchainID ids.ID                        `json:"blockchainID"`
+	Inputs       []FormattedTransferableInput  `json:"inputs"`
+	Outputs      []FormattedTransferableOutput `json:"outputs"`
+
+	// Only set for CreateAssetTxs
+	Name          string                  `json:"name,omitempty"`
+	Symbol        string                  `json:"symbol,omitempty"`
+	Denomination  *json.Uint8             `json:"denomination,omitempty"`
+	InitialStates []FormattedInitialState `json:"initialStates,omitempty"`
+
+	// Only set for OperationTxs
+	Operations []FormattedOperation `json:"operations,omitempty"`
+
+	// Only set for ImportTxs
+	ImportedInputs []FormattedTransferableInput `json:"importedInputs,omitempty"`
+
+	// Only set for ExportTxs
+	ExportedOutputs []FormattedTransferableOutput `json:"exportedOutputs,omitempty"`
+
+	Credentials []FormattedCredential `json:"credentials"`
+}
+
+// FormattedTransferableInput is the human readable representation of an input
+// that consumes a UTXO
+type FormattedTransferableInput struct {
+	TxID        ids.ID         `json:"txID"`
+	OutputIndex json.Uint32    `json:"outputIndex"`
+	AssetID     ids.ID         `json:"assetID"`
+	Input       FormattedInput `json:"input"`
+}
+
+// FormattedTransferableOutput is the human readable representation of an
+// output of an asset
+type FormattedTransferableOutput struct {
+	AssetID ids.ID          `json:"assetID"`
+	Output  FormattedOutput `json:"output"`
+}
+
+// FormattedInitialState is the human readable representation of the outputs an
+// asset is created with
+type FormattedInitialState struct {
+	FxID    json.Uint32       `json:"fxID"`
+	Outputs []FormattedOutput `json:"outputs"`
+}
+
+// FormattedOperation is the human readable representation of an fx operation
+type FormattedOperation struct {
+	Type             string            `json:"type"`
+	AssetID          ids.ID            `json:"assetID"`
+	InputIDs         []ava.UTXOID      `json:"inputIDs"`
+	SignatureIndices []json.Uint32     `json:"signatureIndices"`
+	GroupID          *json.Uint32      `json:"groupID,omitempty"`
+	Payload          *formatting.CB58  `json:"payload,omitempty"`
+	Outputs          []FormattedOutput `json:"outputs"`
+}
+
+// FormattedInput is the human readable representation of an fx input
+type FormattedInput struct {
+	Type             string        `json:"type"`
+	Amount           json.Uint64   `json:"amount"`
+	SignatureIndices []json.Uint32 `json:"signatureIndices"`
+}
+
+// FormattedOutput is the human readable representation of an fx output
+type FormattedOutput struct {
+	Type      string           `json:"type"`
+	Amount    *json.Uint64     `json:"amount,omitempty"`
+	Locktime  *json.Uint64     `json:"locktime,omitempty"`
+	GroupID   *json.Uint32     `json:"groupID,omitempty"`
+	Payload   *formatting.CB58 `json:"payload,omitempty"`
+	Threshold json.Uint32      `json:"threshold"`
+	Addresses []string         `json:"addresses"`
+}
+
+// FormattedCredential is the human readable representation of an fx credential
+type FormattedCredential struct {
+	Type       string            `json:"type"`
+	Signatures []formatting.CB58 `json:"signatures"`
+}
+
+// FormatTx returns the human readable representation of [tx]
+func (vm *VM) FormatTx(tx *Tx) (*FormattedTx, error) {
+	formatted := &FormattedTx{
+		ID:   tx.ID(),
+		Type: typeName(tx.UnsignedTx),
+	}
+
+	var baseTx *BaseTx
+	switch unsignedTx := tx.UnsignedTx.(type) {
+	case *BaseTx:
+		baseTx = unsignedTx
+	case *CreateAssetTx:
+		baseTx = &unsignedTx.BaseTx
+		denomination := json.Uint8(unsignedTx.Denomination)
+		formatted.Name = unsignedTx.Name
+		formatted.Symbol = unsignedTx.Symbol
+		formatted.Denomination = &denomination
+		for _, state := range unsignedTx.States {
+			outs, err := vm.formatOutputs(state.Outs)
+			if err != nil {
+				return nil, err
+			}
+			formatted.InitialStates = append(formatted.InitialStates, FormattedInitialState{
+				FxID:    json.Uint32(state.FxID),
+				Outputs: outs,
+			})
+		}
+	case *OperationTx:
+		baseTx = &unsignedTx.BaseTx
+		for _, op := range unsignedTx.Ops {
+			formattedOp, err := vm.formatOperation(op)
+			if err != nil {
+				return nil, err
+			}
+			formatted.Operations = append(formatted.Operations, formattedOp)
+		}
+	case *ImportTx:
+		baseTx = &unsignedTx.BaseTx
+		ins, err := formatInputs(unsignedTx.Ins)
+		if err != nil {
+			return nil, err
+		}
+		formatted.ImportedInputs = ins
+	case *ExportTx:
+		baseTx = &unsignedTx.BaseTx
+		outs, err := vm.formatTransferableOutputs(unsignedTx.Outs)
+		if err != nil {
+			return nil, err
+		}
+		formatted.ExportedOutputs = outs
+	default:
+		return nil, errUnknownTxType
+	}
+
+	formatted.NetworkID = json.Uint32(baseTx.NetID)
+	formatted.BlockchainID = baseTx.BCID
+
+	ins, err := formatInputs(baseTx.Ins)
+	if err != nil {
+		return nil, err
+	}
+	formatted.Inputs = ins
+
+	outs, err := vm.formatTransferableOutputs(baseTx.Outs)
+	if err != nil {
+		return nil, err
+	}
+	formatted.Outputs = outs
+
+	formatted.Credentials = []FormattedCredential{}
+	for _, credIntf := range tx.Creds {
+		var cred *secp256k1fx.Credential
+		switch c := credIntf.(type) {
+		case *secp256k1fx.Credential:
+			cred = c
+		case *nftfx.Credential:
+			cred = &c.Credential
+		case *propertyfx.Credential:
+			cred = &c.Credential
+		default:
+			return nil, errUnknownCredentialType
+		}
+
+		formattedCred := FormattedCredential{
+			Type:       typeName(credIntf),
+			Signatures: []formatting.CB58{},
+		}
+		for _, sig := range cred.Sigs {
+			formattedCred.Signatures = append(formattedCred.Signatures, formatting.CB58{Bytes: sig[:]})
+		}
+		formatted.Credentials = append(formatted.Credentials, formattedCred)
+	}
+	return formatted, nil
+}
+
+func formatInputs(ins []*ava.TransferableInput) ([]FormattedTransferableInput, error) {
+	formatted := []FormattedTransferableInput{}
+	for _, in := range ins {
+		input, ok := in.In.(*secp256k1fx.TransferInput)
+		if !ok {
+			return nil, errUnknownInputType
+		}
+		formatted = append(formatted, FormattedTransferableInput{
+			TxID:        in.TxID,
+			OutputIndex: json.Uint32(in.OutputIndex),
+			AssetID:     in.AssetID(),
+			Input: FormattedInput{
+				Type:             typeName(input),
+				Amount:           json.Uint64(input.Amt),
+				SignatureIndices: formatSigIndices(input.SigIndices),
+			},
+		})
+	}
+	return formatted, nil
+}
+
+func (vm *VM) formatTransferableOutputs(outs []*ava.TransferableOutput) ([]FormattedTransferableOutput, error) {
+	formatted := []FormattedTransferableOutput{}
+	for _, out := range outs {
+		output, err := vm.formatOutput(out.Out)
+		if err != nil {
+			return nil, err
+		}
+		formatted = append(formatted, FormattedTransferableOutput{
+			AssetID: out.AssetID(),
+			Output:  output,
+		})
+	}
+	return formatted, nil
+}
+
+func (vm *VM) formatOperation(op *Operation) (FormattedOperation, error) {
+	formatted := FormattedOperation{
+		Type:     typeName(op.Op),
+		AssetID:  op.AssetID(),
+		InputIDs: []ava.UTXOID{},
+	}
+	for _, utxoID := range op.UTXOIDs {
+		formatted.InputIDs = append(formatted.InputIDs, *utxoID)
+	}
+
+	switch fxOp := op.Op.(type) {
+	case *secp256k1fx.MintOperation:
+		formatted.SignatureIndices = formatSigIndices(fxOp.MintInput.SigIndices)
+	case *nftfx.MintOperation:
+		groupID := json.Uint32(fxOp.GroupID)
+		formatted.SignatureIndices = formatSigIndices(fxOp.MintInput.SigIndices)
+		formatted.GroupID = &groupID
+		formatted.Payload = &formatting.CB58{Bytes: fxOp.Payload}
+	case *nftfx.TransferOperation:
+		formatted.SignatureIndices = formatSigIndices(fxOp.Input.SigIndices)
+	case *propertyfx.MintOperation:
+		formatted.SignatureIndices = formatSigIndices(fxOp.MintInput.SigIndices)
+	case *propertyfx.BurnOperation:
+		formatted.SignatureIndices = formatSigIndices(fxOp.Input.SigIndices)
+	default:
+		return FormattedOperation{}, errUnknownOperationType
+	}
+
+	outs, err := vm.formatOutputs(op.Op.Outs())
+	if err != nil {
+		return FormattedOperation{}, err
+	}
+	formatted.Outputs = outs
+	return formatted, nil
+}
+
+func (vm *VM) formatOutputs(outs []verify.Verifiable) ([]FormattedOutput, error) {
+	formatted := []FormattedOutput{}
+	for _, out := range outs {
+		output, err := vm.formatOutput(out)
+		if err != nil {
+			return nil, err
+		}
+		formatted = append(formatted, output)
+	}
+	return formatted, nil
+}
+
+func (vm *VM) formatOutput(out verify.Verifiable) (FormattedOutput, error) {
+	formatted := FormattedOutput{Type: typeName(out)}
+
+	var owners *secp256k1fx.OutputOwners
+	switch out := out.(type) {
+	case *secp256k1fx.TransferOutput:
+		amount := json.Uint64(out.Amt)
+		locktime := json.Uint64(out.Locktime)
+		formatted.Amount = &amount
+		formatted.Locktime = &locktime
+		owners = &out.OutputOwners
+	case *secp256k1fx.MintOutput:
+		owners = &out.OutputOwners
+	case *nftfx.MintOutput:
+		groupID := json.Uint32(out.GroupID)
+		formatted.GroupID = &groupID
+		owners = &out.OutputOwners
+	case *nftfx.TransferOutput:
+		groupID := json.Uint32(out.GroupID)
+		formatted.GroupID = &groupID
+		formatted.Payload = &formatting.CB58{Bytes: out.Payload}
+		owners = &out.OutputOwners
+	case *propertyfx.MintOutput:
+		owners = &out.OutputOwners
+	case *propertyfx.OwnedOutput:
+		owners = &out.OutputOwners
+	default:
+		return FormattedOutput{}, errUnknownOutputType
+	}
+
+	formatted.Threshold = json.Uint32(owners.Threshold)
+	formatted.Addresses = []string{}
+	for _, addr := range owners.Addrs {
+		formatted.Addresses = append(formatted.Addresses, vm.Format(addr.Bytes()))
+	}
+	return formatted, nil
+}
+
+func formatSigIndices(sigIndices []uint32) []json.Uint32 {
+	formatted := []json.Uint32{}
+	for _, sigIndex := range sigIndices {
+		formatted = append(formatted, json.Uint32(sigIndex))
+	}
+	return formatted
+}
+
+// typeName returns the name of the type of [val], including its package. For
+// example, "secp256k1fx.TransferOutput".
+func typeName(val interface{}) string {
+	valType := reflect.TypeOf(val)
+	if valType.Kind() == reflect.Ptr {
+		valType = valType.Elem()
+	}
+	return valType.String()
+}
@@ -0,0 +1,141 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/timer"
+)
+
+var (
+	errMempoolFull   = errors.New("mempool is full")
+	errDuplicateTx   = errors.New("transaction is already pending")
+	errConflictingTx = errors.New("transaction conflicts with a pending transaction")
+)
+
+// mempool tracks the transactions that have been issued to this node but not
+// yet decided. Transactions that are still pending [expiry] after they were
+// added are evicted, as the engine may have dropped them without deciding them.
+type mempool struct {
+	maxSize int
+	expiry  time.Duration
+	clock   *timer.Clock
+
+	// Pending transactions, in the order they were added
+	txs []*UniqueTx
+
+	// The time each pending transaction was added, in the same order as [txs]
+	addedAt []time.Time
+
+	// Key: ID of a pending transaction
+	// Value: The pending transaction
+	txIDs map[[32]byte]*UniqueTx
+
+	// Key: ID of an input consumed by a pending transaction
+	// Value: ID of the transaction consuming it
+	consumed map[[32]byte]ids.ID
+}
+
+func newMempool(maxSize int, expiry time.Duration, clock *timer.Clock) *mempool {
+	return &mempool{
+		maxSize:  maxSize,
+		expiry:   expiry,
+		clock:    clock,
+		txIDs:    make(map[[32]byte]*UniqueTx),
+		consumed: make(map[[32]byte]ids.ID),
+	}
+}
+
+// Add [tx] to the mempool. Returns an error if the mempool is full, if [tx] is
+// already pending, or if [tx] consumes an input that a pending transaction
+// also consumes.
+func (m *mempool) Add(tx *UniqueTx) error {
+	m.evictExpired()
+
+	txID := tx.ID()
+	switch {
+	case m.Has(txID):
+		return errDuplicateTx
+	case len(m.txs) >= m.maxSize:
+		return errMempoolFull
+	}
+
+	inputIDs := tx.InputIDs()
+	for _, inputID := range inputIDs.List() {
+		if _, exists := m.consumed[inputID.Key()]; exists {
+			return errConflictingTx
+		}
+	}
+
+	m.txs = append(m.txs, tx)
+	m.addedAt = append(m.addedAt, m.clock.Time())
+	m.txIDs[txID.Key()] = tx
+	for _, inputID := range inputIDs.List() {
+		m.consumed[inputID.Key()] = txID
+	}
+	return nil
+}
+
+// Has returns true if the transaction with ID [txID] is pending
+func (m *mempool) Has(txID ids.ID) bool {
+	_, exists := m.txIDs[txID.Key()]
+	return exists
+}
+
+// Remove [tx] from the mempool, along with any pending transactions that
+// consume the same inputs as [tx]
+func (m *mempool) Remove(tx *UniqueTx) {
+	m.remove(tx.ID())
+	for _, inputID := range tx.InputIDs().List() {
+		if consumerID, exists := m.consumed[inputID.Key()]; exists {
+			m.remove(consumerID)
+		}
+	}
+}
+
+func (m *mempool) remove(txID ids.ID) {
+	tx, exists := m.txIDs[txID.Key()]
+	if !exists {
+		return
+	}
+	delete(m.txIDs, txID.Key())
+
+	for i, pendingTx := range m.txs {
+		if pendingTx.ID().Equals(txID) {
+			m.txs = append(m.txs[:i], m.txs[i+1:]...)
+			m.addedAt = append(m.addedAt[:i], m.addedAt[i+1:]...)
+			break
+		}
+	}
+	for _, inputID := range tx.InputIDs().List() {
+		delete(m.consumed, inputID.Key())
+	}
+}
+
+// evictExpired removes the transactions that were added at least [m.expiry]
+// ago
+func (m *mempool) evictExpired() {
+	expired := m.clock.Time().Add(-m.expiry)
+	for len(m.txs) > 0 && !m.addedAt[0].After(expired) {
+		m.remove(m.txs[0].ID())
+	}
+}
+
+// Txs returns the pending transactions, in the order they were added
+func (m *mempool) Txs() []*UniqueTx {
+	m.evictExpired()
+
+	txs := make([]*UniqueTx, len(m.txs))
+	copy(txs, m.txs)
+	return txs
+}
+
+// Len returns the number of pending transactions
+func (m *mempool) Len() int {
+	m.evictExpired()
+	return len(m.txs)
+}
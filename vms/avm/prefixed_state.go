@@ -17,10 +17,17 @@ const (
 	txStatusID
 	fundsID
 	dbInitializedID
+	utxoIndexID
+	addressTxsID
+	addressTxCountID
+	txsIndexedID
+	utxosIndexedID
 )
 
 var (
 	dbInitialized = ids.Empty.Prefix(dbInitializedID)
+	txsIndexed    = ids.Empty.Prefix(txsIndexedID)
+	utxosIndexed  = ids.Empty.Prefix(utxosIndexedID)
 )
 
 // prefixedState wraps a state object. By prefixing the state, there will be no
@@ -28,8 +35,9 @@ var (
 type prefixedState struct {
 	state *state
 
-	tx, utxo, txStatus, funds cache.Cacher
-	uniqueTx                  cache.Deduplicator
+	tx, utxo, txStatus, funds, utxoIndex cache.Cacher
+	addressTxs, addressTxCount           cache.Cacher
+	uniqueTx                             cache.Deduplicator
 }
 
 // UniqueTx de-duplicates the transaction.
@@ -74,6 +82,49 @@ func (s *prefixedState) SetDBInitialized(status choices.Status) error {
 	return s.state.SetStatus(dbInitialized, status)
 }
 
+// TxsIndexed returns the status of the transaction index. If transactions were
+// never indexed, the status will be unknown.
+func (s *prefixedState) TxsIndexed() (choices.Status, error) { return s.state.Status(txsIndexed) }
+
+// SetTxsIndexed saves the provided status of the transaction index.
+func (s *prefixedState) SetTxsIndexed(status choices.Status) error {
+	return s.state.SetStatus(txsIndexed, status)
+}
+
+// UTXOsIndexed returns the status of the utxo index. If the utxos stored before
+// the index was added were never indexed, the status will be unknown.
+func (s *prefixedState) UTXOsIndexed() (choices.Status, error) {
+	return s.state.Status(utxosIndexed)
+}
+
+// SetUTXOsIndexed saves the provided status of the utxo index.
+func (s *prefixedState) SetUTXOsIndexed(status choices.Status) error {
+	return s.state.SetStatus(utxosIndexed, status)
+}
+
+// AddressTxs returns up to [limit] IDs of accepted transactions that reference
+// the address and asset, in the order they were accepted, starting at position
+// [start]. If [assetID] is empty, transactions of every asset are returned.
+func (s *prefixedState) AddressTxs(addr, assetID ids.ID, start uint64, limit int) ([]ids.ID, error) {
+	listID := addressAssetID(addr, assetID)
+	return s.state.ListedIDs(uniqueID(listID, addressTxsID, s.addressTxs), start, limit)
+}
+
+// AddAddressTx records that the accepted transaction [txID] references the
+// address and asset.
+func (s *prefixedState) AddAddressTx(addr, assetID, txID ids.ID) error {
+	listID := addressAssetID(addr, assetID)
+	return s.state.AppendListedID(
+		uniqueID(listID, addressTxsID, s.addressTxs),
+		uniqueID(listID, addressTxCountID, s.addressTxCount),
+		txID,
+	)
+}
+
+func addressAssetID(addr, assetID ids.ID) ids.ID {
+	return ids.NewID(hashing.ComputeHash256Array(append(addr.Bytes(), assetID.Bytes()...)))
+}
+
 // Funds returns the mapping from the 32 byte representation of an address to a
 // list of utxo IDs that reference the address.
 func (s *prefixedState) Funds(id ids.ID) ([]ids.ID, error) {
@@ -85,6 +136,31 @@ func (s *prefixedState) SetFunds(id ids.ID, idSlice []ids.ID) error {
 	return s.state.SetIDs(uniqueID(id, fundsID, s.funds), idSlice)
 }
 
+// UTXOIDs returns up to [limit] utxo IDs that reference the address, in a
+// stable ascending order. If [start] is non-zero, only utxo IDs that come after
+// [start] are returned.
+func (s *prefixedState) UTXOIDs(id ids.ID, start ids.ID, limit int) ([]ids.ID, error) {
+	return s.state.IndexedIDs(uniqueID(id, utxoIndexID, s.utxoIndex), start, limit)
+}
+
+// IndexUTXO adds the stored utxo [utxo] to the utxo index of each of its
+// addresses.
+func (s *prefixedState) IndexUTXO(utxo *ava.UTXO) error {
+	addressable, ok := utxo.Out.(ava.Addressable)
+	if !ok {
+		return nil
+	}
+
+	utxoID := utxo.InputID()
+	for _, addr := range addressable.Addresses() {
+		addrID := ids.NewID(hashing.ComputeHash256Array(addr))
+		if err := s.state.AddIndexedID(uniqueID(addrID, utxoIndexID, s.utxoIndex), utxoID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SpendUTXO consumes the provided utxo.
 func (s *prefixedState) SpendUTXO(utxoID ids.ID) error {
 	utxo, err := s.UTXO(utxoID)
@@ -113,6 +189,9 @@ func (s *prefixedState) removeUTXO(addrs [][]byte, utxoID ids.ID) error {
 		if err := s.SetFunds(addrID, utxos.List()); err != nil {
 			return err
 		}
+		if err := s.state.RemoveIndexedID(uniqueID(addrID, utxoIndexID, s.utxoIndex), utxoID); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -142,6 +221,9 @@ func (s *prefixedState) addUTXO(addrs [][]byte, utxoID ids.ID) error {
 		if err := s.SetFunds(addrID, utxos.List()); err != nil {
 			return err
 		}
+		if err := s.state.AddIndexedID(uniqueID(addrID, utxoIndexID, s.utxoIndex), utxoID); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -187,3 +187,72 @@ func TestPrefixedFundingAddresses(t *testing.T) {
 		t.Fatalf("Should have returned no utxoIDs")
 	}
 }
+
+func TestPrefixedUTXOIndex(t *testing.T) {
+	_, _, vm := GenesisVM(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	state := vm.state
+
+	vm.codec.RegisterType(&testAddressable{})
+
+	addrID := ids.NewID(hashing.ComputeHash256Array([]byte{0}))
+
+	utxoIDs := []ids.ID{}
+	for i := uint32(0); i < 5; i++ {
+		utxo := &ava.UTXO{
+			UTXOID: ava.UTXOID{
+				TxID:        ids.Empty,
+				OutputIndex: i,
+			},
+			Asset: ava.Asset{ID: ids.Empty},
+			Out: &testAddressable{
+				Addrs: [][]byte{
+					[]byte{0},
+				},
+			},
+		}
+		if err := state.FundUTXO(utxo); err != nil {
+			t.Fatal(err)
+		}
+		utxoIDs = append(utxoIDs, utxo.InputID())
+	}
+	ids.SortIDs(utxoIDs)
+
+	firstPage, err := state.UTXOIDs(addrID, ids.ID{}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(firstPage) != 3 {
+		t.Fatalf("Should have returned 3 utxoIDs, returned %d", len(firstPage))
+	}
+	secondPage, err := state.UTXOIDs(addrID, firstPage[2], 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secondPage) != 2 {
+		t.Fatalf("Should have returned 2 utxoIDs, returned %d", len(secondPage))
+	}
+	for i, utxoID := range append(firstPage, secondPage...) {
+		if !utxoID.Equals(utxoIDs[i]) {
+			t.Fatalf("Returned wrong utxoID at index %d", i)
+		}
+	}
+
+	if err := state.SpendUTXO(utxoIDs[0]); err != nil {
+		t.Fatal(err)
+	}
+	remaining, err := state.UTXOIDs(addrID, ids.ID{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 4 {
+		t.Fatalf("Should have returned 4 utxoIDs, returned %d", len(remaining))
+	}
+	if !remaining[0].Equals(utxoIDs[1]) {
+		t.Fatalf("Spent utxoID should have been removed from the index")
+	}
+}
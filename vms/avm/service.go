@@ -18,7 +18,10 @@ import (
 	"github.com/ava-labs/gecko/utils/json"
 	safemath "github.com/ava-labs/gecko/utils/math"
 	"github.com/ava-labs/gecko/vms/components/ava"
+	"github.com/ava-labs/gecko/vms/components/codec"
 	"github.com/ava-labs/gecko/vms/components/verify"
+	"github.com/ava-labs/gecko/vms/nftfx"
+	"github.com/ava-labs/gecko/vms/propertyfx"
 	"github.com/ava-labs/gecko/vms/secp256k1fx"
 )
 
@@ -37,6 +40,29 @@ var (
 	errUnknownOutputType         = errors.New("unknown output type")
 	errUnneededAddress           = errors.New("address not required to sign")
 	errUnknownCredentialType     = errors.New("unknown credential type")
+	errTxIndexingDisabled        = errors.New("transaction indexing is disabled")
+	errPayloadTooLarge           = errors.New("payload too large")
+	errNoSpendableNFT            = errors.New("no spendable NFT of the provided group")
+	errNoOwnedProperty           = errors.New("no owned output of the provided property")
+	errUnknownInputType          = errors.New("unknown input type")
+	errInputOutputMismatch       = errors.New("input output mismatch")
+	errUnsupportedTxType         = errors.New("only base and export transactions can be signed")
+	errNoTxs                     = errors.New("no transactions provided")
+	errMismatchedTxs             = errors.New("transactions don't match")
+	errConflictingSignatures     = errors.New("transactions contain conflicting signatures")
+	errAddressAndOwners          = errors.New("only one of an address and owners may be provided")
+	errUnknownEncoding           = errors.New("unknown encoding")
+
+	emptySig = [crypto.SECP256K1RSigLen]byte{}
+)
+
+const (
+	// Max number of transaction IDs that can be fetched in a single call to
+	// GetAddressTxs
+	maxPageSize = 1024
+
+	cb58Encoding = "cb58"
+	jsonEncoding = "json"
 )
 
 // Service defines the base service for the asset vm
@@ -99,11 +125,16 @@ func (service *Service) GetTxStatus(r *http.Request, args *GetTxStatusArgs, repl
 // GetTxArgs are arguments for passing into GetTx requests
 type GetTxArgs struct {
 	TxID ids.ID `json:"txID"`
+
+	// Either "cb58", the default, or "json". If "json", the decoded
+	// transaction is returned alongside its bytes.
+	Encoding string `json:"encoding"`
 }
 
 // GetTxReply defines the GetTxStatus replies returned from the API
 type GetTxReply struct {
-	Tx formatting.CB58 `json:"tx"`
+	Tx      formatting.CB58 `json:"tx"`
+	Decoded *FormattedTx    `json:"decoded,omitempty"`
 }
 
 // GetTx returns the specified transaction
@@ -123,35 +154,148 @@ func (service *Service) GetTx(r *http.Request, args *GetTxArgs, reply *GetTxRepl
 	}
 
 	reply.Tx.Bytes = tx.Bytes()
+
+	switch args.Encoding {
+	case "", cb58Encoding:
+	case jsonEncoding:
+		decoded, err := service.vm.FormatTx(tx.Tx)
+		if err != nil {
+			return fmt.Errorf("problem decoding transaction: %w", err)
+		}
+		reply.Decoded = decoded
+	default:
+		return errUnknownEncoding
+	}
+	return nil
+}
+
+// GetAddressTxsArgs are arguments for passing into GetAddressTxs requests
+type GetAddressTxsArgs struct {
+	Address string `json:"address"`
+	// If empty, transactions of every asset are returned
+	AssetID string `json:"assetID"`
+	// Position in the address's transaction history to start fetching from
+	Cursor json.Uint64 `json:"cursor"`
+	// Max number of transaction IDs to return
+	PageSize json.Uint64 `json:"pageSize"`
+}
+
+// GetAddressTxsReply defines the GetAddressTxs replies returned from the API
+type GetAddressTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Cursor to pass into the next call to continue fetching
+	Cursor json.Uint64 `json:"cursor"`
+}
+
+// GetAddressTxs returns the IDs of accepted transactions that reference
+// [args.Address] and [args.AssetID], in the order they were accepted. Requires
+// the node to index transactions.
+func (service *Service) GetAddressTxs(r *http.Request, args *GetAddressTxsArgs, reply *GetAddressTxsReply) error {
+	service.vm.ctx.Log.Verbo("GetAddressTxs called with address: %s assetID: %s", args.Address, args.AssetID)
+
+	if !service.vm.indexTxs {
+		return errTxIndexingDisabled
+	}
+
+	address, err := service.vm.Parse(args.Address)
+	if err != nil {
+		return fmt.Errorf("problem parsing address '%s': %w", args.Address, err)
+	}
+
+	assetID := ids.Empty
+	if args.AssetID != "" {
+		assetID, err = service.vm.Lookup(args.AssetID)
+		if err != nil {
+			assetID, err = ids.FromString(args.AssetID)
+			if err != nil {
+				return fmt.Errorf("asset '%s' not found", args.AssetID)
+			}
+		}
+	}
+
+	pageSize := uint64(args.PageSize)
+	if pageSize == 0 || pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	addrID := ids.NewID(hashing.ComputeHash256Array(address))
+	txIDs, err := service.vm.state.AddressTxs(addrID, assetID, uint64(args.Cursor), int(pageSize))
+	if err != nil {
+		return fmt.Errorf("problem retrieving transactions: %w", err)
+	}
+
+	reply.TxIDs = txIDs
+	if reply.TxIDs == nil {
+		reply.TxIDs = []ids.ID{}
+	}
+	reply.Cursor = args.Cursor + json.Uint64(len(txIDs))
 	return nil
 }
 
+// Index is an address and an associated UTXO. It marks a starting or stopping
+// point when fetching UTXOs, and is used for pagination.
+type Index struct {
+	Address string `json:"address"` // The address as a string
+	UTXO    string `json:"utxo"`    // The UTXO ID as a string
+}
+
 // GetUTXOsArgs are arguments for passing into GetUTXOs requests
 type GetUTXOsArgs struct {
-	Addresses []string `json:"addresses"`
+	Addresses  []string    `json:"addresses"`
+	Limit      json.Uint32 `json:"limit"`
+	StartIndex Index       `json:"startIndex"`
 }
 
 // GetUTXOsReply defines the GetUTXOs replies returned from the API
 type GetUTXOsReply struct {
+	// Number of UTXOs returned
+	NumFetched json.Uint64 `json:"numFetched"`
+	// The UTXOs
 	UTXOs []formatting.CB58 `json:"utxos"`
+	// The last UTXO that was visited. Passing this as the [StartIndex] of the
+	// next call continues fetching after it.
+	EndIndex Index `json:"endIndex"`
 }
 
-// GetUTXOs creates an empty account with the name passed in
+// GetUTXOs gets up to [args.Limit] of the UTXOs referenced by [args.Addresses],
+// starting after [args.StartIndex]. If [args.Limit] is 0 or larger than the
+// maximum, the maximum number of UTXOs is fetched.
 func (service *Service) GetUTXOs(r *http.Request, args *GetUTXOsArgs, reply *GetUTXOsReply) error {
 	service.vm.ctx.Log.Verbo("GetUTXOs called with %s", args.Addresses)
 
 	addrSet := ids.Set{}
+	addrStrs := map[[32]byte]string{} // address ID -> address as it was provided
 	for _, addr := range args.Addresses {
 		addrBytes, err := service.vm.Parse(addr)
 		if err != nil {
 			return err
 		}
-		addrSet.Add(ids.NewID(hashing.ComputeHash256Array(addrBytes)))
+		addrID := ids.NewID(hashing.ComputeHash256Array(addrBytes))
+		addrSet.Add(addrID)
+		addrStrs[addrID.Key()] = addr
 	}
 
-	utxos, err := service.vm.GetUTXOs(addrSet)
+	startAddr := ids.ID{}
+	startUTXO := ids.ID{}
+	if args.StartIndex.Address != "" {
+		addrBytes, err := service.vm.Parse(args.StartIndex.Address)
+		if err != nil {
+			return fmt.Errorf("problem parsing start index address: %w", err)
+		}
+		startAddr = ids.NewID(hashing.ComputeHash256Array(addrBytes))
+		addrStrs[startAddr.Key()] = args.StartIndex.Address
+
+		if args.StartIndex.UTXO != "" {
+			startUTXO, err = ids.FromString(args.StartIndex.UTXO)
+			if err != nil {
+				return fmt.Errorf("problem parsing start index UTXO ID: %w", err)
+			}
+		}
+	}
+
+	utxos, endAddr, endUTXO, err := service.vm.GetPaginatedUTXOs(addrSet, startAddr, startUTXO, int(args.Limit))
 	if err != nil {
-		return err
+		return fmt.Errorf("problem retrieving UTXOs: %w", err)
 	}
 
 	reply.UTXOs = []formatting.CB58{}
@@ -162,6 +306,13 @@ func (service *Service) GetUTXOs(r *http.Request, args *GetUTXOsArgs, reply *Get
 		}
 		reply.UTXOs = append(reply.UTXOs, formatting.CB58{Bytes: b})
 	}
+	reply.NumFetched = json.Uint64(len(utxos))
+	if !endAddr.IsZero() {
+		reply.EndIndex.Address = addrStrs[endAddr.Key()]
+	}
+	if !endUTXO.IsZero() {
+		reply.EndIndex.UTXO = endUTXO.String()
+	}
 	return nil
 }
 
@@ -218,11 +369,14 @@ type GetBalanceArgs struct {
 
 // GetBalanceReply defines the GetBalance replies returned from the API
 type GetBalanceReply struct {
-	Balance json.Uint64  `json:"balance"`
-	UTXOIDs []ava.UTXOID `json:"utxoIDs"`
+	Balance  json.Uint64  `json:"balance"`
+	Unlocked json.Uint64  `json:"unlocked"`
+	Locked   json.Uint64  `json:"locked"`
+	UTXOIDs  []ava.UTXOID `json:"utxoIDs"`
 }
 
-// GetBalance returns the amount of an asset that an address at least partially owns
+// GetBalance returns the amount of an asset that an address at least partially owns.
+// Funds that can't be spent until a future locktime are reported as locked.
 func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply *GetBalanceReply) error {
 	service.vm.ctx.Log.Verbo("GetBalance called with address: %s assetID: %s", args.Address, args.AssetID)
 
@@ -247,25 +401,45 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 		return err
 	}
 
+	now := service.vm.clock.Unix()
 	for _, utxo := range utxos {
 		if !utxo.AssetID().Equals(assetID) {
 			continue
 		}
-		transferable, ok := utxo.Out.(ava.Transferable)
+		balance, ok := balanceOf(utxo.Out)
 		if !ok {
 			continue
 		}
-		amt, err := safemath.Add64(transferable.Amount(), uint64(reply.Balance))
+		amt, err := safemath.Add64(balance, uint64(reply.Balance))
 		if err != nil {
 			return err
 		}
 		reply.Balance = json.Uint64(amt)
 		reply.UTXOIDs = append(reply.UTXOIDs, utxo.UTXOID)
+
+		if out, ok := utxo.Out.(*secp256k1fx.TransferOutput); ok && out.Locktime > now {
+			reply.Locked += json.Uint64(balance)
+		} else {
+			reply.Unlocked += json.Uint64(balance)
+		}
 	}
 
 	return nil
 }
 
+// balanceOf returns the amount of an asset that [out] holds. Each NFT counts as
+// a single unit of its asset. Returns false if [out] doesn't hold a balance.
+func balanceOf(out verify.Verifiable) (uint64, bool) {
+	switch out := out.(type) {
+	case ava.Transferable:
+		return out.Amount(), true
+	case *nftfx.TransferOutput:
+		return 1, true
+	default:
+		return 0, false
+	}
+}
+
 // Balance ...
 type Balance struct {
 	AssetID string      `json:"asset"`
@@ -305,14 +479,14 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 	assetIDs := ids.Set{}                    // IDs of assets the address has a non-zero balance of
 	balances := make(map[[32]byte]uint64, 0) // key: ID (as bytes). value: balance of that asset
 	for _, utxo := range utxos {
-		transferable, ok := utxo.Out.(ava.Transferable)
+		amount, ok := balanceOf(utxo.Out)
 		if !ok {
 			continue
 		}
 		assetID := utxo.AssetID()
 		assetIDs.Add(assetID)
 		balance := balances[assetID.Key()] // 0 if key doesn't exist
-		balance, err := safemath.Add64(transferable.Amount(), balance)
+		balance, err := safemath.Add64(amount, balance)
 		if err != nil {
 			balances[assetID.Key()] = math.MaxUint64
 		} else {
@@ -338,6 +512,105 @@ func (service *Service) GetAllBalances(r *http.Request, args *GetAllBalancesArgs
 	return nil
 }
 
+// GetPendingTxsReply defines the GetPendingTxs replies returned from the API
+type GetPendingTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+}
+
+// GetPendingTxs returns the IDs of the transactions that have been issued to
+// this node but not yet decided
+func (service *Service) GetPendingTxs(_ *http.Request, args *struct{}, reply *GetPendingTxsReply) error {
+	service.vm.ctx.Log.Verbo("GetPendingTxs called")
+
+	reply.TxIDs = service.vm.PendingTxIDs()
+	return nil
+}
+
+// GetPendingBalanceArgs are arguments for passing into GetPendingBalance
+// requests
+type GetPendingBalanceArgs struct {
+	Address string `json:"address"`
+	AssetID string `json:"assetID"`
+}
+
+// GetPendingBalanceReply defines the GetPendingBalance replies returned from
+// the API
+type GetPendingBalanceReply struct {
+	// Balance of the address, counting only accepted transactions
+	Confirmed json.Uint64 `json:"confirmed"`
+	// Amount that pending transactions send to the address
+	Incoming json.Uint64 `json:"incoming"`
+	// Amount that pending transactions spend from the address
+	Outgoing json.Uint64 `json:"outgoing"`
+}
+
+// GetPendingBalance returns the balance of an asset that an address at least
+// partially owns, along with how pending transactions would change it
+func (service *Service) GetPendingBalance(r *http.Request, args *GetPendingBalanceArgs, reply *GetPendingBalanceReply) error {
+	service.vm.ctx.Log.Verbo("GetPendingBalance called with address: %s assetID: %s", args.Address, args.AssetID)
+
+	balanceReply := GetBalanceReply{}
+	if err := service.GetBalance(r, &GetBalanceArgs{Address: args.Address, AssetID: args.AssetID}, &balanceReply); err != nil {
+		return err
+	}
+	reply.Confirmed = balanceReply.Balance
+
+	address, err := service.vm.Parse(args.Address)
+	if err != nil {
+		return err
+	}
+	assetID, err := service.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	incoming, outgoing := uint64(0), uint64(0)
+	for _, tx := range service.vm.mempool.Txs() {
+		for _, utxoID := range tx.InputUTXOs() {
+			if utxoID.Symbolic() {
+				continue
+			}
+			utxo, err := service.vm.getUTXO(utxoID)
+			if err != nil {
+				continue
+			}
+			if amount, ok := addressBalance(utxo, address, assetID); ok {
+				if outgoing, err = safemath.Add64(outgoing, amount); err != nil {
+					return err
+				}
+			}
+		}
+		for _, utxo := range tx.UTXOs() {
+			if amount, ok := addressBalance(utxo, address, assetID); ok {
+				if incoming, err = safemath.Add64(incoming, amount); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	reply.Incoming = json.Uint64(incoming)
+	reply.Outgoing = json.Uint64(outgoing)
+	return nil
+}
+
+// addressBalance returns the amount of [assetID] that [utxo] holds, if [address]
+// is one of its owners
+func addressBalance(utxo *ava.UTXO, address []byte, assetID ids.ID) (uint64, bool) {
+	if !utxo.AssetID().Equals(assetID) {
+		return 0, false
+	}
+	addressable, ok := utxo.Out.(ava.Addressable)
+	if !ok {
+		return 0, false
+	}
+	for _, addr := range addressable.Addresses() {
+		if bytes.Equal(addr, address) {
+			return balanceOf(utxo.Out)
+		}
+	}
+	return 0, false
+}
+
 // CreateFixedCapAssetArgs are arguments for passing into CreateFixedCapAsset requests
 type CreateFixedCapAssetArgs struct {
 	Username       string    `json:"username"`
@@ -348,10 +621,53 @@ type CreateFixedCapAssetArgs struct {
 	InitialHolders []*Holder `json:"initialHolders"`
 }
 
-// Holder describes how much an address owns of an asset
+// Holder describes how much an address owns of an asset. If [Owners] is
+// provided, it is used instead of [Address].
 type Holder struct {
-	Amount  json.Uint64 `json:"amount"`
-	Address string      `json:"address"`
+	Amount  json.Uint64   `json:"amount"`
+	Address string        `json:"address"`
+	Owners  *OutputOwners `json:"owners"`
+}
+
+// OutputOwners describes who can spend an output, and when
+type OutputOwners struct {
+	Locktime  json.Uint64 `json:"locktime"`
+	Threshold json.Uint32 `json:"threshold"`
+	Addresses []string    `json:"addresses"`
+}
+
+// parseOwners returns the locktime and owners of an output sent either to the
+// single address [to] or to [owners]
+func (service *Service) parseOwners(to string, owners *OutputOwners) (uint64, secp256k1fx.OutputOwners, error) {
+	if owners == nil {
+		addr, err := service.parseShortID(to)
+		if err != nil {
+			return 0, secp256k1fx.OutputOwners{}, fmt.Errorf("problem parsing to address '%s': %w", to, err)
+		}
+		return 0, secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}, nil
+	}
+	if to != "" {
+		return 0, secp256k1fx.OutputOwners{}, errAddressAndOwners
+	}
+
+	outputOwners := secp256k1fx.OutputOwners{
+		Threshold: uint32(owners.Threshold),
+	}
+	for _, address := range owners.Addresses {
+		addr, err := service.parseShortID(address)
+		if err != nil {
+			return 0, secp256k1fx.OutputOwners{}, fmt.Errorf("problem parsing owner address '%s': %w", address, err)
+		}
+		outputOwners.Addrs = append(outputOwners.Addrs, addr)
+	}
+	outputOwners.Sort()
+	if err := outputOwners.Verify(); err != nil {
+		return 0, secp256k1fx.OutputOwners{}, fmt.Errorf("invalid owners: %w", err)
+	}
+	return uint64(owners.Locktime), outputOwners, nil
 }
 
 // CreateFixedCapAssetReply defines the CreateFixedCapAsset replies returned from the API
@@ -390,20 +706,14 @@ func (service *Service) CreateFixedCapAsset(r *http.Request, args *CreateFixedCa
 	}}
 
 	for _, holder := range args.InitialHolders {
-		address, err := service.vm.Parse(holder.Address)
-		if err != nil {
-			return err
-		}
-		addr, err := ids.ToShortID(address)
+		locktime, owners, err := service.parseOwners(holder.Address, holder.Owners)
 		if err != nil {
 			return err
 		}
 		initialState.Outs = append(initialState.Outs, &secp256k1fx.TransferOutput{
-			Amt: uint64(holder.Amount),
-			OutputOwners: secp256k1fx.OutputOwners{
-				Threshold: 1,
-				Addrs:     []ids.ShortID{addr},
-			},
+			Amt:          uint64(holder.Amount),
+			Locktime:     locktime,
+			OutputOwners: owners,
 		})
 	}
 	initialState.Sort(service.vm.codec)
@@ -511,121 +821,899 @@ func (service *Service) CreateVariableCapAsset(r *http.Request, args *CreateVari
 	return nil
 }
 
-// CreateAddressArgs are arguments for calling CreateAddress
-type CreateAddressArgs struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+// CreateNFTAssetArgs are arguments for passing into CreateNFTAsset requests
+type CreateNFTAssetArgs struct {
+	Username   string   `json:"username"`
+	Password   string   `json:"password"`
+	Name       string   `json:"name"`
+	Symbol     string   `json:"symbol"`
+	MinterSets []Owners `json:"minterSets"`
 }
 
-// CreateAddressReply define the reply from a CreateAddress call
-type CreateAddressReply struct {
-	Address string `json:"address"`
+// CreateNFTAssetReply defines the CreateNFTAsset replies returned from the API
+type CreateNFTAssetReply struct {
+	AssetID ids.ID `json:"assetID"`
 }
 
-// CreateAddress creates an address for the user [args.Username]
-func (service *Service) CreateAddress(r *http.Request, args *CreateAddressArgs, reply *CreateAddressReply) error {
-	service.vm.ctx.Log.Verbo("CreateAddress called for user '%s'", args.Username)
+// CreateNFTAsset returns ID of the newly created asset. Each minter set is
+// given its own group ID, in the order the sets were provided.
+func (service *Service) CreateNFTAsset(r *http.Request, args *CreateNFTAssetArgs, reply *CreateNFTAssetReply) error {
+	service.vm.ctx.Log.Verbo("CreateNFTAsset called with name: %s symbol: %s number of minters: %d",
+		args.Name,
+		args.Symbol,
+		len(args.MinterSets),
+	)
 
-	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
-	if err != nil {
-		return fmt.Errorf("problem retrieving user: %w", err)
+	if len(args.MinterSets) == 0 {
+		return errNoMinters
 	}
 
-	user := userState{vm: service.vm}
-
-	factory := crypto.FactorySECP256K1R{}
-	skIntf, err := factory.NewPrivateKey()
+	fxID, err := service.vm.getFxIndex(&nftfx.Fx{})
 	if err != nil {
-		return fmt.Errorf("problem generating private key: %w", err)
+		return fmt.Errorf("problem looking up nftfx: %w", err)
 	}
-	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
 
-	if err := user.SetKey(db, sk); err != nil {
-		return fmt.Errorf("problem saving private key: %w", err)
+	initialState := &InitialState{
+		FxID: uint32(fxID),
+		Outs: []verify.Verifiable{},
 	}
 
-	addresses, _ := user.Addresses(db)
-	addresses = append(addresses, ids.NewID(hashing.ComputeHash256Array(sk.PublicKey().Address().Bytes())))
+	tx := &Tx{UnsignedTx: &CreateAssetTx{
+		BaseTx: BaseTx{
+			NetID: service.vm.ctx.NetworkID,
+			BCID:  service.vm.ctx.ChainID,
+		},
+		Name:         args.Name,
+		Symbol:       args.Symbol,
+		Denomination: 0,
+		States: []*InitialState{
+			initialState,
+		},
+	}}
 
-	if err := user.SetAddresses(db, addresses); err != nil {
-		return fmt.Errorf("problem saving address: %w", err)
+	for i, owner := range args.MinterSets {
+		minter := &nftfx.MintOutput{
+			GroupID: uint32(i),
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: uint32(owner.Threshold),
+			},
+		}
+		for _, address := range owner.Minters {
+			addrBytes, err := service.vm.Parse(address)
+			if err != nil {
+				return err
+			}
+			addr, err := ids.ToShortID(addrBytes)
+			if err != nil {
+				return err
+			}
+			minter.Addrs = append(minter.Addrs, addr)
+		}
+		ids.SortShortIDs(minter.Addrs)
+		initialState.Outs = append(initialState.Outs, minter)
 	}
+	initialState.Sort(service.vm.codec)
 
-	reply.Address = service.vm.Format(sk.PublicKey().Address().Bytes())
-	return nil
-}
-
-// ExportKeyArgs are arguments for ExportKey
-type ExportKeyArgs struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
-	Address  string `json:"address"`
-}
-
-// ExportKeyReply is the response for ExportKey
-type ExportKeyReply struct {
-	// The decrypted PrivateKey for the Address provided in the arguments
-	PrivateKey formatting.CB58 `json:"privateKey"`
-}
-
-// ExportKey returns a private key from the provided user
-func (service *Service) ExportKey(r *http.Request, args *ExportKeyArgs, reply *ExportKeyReply) error {
-	service.vm.ctx.Log.Verbo("ExportKey called for user '%s'", args.Username)
-
-	address, err := service.vm.Parse(args.Address)
+	b, err := service.vm.codec.Marshal(tx)
 	if err != nil {
-		return fmt.Errorf("problem parsing address: %w", err)
+		return fmt.Errorf("problem creating transaction: %w", err)
 	}
 
-	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	assetID, err := service.vm.IssueTx(b, nil)
 	if err != nil {
-		return fmt.Errorf("problem retrieving user: %w", err)
+		return fmt.Errorf("problem issuing transaction: %w", err)
 	}
 
-	user := userState{vm: service.vm}
-
-	sk, err := user.Key(db, ids.NewID(hashing.ComputeHash256Array(address)))
-	if err != nil {
-		return fmt.Errorf("problem retrieving private key: %w", err)
-	}
+	reply.AssetID = assetID
 
-	reply.PrivateKey.Bytes = sk.Bytes()
 	return nil
 }
 
-// ImportKeyArgs are arguments for ImportKey
-type ImportKeyArgs struct {
-	Username   string          `json:"username"`
-	Password   string          `json:"password"`
-	PrivateKey formatting.CB58 `json:"privateKey"`
+// MintNFTArgs are arguments for passing into MintNFT requests
+type MintNFTArgs struct {
+	Username string          `json:"username"`
+	Password string          `json:"password"`
+	AssetID  string          `json:"assetID"`
+	GroupID  json.Uint32     `json:"groupID"`
+	Payload  formatting.CB58 `json:"payload"`
+	To       string          `json:"to"`
 }
 
-// ImportKeyReply is the response for ImportKey
-type ImportKeyReply struct {
-	// The address controlled by the PrivateKey provided in the arguments
-	Address string `json:"address"`
+// MintNFTReply defines the MintNFT replies returned from the API
+type MintNFTReply struct {
+	TxID ids.ID `json:"txID"`
 }
 
-// ImportKey adds a private key to the provided user
-func (service *Service) ImportKey(r *http.Request, args *ImportKeyArgs, reply *ImportKeyReply) error {
-	service.vm.ctx.Log.Verbo("ImportKey called for user '%s'", args.Username)
+// MintNFT issues a transaction that mints a new NFT of group [args.GroupID]
+// with payload [args.Payload] to the address [args.To]
+func (service *Service) MintNFT(r *http.Request, args *MintNFTArgs, reply *MintNFTReply) error {
+	service.vm.ctx.Log.Verbo("MintNFT called with username: %s", args.Username)
 
-	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
-	if err != nil {
-		return fmt.Errorf("problem retrieving data: %w", err)
+	if len(args.Payload.Bytes) > nftfx.MaxPayloadSize {
+		return errPayloadTooLarge
 	}
 
-	user := userState{vm: service.vm}
+	assetID, err := service.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
 
-	factory := crypto.FactorySECP256K1R{}
-	skIntf, err := factory.ToPrivateKey(args.PrivateKey.Bytes)
+	to, err := service.parseShortID(args.To)
 	if err != nil {
-		return fmt.Errorf("problem parsing private key %s: %w", args.PrivateKey, err)
+		return fmt.Errorf("problem parsing to address: %w", err)
 	}
-	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
 
-	if err := user.SetKey(db, sk); err != nil {
-		return fmt.Errorf("problem saving key %w", err)
+	utxos, kc, err := service.getUserUTXOs(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+
+	for _, utxo := range utxos {
+		if !utxo.AssetID().Equals(assetID) {
+			continue
+		}
+		out, ok := utxo.Out.(*nftfx.MintOutput)
+		if !ok || out.GroupID != uint32(args.GroupID) {
+			continue
+		}
+		sigs, keys, ok := kc.Match(&out.OutputOwners)
+		if !ok {
+			continue
+		}
+
+		tx := Tx{UnsignedTx: &OperationTx{
+			BaseTx: BaseTx{
+				NetID: service.vm.ctx.NetworkID,
+				BCID:  service.vm.ctx.ChainID,
+			},
+			Ops: []*Operation{
+				&Operation{
+					Asset: ava.Asset{ID: assetID},
+					UTXOIDs: []*ava.UTXOID{
+						&utxo.UTXOID,
+					},
+					Op: &nftfx.MintOperation{
+						MintInput: secp256k1fx.Input{
+							SigIndices: sigs,
+						},
+						GroupID: out.GroupID,
+						Payload: args.Payload.Bytes,
+						Outputs: []*secp256k1fx.OutputOwners{
+							&secp256k1fx.OutputOwners{
+								Threshold: 1,
+								Addrs:     []ids.ShortID{to},
+							},
+						},
+					},
+				},
+			},
+		}}
+
+		txID, err := service.signAndIssueTx(&tx, tx.SignNFTFx, keys)
+		if err != nil {
+			return err
+		}
+		reply.TxID = txID
+		return nil
+	}
+
+	return errAddressesCantMintAsset
+}
+
+// SendNFTArgs are arguments for passing into SendNFT requests
+type SendNFTArgs struct {
+	Username string      `json:"username"`
+	Password string      `json:"password"`
+	AssetID  string      `json:"assetID"`
+	GroupID  json.Uint32 `json:"groupID"`
+	To       string      `json:"to"`
+}
+
+// SendNFTReply defines the SendNFT replies returned from the API
+type SendNFTReply struct {
+	TxID ids.ID `json:"txID"`
+}
+
+// SendNFT issues a transaction that sends an NFT of group [args.GroupID],
+// owned by the user, to the address [args.To]
+func (service *Service) SendNFT(r *http.Request, args *SendNFTArgs, reply *SendNFTReply) error {
+	service.vm.ctx.Log.Verbo("SendNFT called with username: %s", args.Username)
+
+	assetID, err := service.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	to, err := service.parseShortID(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address: %w", err)
+	}
+
+	utxos, kc, err := service.getUserUTXOs(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+
+	for _, utxo := range utxos {
+		if !utxo.AssetID().Equals(assetID) {
+			continue
+		}
+		out, ok := utxo.Out.(*nftfx.TransferOutput)
+		if !ok || out.GroupID != uint32(args.GroupID) {
+			continue
+		}
+		sigs, keys, ok := kc.Match(&out.OutputOwners)
+		if !ok {
+			continue
+		}
+
+		tx := Tx{UnsignedTx: &OperationTx{
+			BaseTx: BaseTx{
+				NetID: service.vm.ctx.NetworkID,
+				BCID:  service.vm.ctx.ChainID,
+			},
+			Ops: []*Operation{
+				&Operation{
+					Asset: ava.Asset{ID: assetID},
+					UTXOIDs: []*ava.UTXOID{
+						&utxo.UTXOID,
+					},
+					Op: &nftfx.TransferOperation{
+						Input: secp256k1fx.Input{
+							SigIndices: sigs,
+						},
+						Output: nftfx.TransferOutput{
+							GroupID: out.GroupID,
+							Payload: out.Payload,
+							OutputOwners: secp256k1fx.OutputOwners{
+								Threshold: 1,
+								Addrs:     []ids.ShortID{to},
+							},
+						},
+					},
+				},
+			},
+		}}
+
+		txID, err := service.signAndIssueTx(&tx, tx.SignNFTFx, keys)
+		if err != nil {
+			return err
+		}
+		reply.TxID = txID
+		return nil
+	}
+
+	return errNoSpendableNFT
+}
+
+// lookupAssetID returns the ID of the asset with alias or ID [asset]
+func (service *Service) lookupAssetID(asset string) (ids.ID, error) {
+	assetID, err := service.vm.Lookup(asset)
+	if err != nil {
+		assetID, err = ids.FromString(asset)
+		if err != nil {
+			return ids.ID{}, fmt.Errorf("asset '%s' not found", asset)
+		}
+	}
+	return assetID, nil
+}
+
+// parseShortID parses the formatted address [address]
+func (service *Service) parseShortID(address string) (ids.ShortID, error) {
+	addrBytes, err := service.vm.Parse(address)
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	return ids.ToShortID(addrBytes)
+}
+
+// getUserUTXOs returns the UTXOs referencing any of the addresses controlled by
+// the user, along with a keychain holding the user's keys
+func (service *Service) getUserUTXOs(username, password string) ([]*ava.UTXO, *secp256k1fx.Keychain, error) {
+	kc, err := service.getUserKeychain(username, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addrs := ids.Set{}
+	for _, addr := range kc.Addrs.List() {
+		addrs.Add(ids.NewID(hashing.ComputeHash256Array(addr.Bytes())))
+	}
+	utxos, err := service.vm.GetUTXOs(addrs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("problem retrieving user's UTXOs: %w", err)
+	}
+	return utxos, kc, nil
+}
+
+// getUserKeychain returns a keychain holding the keys of the user [username]
+func (service *Service) getUserKeychain(username, password string) (*secp256k1fx.Keychain, error) {
+	db, err := service.vm.ctx.Keystore.GetDatabase(username, password)
+	if err != nil {
+		return nil, fmt.Errorf("problem retrieving user: %w", err)
+	}
+
+	user := userState{vm: service.vm}
+
+	addresses, _ := user.Addresses(db)
+
+	kc := secp256k1fx.NewKeychain()
+	for _, addr := range addresses {
+		sk, err := user.Key(db, addr)
+		if err != nil {
+			return nil, fmt.Errorf("problem retrieving private key: %w", err)
+		}
+		kc.Add(sk)
+	}
+	return kc, nil
+}
+
+// signAndIssueTx signs the single operation in [tx] with [keys], using the
+// credential type of [sign], and issues the transaction
+func (service *Service) signAndIssueTx(
+	tx *Tx,
+	sign func(codec.Codec, [][]*crypto.PrivateKeySECP256K1R) error,
+	keys []*crypto.PrivateKeySECP256K1R,
+) (ids.ID, error) {
+	if err := sign(service.vm.codec, [][]*crypto.PrivateKeySECP256K1R{keys}); err != nil {
+		return ids.ID{}, fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	b, err := service.vm.codec.Marshal(tx)
+	if err != nil {
+		return ids.ID{}, fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	txID, err := service.vm.IssueTx(b, nil)
+	if err != nil {
+		return ids.ID{}, fmt.Errorf("problem issuing transaction: %w", err)
+	}
+	return txID, nil
+}
+
+// CreatePropertyAssetArgs are arguments for passing into CreatePropertyAsset
+// requests
+type CreatePropertyAssetArgs struct {
+	Username   string   `json:"username"`
+	Password   string   `json:"password"`
+	Name       string   `json:"name"`
+	Symbol     string   `json:"symbol"`
+	MinterSets []Owners `json:"minterSets"`
+}
+
+// CreatePropertyAssetReply defines the CreatePropertyAsset replies returned
+// from the API
+type CreatePropertyAssetReply struct {
+	AssetID ids.ID `json:"assetID"`
+}
+
+// CreatePropertyAsset returns ID of the newly created property asset
+func (service *Service) CreatePropertyAsset(r *http.Request, args *CreatePropertyAssetArgs, reply *CreatePropertyAssetReply) error {
+	service.vm.ctx.Log.Verbo("CreatePropertyAsset called with name: %s symbol: %s number of minters: %d",
+		args.Name,
+		args.Symbol,
+		len(args.MinterSets),
+	)
+
+	if len(args.MinterSets) == 0 {
+		return errNoMinters
+	}
+
+	fxID, err := service.vm.getFxIndex(&propertyfx.Fx{})
+	if err != nil {
+		return fmt.Errorf("problem looking up propertyfx: %w", err)
+	}
+
+	initialState := &InitialState{
+		FxID: uint32(fxID),
+		Outs: []verify.Verifiable{},
+	}
+
+	tx := &Tx{UnsignedTx: &CreateAssetTx{
+		BaseTx: BaseTx{
+			NetID: service.vm.ctx.NetworkID,
+			BCID:  service.vm.ctx.ChainID,
+		},
+		Name:         args.Name,
+		Symbol:       args.Symbol,
+		Denomination: 0,
+		States: []*InitialState{
+			initialState,
+		},
+	}}
+
+	for _, owner := range args.MinterSets {
+		minter := &propertyfx.MintOutput{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: uint32(owner.Threshold),
+			},
+		}
+		for _, address := range owner.Minters {
+			addr, err := service.parseShortID(address)
+			if err != nil {
+				return err
+			}
+			minter.Addrs = append(minter.Addrs, addr)
+		}
+		ids.SortShortIDs(minter.Addrs)
+		initialState.Outs = append(initialState.Outs, minter)
+	}
+	initialState.Sort(service.vm.codec)
+
+	b, err := service.vm.codec.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	assetID, err := service.vm.IssueTx(b, nil)
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+
+	reply.AssetID = assetID
+
+	return nil
+}
+
+// MintPropertyArgs are arguments for passing into MintProperty requests
+type MintPropertyArgs struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	AssetID  string `json:"assetID"`
+	To       string `json:"to"`
+}
+
+// MintPropertyReply defines the MintProperty replies returned from the API
+type MintPropertyReply struct {
+	TxID ids.ID `json:"txID"`
+}
+
+// MintProperty issues a transaction that gives [args.To] ownership of the
+// property asset [args.AssetID]. The current owners of the property keep their
+// ownership. Use TransferProperty to move ownership.
+func (service *Service) MintProperty(r *http.Request, args *MintPropertyArgs, reply *MintPropertyReply) error {
+	service.vm.ctx.Log.Verbo("MintProperty called with username: %s", args.Username)
+
+	assetID, err := service.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	to, err := service.parseShortID(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address: %w", err)
+	}
+
+	utxos, kc, err := service.getUserUTXOs(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+
+	for _, utxo := range utxos {
+		if !utxo.AssetID().Equals(assetID) {
+			continue
+		}
+		out, ok := utxo.Out.(*propertyfx.MintOutput)
+		if !ok {
+			continue
+		}
+		sigs, keys, ok := kc.Match(&out.OutputOwners)
+		if !ok {
+			continue
+		}
+
+		tx := Tx{UnsignedTx: &OperationTx{
+			BaseTx: BaseTx{
+				NetID: service.vm.ctx.NetworkID,
+				BCID:  service.vm.ctx.ChainID,
+			},
+			Ops: []*Operation{
+				&Operation{
+					Asset: ava.Asset{ID: assetID},
+					UTXOIDs: []*ava.UTXOID{
+						&utxo.UTXOID,
+					},
+					Op: &propertyfx.MintOperation{
+						MintInput: secp256k1fx.Input{
+							SigIndices: sigs,
+						},
+						MintOutput: propertyfx.MintOutput{
+							OutputOwners: out.OutputOwners,
+						},
+						OwnedOutput: propertyfx.OwnedOutput{
+							OutputOwners: secp256k1fx.OutputOwners{
+								Threshold: 1,
+								Addrs:     []ids.ShortID{to},
+							},
+						},
+					},
+				},
+			},
+		}}
+
+		txID, err := service.signAndIssueTx(&tx, tx.SignPropertyFx, keys)
+		if err != nil {
+			return err
+		}
+		reply.TxID = txID
+		return nil
+	}
+
+	return errAddressesCantMintAsset
+}
+
+// TransferPropertyArgs are arguments for passing into TransferProperty requests
+type TransferPropertyArgs struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	AssetID  string `json:"assetID"`
+	To       string `json:"to"`
+}
+
+// TransferPropertyReply defines the TransferProperty replies returned from the
+// API
+type TransferPropertyReply struct {
+	TxID ids.ID `json:"txID"`
+}
+
+// TransferProperty issues a transaction that moves the user's ownership of the
+// property asset [args.AssetID] to [args.To]. Owned property outputs can only
+// be burned, so the transaction burns the user's owned output and mints a new
+// one to [args.To]. The user must own the property and be able to mint it.
+func (service *Service) TransferProperty(r *http.Request, args *TransferPropertyArgs, reply *TransferPropertyReply) error {
+	service.vm.ctx.Log.Verbo("TransferProperty called with username: %s", args.Username)
+
+	assetID, err := service.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	to, err := service.parseShortID(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address: %w", err)
+	}
+
+	utxos, kc, err := service.getUserUTXOs(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+
+	var burnOp, mintOp *Operation
+	// Key: operation
+	// Value: keys that sign the operation
+	opKeys := map[*Operation][]*crypto.PrivateKeySECP256K1R{}
+	for _, utxo := range utxos {
+		if !utxo.AssetID().Equals(assetID) {
+			continue
+		}
+		switch out := utxo.Out.(type) {
+		case *propertyfx.OwnedOutput:
+			if burnOp != nil {
+				continue
+			}
+			sigs, keys, ok := kc.Match(&out.OutputOwners)
+			if !ok {
+				continue
+			}
+			burnOp = &Operation{
+				Asset:   ava.Asset{ID: assetID},
+				UTXOIDs: []*ava.UTXOID{&utxo.UTXOID},
+				Op: &propertyfx.BurnOperation{
+					Input: secp256k1fx.Input{SigIndices: sigs},
+				},
+			}
+			opKeys[burnOp] = keys
+		case *propertyfx.MintOutput:
+			if mintOp != nil {
+				continue
+			}
+			sigs, keys, ok := kc.Match(&out.OutputOwners)
+			if !ok {
+				continue
+			}
+			mintOp = &Operation{
+				Asset:   ava.Asset{ID: assetID},
+				UTXOIDs: []*ava.UTXOID{&utxo.UTXOID},
+				Op: &propertyfx.MintOperation{
+					MintInput: secp256k1fx.Input{SigIndices: sigs},
+					MintOutput: propertyfx.MintOutput{
+						OutputOwners: out.OutputOwners,
+					},
+					OwnedOutput: propertyfx.OwnedOutput{
+						OutputOwners: secp256k1fx.OutputOwners{
+							Threshold: 1,
+							Addrs:     []ids.ShortID{to},
+						},
+					},
+				},
+			}
+			opKeys[mintOp] = keys
+		}
+	}
+	switch {
+	case burnOp == nil:
+		return errNoOwnedProperty
+	case mintOp == nil:
+		return errAddressesCantMintAsset
+	}
+
+	ops := []*Operation{burnOp, mintOp}
+	sortOperations(ops, service.vm.codec)
+	signers := [][]*crypto.PrivateKeySECP256K1R{}
+	for _, op := range ops {
+		signers = append(signers, opKeys[op])
+	}
+
+	tx := Tx{UnsignedTx: &OperationTx{
+		BaseTx: BaseTx{
+			NetID: service.vm.ctx.NetworkID,
+			BCID:  service.vm.ctx.ChainID,
+		},
+		Ops: ops,
+	}}
+	if err := tx.SignPropertyFx(service.vm.codec, signers); err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	b, err := service.vm.codec.Marshal(&tx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	txID, err := service.vm.IssueTx(b, nil)
+	if err != nil {
+		return fmt.Errorf("problem issuing transaction: %w", err)
+	}
+	reply.TxID = txID
+	return nil
+}
+
+// BurnPropertyArgs are arguments for passing into BurnProperty requests
+type BurnPropertyArgs struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	AssetID  string `json:"assetID"`
+}
+
+// BurnPropertyReply defines the BurnProperty replies returned from the API
+type BurnPropertyReply struct {
+	TxID ids.ID `json:"txID"`
+}
+
+// BurnProperty issues a transaction that destroys the user's ownership of the
+// property asset [args.AssetID]
+func (service *Service) BurnProperty(r *http.Request, args *BurnPropertyArgs, reply *BurnPropertyReply) error {
+	service.vm.ctx.Log.Verbo("BurnProperty called with username: %s", args.Username)
+
+	assetID, err := service.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	utxos, kc, err := service.getUserUTXOs(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+
+	for _, utxo := range utxos {
+		if !utxo.AssetID().Equals(assetID) {
+			continue
+		}
+		out, ok := utxo.Out.(*propertyfx.OwnedOutput)
+		if !ok {
+			continue
+		}
+		sigs, keys, ok := kc.Match(&out.OutputOwners)
+		if !ok {
+			continue
+		}
+
+		tx := Tx{UnsignedTx: &OperationTx{
+			BaseTx: BaseTx{
+				NetID: service.vm.ctx.NetworkID,
+				BCID:  service.vm.ctx.ChainID,
+			},
+			Ops: []*Operation{
+				&Operation{
+					Asset: ava.Asset{ID: assetID},
+					UTXOIDs: []*ava.UTXOID{
+						&utxo.UTXOID,
+					},
+					Op: &propertyfx.BurnOperation{
+						Input: secp256k1fx.Input{
+							SigIndices: sigs,
+						},
+					},
+				},
+			},
+		}}
+
+		txID, err := service.signAndIssueTx(&tx, tx.SignPropertyFx, keys)
+		if err != nil {
+			return err
+		}
+		reply.TxID = txID
+		return nil
+	}
+
+	return errNoOwnedProperty
+}
+
+// GetOwnedPropertiesArgs are arguments for passing into GetOwnedProperties
+// requests
+type GetOwnedPropertiesArgs struct {
+	Address string `json:"address"`
+}
+
+// OwnedProperty describes an output that gives ownership of a property asset
+type OwnedProperty struct {
+	AssetID   string      `json:"assetID"`
+	UTXOID    ava.UTXOID  `json:"utxoID"`
+	Threshold json.Uint32 `json:"threshold"`
+	Owners    []string    `json:"owners"`
+}
+
+// GetOwnedPropertiesReply defines the GetOwnedProperties replies returned from
+// the API
+type GetOwnedPropertiesReply struct {
+	Properties []OwnedProperty `json:"properties"`
+}
+
+// GetOwnedProperties returns the property outputs that [args.Address] at least
+// partially owns
+func (service *Service) GetOwnedProperties(r *http.Request, args *GetOwnedPropertiesArgs, reply *GetOwnedPropertiesReply) error {
+	service.vm.ctx.Log.Verbo("GetOwnedProperties called with address: %s", args.Address)
+
+	address, err := service.vm.Parse(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse given address: %w", err)
+	}
+	addrSet := ids.Set{}
+	addrSet.Add(ids.NewID(hashing.ComputeHash256Array(address)))
+
+	utxos, err := service.vm.GetUTXOs(addrSet)
+	if err != nil {
+		return fmt.Errorf("couldn't get address's UTXOs: %w", err)
+	}
+
+	reply.Properties = []OwnedProperty{}
+	for _, utxo := range utxos {
+		out, ok := utxo.Out.(*propertyfx.OwnedOutput)
+		if !ok {
+			continue
+		}
+		assetID := utxo.AssetID()
+		property := OwnedProperty{
+			AssetID:   assetID.String(),
+			UTXOID:    utxo.UTXOID,
+			Threshold: json.Uint32(out.Threshold),
+		}
+		if alias, err := service.vm.PrimaryAlias(assetID); err == nil {
+			property.AssetID = alias
+		}
+		for _, owner := range out.Addrs {
+			property.Owners = append(property.Owners, service.vm.Format(owner.Bytes()))
+		}
+		reply.Properties = append(reply.Properties, property)
+	}
+	return nil
+}
+
+// CreateAddressArgs are arguments for calling CreateAddress
+type CreateAddressArgs struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// CreateAddressReply define the reply from a CreateAddress call
+type CreateAddressReply struct {
+	Address string `json:"address"`
+}
+
+// CreateAddress creates an address for the user [args.Username]
+func (service *Service) CreateAddress(r *http.Request, args *CreateAddressArgs, reply *CreateAddressReply) error {
+	service.vm.ctx.Log.Verbo("CreateAddress called for user '%s'", args.Username)
+
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user: %w", err)
+	}
+
+	user := userState{vm: service.vm}
+
+	factory := crypto.FactorySECP256K1R{}
+	skIntf, err := factory.NewPrivateKey()
+	if err != nil {
+		return fmt.Errorf("problem generating private key: %w", err)
+	}
+	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
+
+	if err := user.SetKey(db, sk); err != nil {
+		return fmt.Errorf("problem saving private key: %w", err)
+	}
+
+	addresses, _ := user.Addresses(db)
+	addresses = append(addresses, ids.NewID(hashing.ComputeHash256Array(sk.PublicKey().Address().Bytes())))
+
+	if err := user.SetAddresses(db, addresses); err != nil {
+		return fmt.Errorf("problem saving address: %w", err)
+	}
+
+	reply.Address = service.vm.Format(sk.PublicKey().Address().Bytes())
+	return nil
+}
+
+// ExportKeyArgs are arguments for ExportKey
+type ExportKeyArgs struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Address  string `json:"address"`
+}
+
+// ExportKeyReply is the response for ExportKey
+type ExportKeyReply struct {
+	// The decrypted PrivateKey for the Address provided in the arguments
+	PrivateKey formatting.CB58 `json:"privateKey"`
+}
+
+// ExportKey returns a private key from the provided user
+func (service *Service) ExportKey(r *http.Request, args *ExportKeyArgs, reply *ExportKeyReply) error {
+	service.vm.ctx.Log.Verbo("ExportKey called for user '%s'", args.Username)
+
+	address, err := service.vm.Parse(args.Address)
+	if err != nil {
+		return fmt.Errorf("problem parsing address: %w", err)
+	}
+
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving user: %w", err)
+	}
+
+	user := userState{vm: service.vm}
+
+	sk, err := user.Key(db, ids.NewID(hashing.ComputeHash256Array(address)))
+	if err != nil {
+		return fmt.Errorf("problem retrieving private key: %w", err)
+	}
+
+	reply.PrivateKey.Bytes = sk.Bytes()
+	return nil
+}
+
+// ImportKeyArgs are arguments for ImportKey
+type ImportKeyArgs struct {
+	Username   string          `json:"username"`
+	Password   string          `json:"password"`
+	PrivateKey formatting.CB58 `json:"privateKey"`
+}
+
+// ImportKeyReply is the response for ImportKey
+type ImportKeyReply struct {
+	// The address controlled by the PrivateKey provided in the arguments
+	Address string `json:"address"`
+}
+
+// ImportKey adds a private key to the provided user
+func (service *Service) ImportKey(r *http.Request, args *ImportKeyArgs, reply *ImportKeyReply) error {
+	service.vm.ctx.Log.Verbo("ImportKey called for user '%s'", args.Username)
+
+	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
+	if err != nil {
+		return fmt.Errorf("problem retrieving data: %w", err)
+	}
+
+	user := userState{vm: service.vm}
+
+	factory := crypto.FactorySECP256K1R{}
+	skIntf, err := factory.ToPrivateKey(args.PrivateKey.Bytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing private key %s: %w", args.PrivateKey, err)
+	}
+	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
+
+	if err := user.SetKey(db, sk); err != nil {
+		return fmt.Errorf("problem saving key %w", err)
 	}
 
 	addresses, _ := user.Addresses(db)
@@ -646,6 +1734,9 @@ type SendArgs struct {
 	Amount   json.Uint64 `json:"amount"`
 	AssetID  string      `json:"assetID"`
 	To       string      `json:"to"`
+
+	// If provided, the funds are sent to these owners rather than to [To]
+	Owners *OutputOwners `json:"owners"`
 }
 
 // SendReply defines the Send replies returned from the API
@@ -669,13 +1760,9 @@ func (service *Service) Send(r *http.Request, args *SendArgs, reply *SendReply)
 		}
 	}
 
-	toBytes, err := service.vm.Parse(args.To)
-	if err != nil {
-		return fmt.Errorf("problem parsing to address: %w", err)
-	}
-	to, err := ids.ToShortID(toBytes)
+	locktime, owners, err := service.parseOwners(args.To, args.Owners)
 	if err != nil {
-		return fmt.Errorf("problem parsing to address: %w", err)
+		return err
 	}
 
 	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
@@ -749,12 +1836,9 @@ func (service *Service) Send(r *http.Request, args *SendArgs, reply *SendReply)
 	outs := []*ava.TransferableOutput{&ava.TransferableOutput{
 		Asset: ava.Asset{ID: assetID},
 		Out: &secp256k1fx.TransferOutput{
-			Amt:      uint64(args.Amount),
-			Locktime: 0,
-			OutputOwners: secp256k1fx.OutputOwners{
-				Threshold: 1,
-				Addrs:     []ids.ShortID{to},
-			},
+			Amt:          uint64(args.Amount),
+			Locktime:     locktime,
+			OutputOwners: owners,
 		},
 	}}
 
@@ -784,26 +1868,9 @@ func (service *Service) Send(r *http.Request, args *SendArgs, reply *SendReply)
 		},
 	}
 
-	unsignedBytes, err := service.vm.codec.Marshal(&tx.UnsignedTx)
-	if err != nil {
+	if err := tx.SignSECP256K1Fx(service.vm.codec, keys); err != nil {
 		return fmt.Errorf("problem creating transaction: %w", err)
 	}
-	hash := hashing.ComputeHash256(unsignedBytes)
-
-	for _, credKeys := range keys {
-		cred := &secp256k1fx.Credential{}
-		for _, key := range credKeys {
-			sig, err := key.SignHash(hash)
-			if err != nil {
-				return fmt.Errorf("problem creating transaction: %w", err)
-			}
-			fixedSig := [crypto.SECP256K1RSigLen]byte{}
-			copy(fixedSig[:], sig)
-
-			cred.Sigs = append(cred.Sigs, fixedSig)
-		}
-		tx.Creds = append(tx.Creds, cred)
-	}
 
 	b, err := service.vm.codec.Marshal(tx)
 	if err != nil {
@@ -1055,6 +2122,386 @@ func (service *Service) SignMintTx(r *http.Request, args *SignMintTxArgs, reply
 	return nil
 }
 
+// BuildUnsignedSendArgs are arguments for passing into BuildUnsignedSend
+// requests
+type BuildUnsignedSendArgs struct {
+	// Addresses that will sign the transaction. Only UTXOs that can be spent
+	// by these addresses are consumed.
+	From    []string    `json:"from"`
+	Amount  json.Uint64 `json:"amount"`
+	AssetID string      `json:"assetID"`
+	To      string      `json:"to"`
+}
+
+// BuildUnsignedSendReply defines the BuildUnsignedSend replies returned from
+// the API
+type BuildUnsignedSendReply struct {
+	Tx formatting.CB58 `json:"tx"`
+}
+
+// BuildUnsignedSend returns a transaction that sends [args.Amount] of
+// [args.AssetID] to [args.To]. The transaction's credentials contain an empty
+// signature for every signature that is required, to be filled in by SignTx.
+// Any change is returned to the owners of the first UTXO that is spent.
+func (service *Service) BuildUnsignedSend(r *http.Request, args *BuildUnsignedSendArgs, reply *BuildUnsignedSendReply) error {
+	service.vm.ctx.Log.Verbo("BuildUnsignedSend called with %d signers", len(args.From))
+
+	if args.Amount == 0 {
+		return errInvalidAmount
+	}
+
+	assetID, err := service.lookupAssetID(args.AssetID)
+	if err != nil {
+		return err
+	}
+
+	to, err := service.parseShortID(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address: %w", err)
+	}
+
+	addrs := ids.Set{}
+	signers := ids.ShortSet{}
+	for _, from := range args.From {
+		addr, err := service.parseShortID(from)
+		if err != nil {
+			return fmt.Errorf("problem parsing from address '%s': %w", from, err)
+		}
+		addrs.Add(ids.NewID(hashing.ComputeHash256Array(addr.Bytes())))
+		signers.Add(addr)
+	}
+
+	utxos, err := service.vm.GetUTXOs(addrs)
+	if err != nil {
+		return fmt.Errorf("problem retrieving UTXOs: %w", err)
+	}
+
+	amountSpent := uint64(0)
+	time := service.vm.clock.Unix()
+
+	ins := []*ava.TransferableInput{}
+	var changeOwners *secp256k1fx.OutputOwners
+	for _, utxo := range utxos {
+		if !utxo.AssetID().Equals(assetID) {
+			continue
+		}
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok || time < out.Locktime {
+			continue
+		}
+		sigIndices, ok := matchOwners(&out.OutputOwners, signers)
+		if !ok {
+			continue
+		}
+		spent, err := safemath.Add64(amountSpent, out.Amount())
+		if err != nil {
+			return errSpendOverflow
+		}
+		amountSpent = spent
+
+		if changeOwners == nil {
+			changeOwners = &out.OutputOwners
+		}
+
+		ins = append(ins, &ava.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  ava.Asset{ID: assetID},
+			In: &secp256k1fx.TransferInput{
+				Amt: out.Amount(),
+				Input: secp256k1fx.Input{
+					SigIndices: sigIndices,
+				},
+			},
+		})
+
+		if amountSpent >= uint64(args.Amount) {
+			break
+		}
+	}
+
+	if amountSpent < uint64(args.Amount) {
+		return errInsufficientFunds
+	}
+
+	ava.SortTransferableInputs(ins)
+
+	creds := []verify.Verifiable{}
+	for _, in := range ins {
+		input := in.In.(*secp256k1fx.TransferInput)
+		creds = append(creds, &secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(input.SigIndices)),
+		})
+	}
+
+	outs := []*ava.TransferableOutput{&ava.TransferableOutput{
+		Asset: ava.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: uint64(args.Amount),
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{to},
+			},
+		},
+	}}
+
+	if amountSpent > uint64(args.Amount) {
+		outs = append(outs, &ava.TransferableOutput{
+			Asset: ava.Asset{ID: assetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amountSpent - uint64(args.Amount),
+				OutputOwners: *changeOwners,
+			},
+		})
+	}
+
+	ava.SortTransferableOutputs(outs, service.vm.codec)
+
+	tx := Tx{
+		UnsignedTx: &BaseTx{
+			NetID: service.vm.ctx.NetworkID,
+			BCID:  service.vm.ctx.ChainID,
+			Outs:  outs,
+			Ins:   ins,
+		},
+		Creds: creds,
+	}
+
+	txBytes, err := service.vm.codec.Marshal(&tx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+	reply.Tx.Bytes = txBytes
+	return nil
+}
+
+// matchOwners returns the indices of the first addresses in [owners] that are
+// in [signers], up to the threshold. Returns false if the threshold can't be
+// met.
+func matchOwners(owners *secp256k1fx.OutputOwners, signers ids.ShortSet) ([]uint32, bool) {
+	sigIndices := []uint32{}
+	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(sigIndices)) < owners.Threshold; i++ {
+		if signers.Contains(owners.Addrs[i]) {
+			sigIndices = append(sigIndices, i)
+		}
+	}
+	return sigIndices, uint32(len(sigIndices)) == owners.Threshold
+}
+
+// SignTxArgs are arguments for passing into SignTx requests
+type SignTxArgs struct {
+	Username string          `json:"username"`
+	Password string          `json:"password"`
+	Tx       formatting.CB58 `json:"tx"`
+}
+
+// SignTxReply defines the SignTx replies returned from the API
+type SignTxReply struct {
+	Tx       formatting.CB58 `json:"tx"`
+	Complete bool            `json:"complete"`
+}
+
+// SignTx adds to [args.Tx] every missing signature that can be produced by
+// the user's keys. Signatures that are already present are kept.
+func (service *Service) SignTx(r *http.Request, args *SignTxArgs, reply *SignTxReply) error {
+	service.vm.ctx.Log.Verbo("SignTx called with username: %s", args.Username)
+
+	tx := Tx{}
+	if err := service.vm.codec.Unmarshal(args.Tx.Bytes, &tx); err != nil {
+		return fmt.Errorf("problem parsing transaction: %w", err)
+	}
+
+	ins, creds, err := secp256k1fxInputs(&tx)
+	if err != nil {
+		return err
+	}
+
+	kc, err := service.getUserKeychain(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+
+	unsignedBytes, err := service.vm.codec.Marshal(&tx.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+	hash := hashing.ComputeHash256(unsignedBytes)
+
+	for i, in := range ins {
+		utxo, err := service.vm.getUTXO(&in.UTXOID)
+		if err != nil {
+			return err
+		}
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return errUnknownOutputType
+		}
+		input, ok := in.In.(*secp256k1fx.TransferInput)
+		if !ok {
+			return errUnknownInputType
+		}
+		cred := creds[i]
+		if len(cred.Sigs) != len(input.SigIndices) {
+			cred.Sigs = make([][crypto.SECP256K1RSigLen]byte, len(input.SigIndices))
+		}
+
+		for j, addrIndex := range input.SigIndices {
+			if addrIndex >= uint32(len(out.Addrs)) {
+				return errInputOutputMismatch
+			}
+			if cred.Sigs[j] != emptySig {
+				continue
+			}
+			key, exists := kc.Get(out.Addrs[addrIndex])
+			if !exists {
+				continue
+			}
+			sig, err := key.SignHash(hash)
+			if err != nil {
+				return fmt.Errorf("problem signing transaction: %w", err)
+			}
+			copy(cred.Sigs[j][:], sig)
+		}
+	}
+
+	txBytes, err := service.vm.codec.Marshal(&tx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+	reply.Tx.Bytes = txBytes
+	reply.Complete = isFullySigned(creds)
+	return nil
+}
+
+// CombineSignaturesArgs are arguments for passing into CombineSignatures
+// requests
+type CombineSignaturesArgs struct {
+	Txs []formatting.CB58 `json:"txs"`
+}
+
+// CombineSignaturesReply defines the CombineSignatures replies returned from
+// the API
+type CombineSignaturesReply struct {
+	Tx       formatting.CB58 `json:"tx"`
+	Complete bool            `json:"complete"`
+}
+
+// CombineSignatures merges the signatures of several partially signed copies of
+// the same transaction into a single transaction
+func (service *Service) CombineSignatures(r *http.Request, args *CombineSignaturesArgs, reply *CombineSignaturesReply) error {
+	service.vm.ctx.Log.Verbo("CombineSignatures called with %d txs", len(args.Txs))
+
+	if len(args.Txs) == 0 {
+		return errNoTxs
+	}
+
+	combined := Tx{}
+	if err := service.vm.codec.Unmarshal(args.Txs[0].Bytes, &combined); err != nil {
+		return fmt.Errorf("problem parsing transaction: %w", err)
+	}
+	_, combinedCreds, err := secp256k1fxInputs(&combined)
+	if err != nil {
+		return err
+	}
+	unsignedBytes, err := service.vm.codec.Marshal(&combined.UnsignedTx)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+
+	for _, txBytes := range args.Txs[1:] {
+		tx := Tx{}
+		if err := service.vm.codec.Unmarshal(txBytes.Bytes, &tx); err != nil {
+			return fmt.Errorf("problem parsing transaction: %w", err)
+		}
+		otherUnsignedBytes, err := service.vm.codec.Marshal(&tx.UnsignedTx)
+		if err != nil {
+			return fmt.Errorf("problem creating transaction: %w", err)
+		}
+		if !bytes.Equal(unsignedBytes, otherUnsignedBytes) {
+			return errMismatchedTxs
+		}
+		_, creds, err := secp256k1fxInputs(&tx)
+		if err != nil {
+			return err
+		}
+
+		for i, cred := range creds {
+			combinedCred := combinedCreds[i]
+			switch {
+			case len(cred.Sigs) == 0:
+				// This copy wasn't signed for this input
+				continue
+			case len(combinedCred.Sigs) == 0:
+				// None of the copies merged so far were signed for this input
+				combinedCred.Sigs = make([][crypto.SECP256K1RSigLen]byte, len(cred.Sigs))
+			case len(cred.Sigs) != len(combinedCred.Sigs):
+				return errMismatchedTxs
+			}
+			for j, sig := range cred.Sigs {
+				switch {
+				case sig == emptySig:
+				case combinedCred.Sigs[j] == emptySig:
+					combinedCred.Sigs[j] = sig
+				case combinedCred.Sigs[j] != sig:
+					return errConflictingSignatures
+				}
+			}
+		}
+	}
+
+	txBytes, err := service.vm.codec.Marshal(&combined)
+	if err != nil {
+		return fmt.Errorf("problem creating transaction: %w", err)
+	}
+	reply.Tx.Bytes = txBytes
+	reply.Complete = isFullySigned(combinedCreds)
+	return nil
+}
+
+// secp256k1fxInputs returns the inputs of [tx] along with their credentials.
+// If [tx] has no credentials yet, empty ones are added.
+func secp256k1fxInputs(tx *Tx) ([]*ava.TransferableInput, []*secp256k1fx.Credential, error) {
+	var ins []*ava.TransferableInput
+	switch unsignedTx := tx.UnsignedTx.(type) {
+	case *BaseTx:
+		ins = unsignedTx.Ins
+	case *ExportTx:
+		ins = unsignedTx.Ins
+	default:
+		return nil, nil, errUnsupportedTxType
+	}
+
+	if len(tx.Creds) == 0 {
+		for range ins {
+			tx.Creds = append(tx.Creds, &secp256k1fx.Credential{})
+		}
+	}
+	if len(tx.Creds) != len(ins) {
+		return nil, nil, errWrongNumberOfCredentials
+	}
+
+	creds := make([]*secp256k1fx.Credential, len(tx.Creds))
+	for i, credIntf := range tx.Creds {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			return nil, nil, errUnknownCredentialType
+		}
+		creds[i] = cred
+	}
+	return ins, creds, nil
+}
+
+// isFullySigned returns true if none of the signatures in [creds] are missing
+func isFullySigned(creds []*secp256k1fx.Credential) bool {
+	for _, cred := range creds {
+		for _, sig := range cred.Sigs {
+			if sig == emptySig {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // ImportAVAArgs are arguments for passing into ImportAVA requests
 type ImportAVAArgs struct {
 	// User that controls To
@@ -1210,8 +2657,9 @@ type ExportAVAArgs struct {
 	// Amount of nAVA to send
 	Amount json.Uint64 `json:"amount"`
 
-	// ID of P-Chain account that will receive the AVA
-	To ids.ShortID `json:"to"`
+	// Address of the P-Chain account that will receive the AVA, without a chain
+	// prefix. May be given in either CB58 or bech32.
+	To string `json:"to"`
 }
 
 // ExportAVAReply defines the Send replies returned from the API
@@ -1229,6 +2677,15 @@ func (service *Service) ExportAVA(_ *http.Request, args *ExportAVAArgs, reply *E
 		return errInvalidAmount
 	}
 
+	toBytes, err := service.vm.parseRawAddress(args.To)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address: %w", err)
+	}
+	to, err := ids.ToShortID(toBytes)
+	if err != nil {
+		return fmt.Errorf("problem parsing to address: %w", err)
+	}
+
 	db, err := service.vm.ctx.Keystore.GetDatabase(args.Username, args.Password)
 	if err != nil {
 		return fmt.Errorf("problem retrieving user: %w", err)
@@ -1304,7 +2761,7 @@ func (service *Service) ExportAVA(_ *http.Request, args *ExportAVAArgs, reply *E
 			Locktime: 0,
 			OutputOwners: secp256k1fx.OutputOwners{
 				Threshold: 1,
-				Addrs:     []ids.ShortID{args.To},
+				Addrs:     []ids.ShortID{to},
 			},
 		},
 	}}
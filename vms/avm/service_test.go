@@ -4,14 +4,33 @@
 package avm
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ava-labs/gecko/api/keystore"
+	"github.com/ava-labs/gecko/database/memdb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/choices"
+	"github.com/ava-labs/gecko/snow/engine/common"
+	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/json"
+	"github.com/ava-labs/gecko/utils/logging"
+	"github.com/ava-labs/gecko/vms/components/verify"
+	"github.com/ava-labs/gecko/vms/nftfx"
+	"github.com/ava-labs/gecko/vms/propertyfx"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
+)
+
+const (
+	testUsername = "bob"
+	testPassword = "N_+=_jJ;^(<;{4,:*m6CET}'&N;83FYK.wtNpwp-Jt"
 )
 
 func setup(t *testing.T) ([]byte, *VM, *Service) {
@@ -20,6 +39,77 @@ func setup(t *testing.T) ([]byte, *VM, *Service) {
 	return genesisBytes, vm, s
 }
 
+// setupWithKeystore initializes a VM with the provided fxs and a keystore user
+// that controls keys[0]
+func setupWithKeystore(t *testing.T, fxs ...Fx) ([]byte, *VM, *Service) {
+	genesisBytes := BuildGenesisTest(t)
+
+	ks := keystore.Keystore{}
+	ks.Initialize(logging.NoLog{}, memdb.New())
+	if err := ks.CreateUser(nil, &keystore.CreateUserArgs{
+		Username: testUsername,
+		Password: testPassword,
+	}, &keystore.CreateUserReply{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// NB: this lock is intentionally left locked when this function returns.
+	// The caller of this function is responsible for unlocking.
+	ctx.Lock.Lock()
+	ctx.Keystore = ks.NewBlockchainKeyStore(chainID)
+
+	commonFxs := []*common.Fx{}
+	for i, fx := range fxs {
+		commonFxs = append(commonFxs, &common.Fx{
+			ID: ids.Empty.Prefix(uint64(i)),
+			Fx: fx,
+		})
+	}
+
+	vm := &VM{}
+	if err := vm.Initialize(
+		ctx,
+		memdb.New(),
+		genesisBytes,
+		make(chan common.Message, 1),
+		commonFxs,
+	); err != nil {
+		t.Fatal(err)
+	}
+	vm.batchTimeout = 0
+
+	setUserKeys(t, vm, keys[0])
+	return genesisBytes, vm, &Service{vm: vm}
+}
+
+// setUserKeys replaces the keys controlled by the keystore user with [sks]
+func setUserKeys(t *testing.T, vm *VM, sks ...*crypto.PrivateKeySECP256K1R) {
+	db, err := ctx.Keystore.GetDatabase(testUsername, testPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := userState{vm: vm}
+	addrs := []ids.ID{}
+	for _, sk := range sks {
+		if err := user.SetKey(db, sk); err != nil {
+			t.Fatal(err)
+		}
+		addrs = append(addrs, ids.NewID(hashing.ComputeHash256Array(sk.PublicKey().Address().Bytes())))
+	}
+	if err := user.SetAddresses(db, addrs); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// acceptPendingTx accepts the single transaction that is pending in [vm]
+func acceptPendingTx(t *testing.T, vm *VM) {
+	txs := vm.PendingTxs()
+	if len(txs) != 1 {
+		t.Fatalf("Should have returned %d tx(s)", 1)
+	}
+	txs[0].Accept()
+}
+
 func TestServiceIssueTx(t *testing.T) {
 	genesisBytes, vm, s := setup(t)
 	defer func() {
@@ -128,6 +218,66 @@ func TestServiceGetTx(t *testing.T) {
 	assert.Equal(t, genesisTxBytes, reply.Tx.Bytes, "Wrong tx returned from service.GetTx")
 }
 
+func TestServiceGetTxJSON(t *testing.T) {
+	genesisBytes, vm, s := setupWithKeystore(t, &secp256k1fx.Fx{})
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+
+	if err := s.GetTx(nil, &GetTxArgs{TxID: genesisTx.ID(), Encoding: "hex"}, &GetTxReply{}); err == nil {
+		t.Fatal("Should have errored because the encoding is unknown")
+	}
+
+	reply := GetTxReply{}
+	if err := s.GetTx(nil, &GetTxArgs{TxID: genesisTx.ID(), Encoding: "json"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	decoded := reply.Decoded
+	if decoded == nil {
+		t.Fatal("Should have returned the decoded tx")
+	}
+	createAssetTx := genesisTx.UnsignedTx.(*CreateAssetTx)
+	assert.Equal(t, "avm.CreateAssetTx", decoded.Type)
+	assert.Equal(t, createAssetTx.Name, decoded.Name)
+	assert.Equal(t, len(createAssetTx.States), len(decoded.InitialStates))
+	assert.Equal(t, "secp256k1fx.TransferOutput", decoded.InitialStates[0].Outputs[0].Type)
+
+	to := vm.Format(keys[1].PublicKey().Address().Bytes())
+	sendReply := &SendReply{}
+	if err := s.Send(nil, &SendArgs{
+		Username: testUsername,
+		Password: testPassword,
+		Amount:   100,
+		AssetID:  genesisTx.ID().String(),
+		To:       to,
+	}, sendReply); err != nil {
+		t.Fatal(err)
+	}
+
+	reply = GetTxReply{}
+	if err := s.GetTx(nil, &GetTxArgs{TxID: sendReply.TxID, Encoding: "json"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	decoded = reply.Decoded
+	assert.Equal(t, "avm.BaseTx", decoded.Type)
+	assert.Equal(t, len(decoded.Inputs), len(decoded.Credentials))
+	assert.Equal(t, "secp256k1fx.Credential", decoded.Credentials[0].Type)
+
+	found := false
+	for _, out := range decoded.Outputs {
+		if len(out.Output.Addresses) == 1 && out.Output.Addresses[0] == to {
+			found = true
+			assert.Equal(t, json.Uint64(100), *out.Output.Amount)
+		}
+	}
+	if !found {
+		t.Fatalf("Should have found an output to %s", to)
+	}
+}
+
 func TestServiceGetNilTx(t *testing.T) {
 	_, vm, s := setup(t)
 	defer func() {
@@ -164,13 +314,13 @@ func TestServiceGetUTXOsInvalidAddress(t *testing.T) {
 		label string
 		args  *GetUTXOsArgs
 	}{
-		{"[", &GetUTXOsArgs{[]string{""}}},
-		{"[-]", &GetUTXOsArgs{[]string{"-"}}},
-		{"[foo]", &GetUTXOsArgs{[]string{"foo"}}},
-		{"[foo-bar]", &GetUTXOsArgs{[]string{"foo-bar"}}},
-		{"[<ChainID>]", &GetUTXOsArgs{[]string{ctx.ChainID.String()}}},
-		{"[<ChainID>-]", &GetUTXOsArgs{[]string{fmt.Sprintf("%s-", ctx.ChainID.String())}}},
-		{"[<Unknown ID>-<addr0>]", &GetUTXOsArgs{[]string{fmt.Sprintf("%s-%s", ids.NewID([32]byte{42}).String(), addr0.String())}}},
+		{"[", &GetUTXOsArgs{Addresses: []string{""}}},
+		{"[-]", &GetUTXOsArgs{Addresses: []string{"-"}}},
+		{"[foo]", &GetUTXOsArgs{Addresses: []string{"foo"}}},
+		{"[foo-bar]", &GetUTXOsArgs{Addresses: []string{"foo-bar"}}},
+		{"[<ChainID>]", &GetUTXOsArgs{Addresses: []string{ctx.ChainID.String()}}},
+		{"[<ChainID>-]", &GetUTXOsArgs{Addresses: []string{fmt.Sprintf("%s-", ctx.ChainID.String())}}},
+		{"[<Unknown ID>-<addr0>]", &GetUTXOsArgs{Addresses: []string{fmt.Sprintf("%s-%s", ids.NewID([32]byte{42}).String(), addr0.String())}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.label, func(t *testing.T) {
@@ -201,7 +351,7 @@ func TestServiceGetUTXOs(t *testing.T) {
 			0,
 		}, {
 			"[<ChainID>-<unrelated address>]",
-			&GetUTXOsArgs{[]string{
+			&GetUTXOsArgs{Addresses: []string{
 				// TODO: Should GetUTXOs() raise an error for this? The address portion is
 				//		 longer than addr0.String()
 				fmt.Sprintf("%s-%s", ctx.ChainID.String(), ids.NewID([32]byte{42}).String()),
@@ -209,13 +359,13 @@ func TestServiceGetUTXOs(t *testing.T) {
 			0,
 		}, {
 			"[<ChainID>-<addr0>]",
-			&GetUTXOsArgs{[]string{
+			&GetUTXOsArgs{Addresses: []string{
 				fmt.Sprintf("%s-%s", ctx.ChainID.String(), addr0.String()),
 			}},
 			7,
 		}, {
 			"[<ChainID>-<addr0>,<ChainID>-<addr0>]",
-			&GetUTXOsArgs{[]string{
+			&GetUTXOsArgs{Addresses: []string{
 				fmt.Sprintf("%s-%s", ctx.ChainID.String(), addr0.String()),
 				fmt.Sprintf("%s-%s", ctx.ChainID.String(), addr0.String()),
 			}},
@@ -286,6 +436,48 @@ func TestGetBalance(t *testing.T) {
 	}
 }
 
+func TestGetBalanceBech32(t *testing.T) {
+	genesisBytes, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+	vm.hrp = "local"
+
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	avaAssetID := genesisTx.ID()
+
+	addr := keys[0].PublicKey().Address().Bytes()
+	cb58Addr := vm.Format(addr)
+	vm.bech32Addresses = true
+	bech32Addr := vm.Format(addr)
+	chainPrefix := bech32Addr[:strings.Index(bech32Addr, addressSep)+1]
+	if !strings.HasPrefix(bech32Addr, chainPrefix+"local1") {
+		t.Fatalf("Expected a bech32 address, got %s", bech32Addr)
+	}
+
+	for _, addrStr := range []string{bech32Addr, cb58Addr} {
+		reply := GetBalanceReply{}
+		if err := s.GetBalance(nil, &GetBalanceArgs{
+			Address: addrStr,
+			AssetID: avaAssetID.String(),
+		}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		if reply.Balance != 300000 {
+			t.Fatalf("Wrong balance returned from GetBalance %d", reply.Balance)
+		}
+	}
+
+	otherNetworkAddr, err := formatting.FormatBech32("ava", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.Parse(chainPrefix + otherNetworkAddr); err != errWrongHRP {
+		t.Fatalf("Expected %s, got %v", errWrongHRP, err)
+	}
+}
+
 func TestCreateFixedCapAsset(t *testing.T) {
 	_, vm, s := setup(t)
 	defer func() {
@@ -340,3 +532,632 @@ func TestCreateVariableCapAsset(t *testing.T) {
 		t.Fatalf("Wrong assetID returned from CreateFixedCapAsset %s", reply.AssetID)
 	}
 }
+
+func TestServiceGetUTXOsPagination(t *testing.T) {
+	_, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	addr0 := keys[0].PublicKey().Address()
+	addrStr := fmt.Sprintf("%s-%s", ctx.ChainID.String(), addr0.String())
+
+	fetched := map[string]bool{}
+	args := &GetUTXOsArgs{
+		Addresses: []string{addrStr},
+		Limit:     3,
+	}
+	for _, expected := range []int{3, 3, 1, 0} {
+		reply := &GetUTXOsReply{}
+		if err := s.GetUTXOs(nil, args, reply); err != nil {
+			t.Fatal(err)
+		}
+		if len(reply.UTXOs) != expected {
+			t.Fatalf("Expected %d utxos, got %d", expected, len(reply.UTXOs))
+		}
+		if int(reply.NumFetched) != expected {
+			t.Fatalf("Expected numFetched to be %d, got %d", expected, reply.NumFetched)
+		}
+		for _, utxo := range reply.UTXOs {
+			if fetched[utxo.String()] {
+				t.Fatalf("UTXO %s was returned twice", utxo)
+			}
+			fetched[utxo.String()] = true
+		}
+		if reply.EndIndex.Address != addrStr {
+			t.Fatalf("Expected end index address %s, got %s", addrStr, reply.EndIndex.Address)
+		}
+		args.StartIndex = reply.EndIndex
+	}
+	if len(fetched) != 7 {
+		t.Fatalf("Expected 7 distinct utxos, got %d", len(fetched))
+	}
+}
+
+func TestServiceGetAddressTxs(t *testing.T) {
+	genesisBytes, vm, s := setup(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	addrStr := vm.Format(keys[0].PublicKey().Address().Bytes())
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+
+	reply := &GetAddressTxsReply{}
+	if err := s.GetAddressTxs(nil, &GetAddressTxsArgs{Address: addrStr}, reply); err == nil {
+		t.Fatal("Should have errored because transaction indexing is disabled")
+	}
+
+	vm.indexTxs = true
+
+	newTx := NewTx(t, genesisBytes, vm)
+	if _, err := vm.IssueTx(newTx.Bytes(), nil); err != nil {
+		t.Fatal(err)
+	}
+	txs := vm.PendingTxs()
+	if len(txs) != 1 {
+		t.Fatalf("Should have returned %d tx(s)", 1)
+	}
+	txs[0].Accept()
+
+	tests := []struct {
+		label   string
+		args    *GetAddressTxsArgs
+		txCount int
+		cursor  uint64
+	}{
+		{"all assets", &GetAddressTxsArgs{Address: addrStr}, 1, 1},
+		{"spent asset", &GetAddressTxsArgs{Address: addrStr, AssetID: genesisTx.ID().String()}, 1, 1},
+		{"other asset", &GetAddressTxsArgs{Address: addrStr, AssetID: asset.String()}, 0, 0},
+		{"after cursor", &GetAddressTxsArgs{Address: addrStr, Cursor: 1}, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			reply := &GetAddressTxsReply{}
+			if err := s.GetAddressTxs(nil, tt.args, reply); err != nil {
+				t.Fatal(err)
+			}
+			if len(reply.TxIDs) != tt.txCount {
+				t.Fatalf("Expected %d txs, got %d", tt.txCount, len(reply.TxIDs))
+			}
+			if tt.txCount > 0 && !reply.TxIDs[0].Equals(newTx.ID()) {
+				t.Fatalf("Expected tx %s, got %s", newTx.ID(), reply.TxIDs[0])
+			}
+			if uint64(reply.Cursor) != tt.cursor {
+				t.Fatalf("Expected cursor %d, got %d", tt.cursor, reply.Cursor)
+			}
+		})
+	}
+}
+
+func TestServiceNFT(t *testing.T) {
+	_, vm, s := setupWithKeystore(t, &secp256k1fx.Fx{}, &nftfx.Fx{})
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	minter := vm.Format(keys[0].PublicKey().Address().Bytes())
+	receiver := vm.Format(keys[1].PublicKey().Address().Bytes())
+
+	createReply := &CreateNFTAssetReply{}
+	if err := s.CreateNFTAsset(nil, &CreateNFTAssetArgs{
+		Username: testUsername,
+		Password: testPassword,
+		Name:     "Team Rocket",
+		Symbol:   "TR",
+		MinterSets: []Owners{
+			Owners{Threshold: 1, Minters: []string{minter}},
+			Owners{Threshold: 1, Minters: []string{receiver}},
+		},
+	}, createReply); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+	assetID := createReply.AssetID.String()
+
+	if err := s.MintNFT(nil, &MintNFTArgs{
+		Username: testUsername,
+		Password: testPassword,
+		AssetID:  assetID,
+		GroupID:  1,
+		To:       minter,
+	}, &MintNFTReply{}); err == nil {
+		t.Fatal("Should have errored because the user can't mint group 1")
+	}
+
+	payload := []byte{'N', 'F', 'T'}
+	if err := s.MintNFT(nil, &MintNFTArgs{
+		Username: testUsername,
+		Password: testPassword,
+		AssetID:  assetID,
+		GroupID:  0,
+		Payload:  formatting.CB58{Bytes: payload},
+		To:       minter,
+	}, &MintNFTReply{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+
+	balanceReply := &GetBalanceReply{}
+	if err := s.GetBalance(nil, &GetBalanceArgs{Address: minter, AssetID: assetID}, balanceReply); err != nil {
+		t.Fatal(err)
+	}
+	if balanceReply.Balance != 1 {
+		t.Fatalf("Expected balance of %d, got %d", 1, balanceReply.Balance)
+	}
+
+	sendReply := &SendNFTReply{}
+	if err := s.SendNFT(nil, &SendNFTArgs{
+		Username: testUsername,
+		Password: testPassword,
+		AssetID:  assetID,
+		GroupID:  0,
+		To:       receiver,
+	}, sendReply); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+
+	if err := s.SendNFT(nil, &SendNFTArgs{
+		Username: testUsername,
+		Password: testPassword,
+		AssetID:  assetID,
+		GroupID:  0,
+		To:       receiver,
+	}, &SendNFTReply{}); err == nil {
+		t.Fatal("Should have errored because the NFT was already sent")
+	}
+
+	allBalancesReply := &GetAllBalancesReply{}
+	if err := s.GetAllBalances(nil, &GetAllBalancesArgs{Address: receiver}, allBalancesReply); err != nil {
+		t.Fatal(err)
+	}
+	if len(allBalancesReply.Balances) != 1 {
+		t.Fatalf("Expected %d balance(s), got %d", 1, len(allBalancesReply.Balances))
+	}
+	if balance := allBalancesReply.Balances[0]; balance.AssetID != assetID || balance.Balance != 1 {
+		t.Fatalf("Expected balance of %d of %s, got %d of %s", 1, assetID, balance.Balance, balance.AssetID)
+	}
+
+	addrs := ids.Set{}
+	addrs.Add(ids.NewID(hashing.ComputeHash256Array(keys[1].PublicKey().Address().Bytes())))
+	utxos, err := vm.GetUTXOs(addrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, utxo := range utxos {
+		if out, ok := utxo.Out.(*nftfx.TransferOutput); ok {
+			found = true
+			assert.Equal(t, payload, out.Payload)
+		}
+	}
+	if !found {
+		t.Fatal("Should have found the sent NFT")
+	}
+}
+
+func TestServiceProperty(t *testing.T) {
+	_, vm, s := setupWithKeystore(t, &secp256k1fx.Fx{}, &nftfx.Fx{}, &propertyfx.Fx{})
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	minter := vm.Format(keys[0].PublicKey().Address().Bytes())
+	owner := vm.Format(keys[1].PublicKey().Address().Bytes())
+
+	createReply := &CreatePropertyAssetReply{}
+	if err := s.CreatePropertyAsset(nil, &CreatePropertyAssetArgs{
+		Username: testUsername,
+		Password: testPassword,
+		Name:     "Pallet Town",
+		Symbol:   "PT",
+		MinterSets: []Owners{
+			Owners{Threshold: 1, Minters: []string{minter}},
+		},
+	}, createReply); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+	assetID := createReply.AssetID.String()
+
+	if err := s.BurnProperty(nil, &BurnPropertyArgs{
+		Username: testUsername,
+		Password: testPassword,
+		AssetID:  assetID,
+	}, &BurnPropertyReply{}); err == nil {
+		t.Fatal("Should have errored because the user doesn't own the property")
+	}
+
+	for _, to := range []string{owner, minter} {
+		if err := s.MintProperty(nil, &MintPropertyArgs{
+			Username: testUsername,
+			Password: testPassword,
+			AssetID:  assetID,
+			To:       to,
+		}, &MintPropertyReply{}); err != nil {
+			t.Fatal(err)
+		}
+		acceptPendingTx(t, vm)
+	}
+
+	for _, addr := range []string{owner, minter} {
+		reply := &GetOwnedPropertiesReply{}
+		if err := s.GetOwnedProperties(nil, &GetOwnedPropertiesArgs{Address: addr}, reply); err != nil {
+			t.Fatal(err)
+		}
+		if len(reply.Properties) != 1 {
+			t.Fatalf("Expected %d property(s), got %d", 1, len(reply.Properties))
+		}
+		property := reply.Properties[0]
+		assert.Equal(t, assetID, property.AssetID)
+		assert.Equal(t, []string{addr}, property.Owners)
+	}
+
+	if err := s.BurnProperty(nil, &BurnPropertyArgs{
+		Username: testUsername,
+		Password: testPassword,
+		AssetID:  assetID,
+	}, &BurnPropertyReply{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+
+	reply := &GetOwnedPropertiesReply{}
+	if err := s.GetOwnedProperties(nil, &GetOwnedPropertiesArgs{Address: minter}, reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Properties) != 0 {
+		t.Fatalf("Expected %d property(s), got %d", 0, len(reply.Properties))
+	}
+}
+
+func TestServiceTransferProperty(t *testing.T) {
+	_, vm, s := setupWithKeystore(t, &secp256k1fx.Fx{}, &nftfx.Fx{}, &propertyfx.Fx{})
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	minter := vm.Format(keys[0].PublicKey().Address().Bytes())
+	owner := vm.Format(keys[1].PublicKey().Address().Bytes())
+	receiver := vm.Format(ids.NewShortID([20]byte{1}).Bytes())
+	// The user can both mint the property and transfer it from [owner]
+	setUserKeys(t, vm, keys[0], keys[1])
+
+	createReply := &CreatePropertyAssetReply{}
+	if err := s.CreatePropertyAsset(nil, &CreatePropertyAssetArgs{
+		Username: testUsername,
+		Password: testPassword,
+		Name:     "Pallet Town",
+		Symbol:   "PT",
+		MinterSets: []Owners{
+			Owners{Threshold: 1, Minters: []string{minter}},
+		},
+	}, createReply); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+	assetID := createReply.AssetID.String()
+
+	if err := s.TransferProperty(nil, &TransferPropertyArgs{
+		Username: testUsername,
+		Password: testPassword,
+		AssetID:  assetID,
+		To:       receiver,
+	}, &TransferPropertyReply{}); err != errNoOwnedProperty {
+		t.Fatalf("Should have errored with %s, got %v", errNoOwnedProperty, err)
+	}
+
+	if err := s.MintProperty(nil, &MintPropertyArgs{
+		Username: testUsername,
+		Password: testPassword,
+		AssetID:  assetID,
+		To:       owner,
+	}, &MintPropertyReply{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+
+	if err := s.TransferProperty(nil, &TransferPropertyArgs{
+		Username: testUsername,
+		Password: testPassword,
+		AssetID:  assetID,
+		To:       receiver,
+	}, &TransferPropertyReply{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+
+	// The previous owner no longer owns the property
+	expected := map[string]int{owner: 0, receiver: 1}
+	for addr, numProperties := range expected {
+		reply := &GetOwnedPropertiesReply{}
+		if err := s.GetOwnedProperties(nil, &GetOwnedPropertiesArgs{Address: addr}, reply); err != nil {
+			t.Fatal(err)
+		}
+		if len(reply.Properties) != numProperties {
+			t.Fatalf("Expected %s to own %d property(s), got %d", addr, numProperties, len(reply.Properties))
+		}
+	}
+}
+
+func TestServiceMultisigSend(t *testing.T) {
+	_, vm, s := setupWithKeystore(t, &secp256k1fx.Fx{})
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	owners := secp256k1fx.OutputOwners{Threshold: 2}
+	for _, key := range keys[:3] {
+		owners.Addrs = append(owners.Addrs, key.PublicKey().Address())
+	}
+	ids.SortShortIDs(owners.Addrs)
+
+	createAssetTx := &Tx{UnsignedTx: &CreateAssetTx{
+		BaseTx: BaseTx{
+			NetID: networkID,
+			BCID:  chainID,
+		},
+		Name:   "Custody",
+		Symbol: "CSTD",
+		States: []*InitialState{&InitialState{
+			FxID: 0,
+			Outs: []verify.Verifiable{
+				&secp256k1fx.TransferOutput{
+					Amt:          1000,
+					OutputOwners: owners,
+				},
+			},
+		}},
+	}}
+	b, err := vm.codec.Marshal(createAssetTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assetID, err := vm.IssueTx(b, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+
+	from := []string{}
+	for _, key := range keys[:2] {
+		from = append(from, vm.Format(key.PublicKey().Address().Bytes()))
+	}
+	to := vm.Format(ids.NewShortID([20]byte{1}).Bytes())
+
+	buildReply := &BuildUnsignedSendReply{}
+	if err := s.BuildUnsignedSend(nil, &BuildUnsignedSendArgs{
+		From:    from,
+		Amount:  400,
+		AssetID: assetID.String(),
+		To:      to,
+	}, buildReply); err != nil {
+		t.Fatal(err)
+	}
+
+	signedTxs := []formatting.CB58{}
+	for _, key := range keys[:2] {
+		setUserKeys(t, vm, key)
+
+		signReply := &SignTxReply{}
+		if err := s.SignTx(nil, &SignTxArgs{
+			Username: testUsername,
+			Password: testPassword,
+			Tx:       buildReply.Tx,
+		}, signReply); err != nil {
+			t.Fatal(err)
+		}
+		if signReply.Complete {
+			t.Fatal("A single signer shouldn't be able to complete the transaction")
+		}
+		if _, err := s.vm.IssueTx(signReply.Tx.Bytes, nil); err == nil {
+			t.Fatal("Should have errored because the transaction is missing a signature")
+		}
+		signedTxs = append(signedTxs, signReply.Tx)
+	}
+
+	if err := s.CombineSignatures(nil, &CombineSignaturesArgs{
+		Txs: []formatting.CB58{signedTxs[0], {Bytes: createAssetTx.Bytes()}},
+	}, &CombineSignaturesReply{}); err == nil {
+		t.Fatal("Should have errored because the transactions don't match")
+	}
+
+	// The copies' signatures are merged even if the first copy doesn't have
+	// any credentials
+	uncredentialedTx := &Tx{}
+	if err := vm.codec.Unmarshal(buildReply.Tx.Bytes, uncredentialedTx); err != nil {
+		t.Fatal(err)
+	}
+	uncredentialedTx.Creds = nil
+	uncredentialedBytes, err := vm.codec.Marshal(uncredentialedTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsignedFirstReply := &CombineSignaturesReply{}
+	if err := s.CombineSignatures(nil, &CombineSignaturesArgs{
+		Txs: []formatting.CB58{{Bytes: uncredentialedBytes}, signedTxs[0], signedTxs[1]},
+	}, unsignedFirstReply); err != nil {
+		t.Fatal(err)
+	}
+	if !unsignedFirstReply.Complete {
+		t.Fatal("Combined transaction should have been fully signed")
+	}
+
+	combineReply := &CombineSignaturesReply{}
+	if err := s.CombineSignatures(nil, &CombineSignaturesArgs{Txs: signedTxs}, combineReply); err != nil {
+		t.Fatal(err)
+	}
+	if !combineReply.Complete {
+		t.Fatal("Combined transaction should have been fully signed")
+	}
+	if !bytes.Equal(unsignedFirstReply.Tx.Bytes, combineReply.Tx.Bytes) {
+		t.Fatal("The order of the copies shouldn't change the combined transaction")
+	}
+
+	issueReply := &IssueTxReply{}
+	if err := s.IssueTx(nil, &IssueTxArgs{Tx: combineReply.Tx}, issueReply); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+
+	balanceReply := &GetBalanceReply{}
+	if err := s.GetBalance(nil, &GetBalanceArgs{Address: to, AssetID: assetID.String()}, balanceReply); err != nil {
+		t.Fatal(err)
+	}
+	if balanceReply.Balance != 400 {
+		t.Fatalf("Expected balance of %d, got %d", 400, balanceReply.Balance)
+	}
+
+	balanceReply = &GetBalanceReply{}
+	if err := s.GetBalance(nil, &GetBalanceArgs{Address: from[0], AssetID: assetID.String()}, balanceReply); err != nil {
+		t.Fatal(err)
+	}
+	if balanceReply.Balance != 600 {
+		t.Fatalf("Expected change of %d, got %d", 600, balanceReply.Balance)
+	}
+}
+
+func TestServiceLockedSend(t *testing.T) {
+	_, vm, s := setupWithKeystore(t, &secp256k1fx.Fx{})
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	now := time.Unix(1000, 0)
+	vm.clock.Set(now)
+
+	addr := vm.Format(keys[0].PublicKey().Address().Bytes())
+	otherAddr := vm.Format(keys[1].PublicKey().Address().Bytes())
+
+	createReply := &CreateFixedCapAssetReply{}
+	if err := s.CreateFixedCapAsset(nil, &CreateFixedCapAssetArgs{
+		Username: testUsername,
+		Password: testPassword,
+		Name:     "Vesting",
+		Symbol:   "VEST",
+		InitialHolders: []*Holder{
+			&Holder{
+				Amount: 100,
+				Owners: &OutputOwners{
+					Locktime:  json.Uint64(now.Add(time.Hour).Unix()),
+					Threshold: 1,
+					Addresses: []string{addr},
+				},
+			},
+			&Holder{
+				Amount:  50,
+				Address: addr,
+			},
+		},
+	}, createReply); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+	assetID := createReply.AssetID.String()
+
+	balanceReply := &GetBalanceReply{}
+	if err := s.GetBalance(nil, &GetBalanceArgs{Address: addr, AssetID: assetID}, balanceReply); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, json.Uint64(150), balanceReply.Balance)
+	assert.Equal(t, json.Uint64(100), balanceReply.Locked)
+	assert.Equal(t, json.Uint64(50), balanceReply.Unlocked)
+
+	sendArgs := &SendArgs{
+		Username: testUsername,
+		Password: testPassword,
+		Amount:   120,
+		AssetID:  assetID,
+		Owners: &OutputOwners{
+			Threshold: 2,
+			Addresses: []string{addr, otherAddr},
+		},
+	}
+	if err := s.Send(nil, sendArgs, &SendReply{}); err == nil {
+		t.Fatal("Should have errored because the funds are locked")
+	}
+
+	sendArgs.To = otherAddr
+	vm.clock.Set(now.Add(time.Hour))
+	if err := s.Send(nil, sendArgs, &SendReply{}); err == nil {
+		t.Fatal("Should have errored because both an address and owners were provided")
+	}
+
+	sendArgs.To = ""
+	if err := s.Send(nil, sendArgs, &SendReply{}); err != nil {
+		t.Fatal(err)
+	}
+	acceptPendingTx(t, vm)
+
+	balanceReply = &GetBalanceReply{}
+	if err := s.GetBalance(nil, &GetBalanceArgs{Address: otherAddr, AssetID: assetID}, balanceReply); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, json.Uint64(120), balanceReply.Balance)
+	assert.Equal(t, json.Uint64(0), balanceReply.Locked)
+}
+
+func TestServicePendingTxs(t *testing.T) {
+	genesisBytes, vm, s := setupWithKeystore(t, &secp256k1fx.Fx{})
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	assetID := GetFirstTxFromGenesisTest(genesisBytes, t).ID().String()
+	sender := vm.Format(keys[0].PublicKey().Address().Bytes())
+	receiver := vm.Format(keys[1].PublicKey().Address().Bytes())
+
+	sendReply := &SendReply{}
+	if err := s.Send(nil, &SendArgs{
+		Username: testUsername,
+		Password: testPassword,
+		Amount:   100,
+		AssetID:  assetID,
+		To:       receiver,
+	}, sendReply); err != nil {
+		t.Fatal(err)
+	}
+
+	pendingReply := &GetPendingTxsReply{}
+	if err := s.GetPendingTxs(nil, nil, pendingReply); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []ids.ID{sendReply.TxID}, pendingReply.TxIDs)
+
+	receiverReply := &GetPendingBalanceReply{}
+	if err := s.GetPendingBalance(nil, &GetPendingBalanceArgs{Address: receiver, AssetID: assetID}, receiverReply); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, json.Uint64(100), receiverReply.Incoming)
+	assert.Equal(t, json.Uint64(0), receiverReply.Outgoing)
+
+	senderReply := &GetPendingBalanceReply{}
+	if err := s.GetPendingBalance(nil, &GetPendingBalanceArgs{Address: sender, AssetID: assetID}, senderReply); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, json.Uint64(100), senderReply.Outgoing-senderReply.Incoming)
+
+	acceptPendingTx(t, vm)
+
+	pendingReply = &GetPendingTxsReply{}
+	if err := s.GetPendingTxs(nil, nil, pendingReply); err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, pendingReply.TxIDs)
+
+	receiverReply = &GetPendingBalanceReply{}
+	if err := s.GetPendingBalance(nil, &GetPendingBalanceArgs{Address: receiver, AssetID: assetID}, receiverReply); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, json.Uint64(100), receiverReply.Confirmed)
+	assert.Equal(t, json.Uint64(0), receiverReply.Incoming)
+}
@@ -4,10 +4,14 @@
 package avm
 
 import (
+	"encoding/binary"
 	"errors"
 
 	"github.com/ava-labs/gecko/cache"
+	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/wrappers"
 	"github.com/ava-labs/gecko/vms/components/ava"
 )
 
@@ -63,3 +67,134 @@ func (s *state) SetTx(id ids.ID, tx *Tx) error {
 	s.Cache.Put(id, tx)
 	return s.DB.Put(id.Bytes(), tx.Bytes())
 }
+
+// IndexedIDs returns up to [limit] IDs stored in the index [id], in ascending
+// order. If [start] is non-zero, only IDs strictly greater than [start] are
+// returned.
+func (s *state) IndexedIDs(id ids.ID, start ids.ID, limit int) ([]ids.ID, error) {
+	prefix := id.Bytes()
+	startKey := prefix
+	if !start.IsZero() {
+		startKey = indexKey(id, start)
+	}
+
+	iter := s.DB.NewIteratorWithStartAndPrefix(startKey, prefix)
+	defer iter.Release()
+
+	idSlice := []ids.ID(nil)
+	for len(idSlice) < limit && iter.Next() {
+		key := iter.Key()
+		if len(key) != len(prefix)+hashing.HashLen {
+			continue
+		}
+		indexedID, err := ids.ToID(key[len(prefix):])
+		if err != nil {
+			return nil, err
+		}
+		if indexedID.Equals(start) {
+			continue
+		}
+		idSlice = append(idSlice, indexedID)
+	}
+	return idSlice, iter.Error()
+}
+
+// AddIndexedID adds [indexedID] to the index [id].
+func (s *state) AddIndexedID(id ids.ID, indexedID ids.ID) error {
+	return s.DB.Put(indexKey(id, indexedID), nil)
+}
+
+// RemoveIndexedID removes [indexedID] from the index [id].
+func (s *state) RemoveIndexedID(id ids.ID, indexedID ids.ID) error {
+	return s.DB.Delete(indexKey(id, indexedID))
+}
+
+func indexKey(id ids.ID, indexedID ids.ID) []byte {
+	key := make([]byte, 2*hashing.HashLen)
+	copy(key, id.Bytes())
+	copy(key[hashing.HashLen:], indexedID.Bytes())
+	return key
+}
+
+// Count returns a counter from storage. If the counter was never set, 0 is
+// returned.
+func (s *state) Count(id ids.ID) (uint64, error) {
+	if countIntf, found := s.Cache.Get(id); found {
+		if count, ok := countIntf.(uint64); ok {
+			return count, nil
+		}
+		return 0, errCacheTypeMismatch
+	}
+
+	bytes, err := s.DB.Get(id.Bytes())
+	if err == database.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	if err := s.Codec.Unmarshal(bytes, &count); err != nil {
+		return 0, err
+	}
+
+	s.Cache.Put(id, count)
+	return count, nil
+}
+
+// SetCount saves a counter to storage.
+func (s *state) SetCount(id ids.ID, count uint64) error {
+	if count == 0 {
+		s.Cache.Evict(id)
+		return s.DB.Delete(id.Bytes())
+	}
+
+	bytes, err := s.Codec.Marshal(count)
+	if err != nil {
+		return err
+	}
+
+	s.Cache.Put(id, count)
+	return s.DB.Put(id.Bytes(), bytes)
+}
+
+// ListedIDs returns up to [limit] IDs from the list [id], in the order they
+// were appended, starting at position [start].
+func (s *state) ListedIDs(id ids.ID, start uint64, limit int) ([]ids.ID, error) {
+	prefix := id.Bytes()
+	iter := s.DB.NewIteratorWithStartAndPrefix(listKey(id, start), prefix)
+	defer iter.Release()
+
+	idSlice := []ids.ID(nil)
+	for len(idSlice) < limit && iter.Next() {
+		if len(iter.Key()) != len(prefix)+wrappers.LongLen {
+			continue
+		}
+		listedID, err := ids.ToID(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		idSlice = append(idSlice, listedID)
+	}
+	return idSlice, iter.Error()
+}
+
+// AppendListedID adds [listedID] to the end of the list [id]. The length of
+// the list is tracked by the counter [countID].
+func (s *state) AppendListedID(id ids.ID, countID ids.ID, listedID ids.ID) error {
+	count, err := s.Count(countID)
+	if err != nil {
+		return err
+	}
+	if err := s.DB.Put(listKey(id, count), listedID.Bytes()); err != nil {
+		return err
+	}
+	return s.SetCount(countID, count+1)
+}
+
+func listKey(id ids.ID, index uint64) []byte {
+	key := make([]byte, hashing.HashLen+wrappers.LongLen)
+	copy(key, id.Bytes())
+	binary.BigEndian.PutUint64(key[hashing.HashLen:], index)
+	return key
+}
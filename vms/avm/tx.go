@@ -9,9 +9,14 @@ import (
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/hashing"
 	"github.com/ava-labs/gecko/vms/components/ava"
 	"github.com/ava-labs/gecko/vms/components/codec"
 	"github.com/ava-labs/gecko/vms/components/verify"
+	"github.com/ava-labs/gecko/vms/nftfx"
+	"github.com/ava-labs/gecko/vms/propertyfx"
+	"github.com/ava-labs/gecko/vms/secp256k1fx"
 )
 
 var (
@@ -80,3 +85,53 @@ func (t *Tx) SemanticVerify(vm *VM, uTx *UniqueTx) error {
 
 	return t.UnsignedTx.SemanticVerify(vm, uTx, t.Creds)
 }
+
+// SignSECP256K1Fx appends a secp256k1fx credential to this transaction for each
+// of the sets of [signers]
+func (t *Tx) SignSECP256K1Fx(c codec.Codec, signers [][]*crypto.PrivateKeySECP256K1R) error {
+	return t.sign(c, signers, func(cred secp256k1fx.Credential) verify.Verifiable { return &cred })
+}
+
+// SignNFTFx appends an nftfx credential to this transaction for each of the
+// sets of [signers]
+func (t *Tx) SignNFTFx(c codec.Codec, signers [][]*crypto.PrivateKeySECP256K1R) error {
+	return t.sign(c, signers, func(cred secp256k1fx.Credential) verify.Verifiable {
+		return &nftfx.Credential{Credential: cred}
+	})
+}
+
+// SignPropertyFx appends a propertyfx credential to this transaction for each
+// of the sets of [signers]
+func (t *Tx) SignPropertyFx(c codec.Codec, signers [][]*crypto.PrivateKeySECP256K1R) error {
+	return t.sign(c, signers, func(cred secp256k1fx.Credential) verify.Verifiable {
+		return &propertyfx.Credential{Credential: cred}
+	})
+}
+
+func (t *Tx) sign(
+	c codec.Codec,
+	signers [][]*crypto.PrivateKeySECP256K1R,
+	wrap func(secp256k1fx.Credential) verify.Verifiable,
+) error {
+	unsignedBytes, err := c.Marshal(&t.UnsignedTx)
+	if err != nil {
+		return err
+	}
+	hash := hashing.ComputeHash256(unsignedBytes)
+
+	for _, keys := range signers {
+		cred := secp256k1fx.Credential{}
+		for _, key := range keys {
+			sig, err := key.SignHash(hash)
+			if err != nil {
+				return err
+			}
+			fixedSig := [crypto.SECP256K1RSigLen]byte{}
+			copy(fixedSig[:], sig)
+
+			cred.Sigs = append(cred.Sigs, fixedSig)
+		}
+		t.Creds = append(t.Creds, wrap(cred))
+	}
+	return nil
+}
@@ -107,6 +107,15 @@ func (tx *UniqueTx) Accept() {
 		return
 	}
 
+	// The address index is optional, so failing to index the tx mustn't stop
+	// the tx from being accepted. The index is written in the same batch as
+	// the accept.
+	if tx.vm.indexTxs {
+		if err := tx.vm.indexTx(tx); err != nil {
+			tx.vm.ctx.Log.Warn("Failed to index tx %s due to %s", tx.txID, err)
+		}
+	}
+
 	// Remove spent utxos
 	for _, utxo := range tx.InputUTXOs() {
 		if utxo.Symbolic() {
@@ -142,6 +151,8 @@ func (tx *UniqueTx) Accept() {
 
 	tx.vm.ctx.Log.Verbo("Accepted Tx: %s", txID)
 
+	tx.vm.mempool.Remove(tx)
+
 	tx.vm.pubsub.Publish("accepted", txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
@@ -167,6 +178,8 @@ func (tx *UniqueTx) Reject() {
 		tx.vm.ctx.Log.Error("Failed to commit reject %s due to %s", tx.txID, err)
 	}
 
+	tx.vm.mempool.Remove(tx)
+
 	tx.vm.pubsub.Publish("rejected", txID)
 
 	tx.deps = nil // Needed to prevent a memory leak
@@ -4,6 +4,7 @@
 package avm
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"reflect"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/ava-labs/gecko/cache"
 	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/database/versiondb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
@@ -21,6 +23,7 @@ import (
 	"github.com/ava-labs/gecko/snow/consensus/snowstorm"
 	"github.com/ava-labs/gecko/snow/engine/common"
 	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/utils/hashing"
 	"github.com/ava-labs/gecko/utils/logging"
 	"github.com/ava-labs/gecko/utils/timer"
 	"github.com/ava-labs/gecko/utils/wrappers"
@@ -37,6 +40,16 @@ const (
 	idCacheSize    = 10000
 	txCacheSize    = 10000
 	addressSep     = "-"
+
+	// Max number of UTXOs that can be fetched in a single call to
+	// GetPaginatedUTXOs
+	maxUTXOsToFetch = 1024
+
+	// Max number of undecided transactions that are tracked
+	mempoolSize = 4096
+
+	// How long an undecided transaction is tracked for
+	mempoolTxExpiry = 10 * time.Minute
 )
 
 var (
@@ -45,8 +58,13 @@ var (
 	errGenesisAssetMustHaveState = errors.New("genesis asset must have non-empty state")
 	errInvalidAddress            = errors.New("invalid address")
 	errWrongBlockchainID         = errors.New("wrong blockchain ID")
+	errDecidedTx                 = errors.New("transaction has already been decided")
+	errWrongHRP                  = errors.New("address belongs to a different network")
 )
 
+// schema is the versioned layout of the VM's database
+var schema = migration.NewSchema("avm")
+
 // VM implements the avalanche.DAGVM interface
 type VM struct {
 	ids.Aliaser
@@ -54,6 +72,16 @@ type VM struct {
 	ava      ids.ID
 	platform ids.ID
 
+	// If true, accepted transactions are indexed by the addresses and assets
+	// they reference
+	indexTxs bool
+
+	// Human readable part of bech32 addresses on this network
+	hrp string
+
+	// If true, addresses are formatted in bech32 rather than CB58
+	bech32Addresses bool
+
 	// Contains information of where this VM is executing
 	ctx *snow.Context
 
@@ -73,6 +101,12 @@ type VM struct {
 	txs          []snowstorm.Tx
 	toEngine     chan<- common.Message
 
+	// Transactions that have been issued but not yet decided
+	mempool *mempool
+
+	// Used to gossip issued transactions to peers. May be nil.
+	gossiper common.Gossiper
+
 	baseDB database.Database
 	db     *versiondb.Database
 
@@ -112,6 +146,7 @@ func (vm *VM) Initialize(
 ) error {
 	vm.ctx = ctx
 	vm.toEngine = toEngine
+	vm.mempool = newMempool(mempoolSize, mempoolTxExpiry, &vm.clock)
 	vm.baseDB = db
 	vm.db = versiondb.New(db)
 	vm.typeToFxIndex = map[reflect.Type]int{}
@@ -168,10 +203,14 @@ func (vm *VM) Initialize(
 			Codec: vm.codec,
 		}},
 
-		tx:       &cache.LRU{Size: idCacheSize},
-		utxo:     &cache.LRU{Size: idCacheSize},
-		txStatus: &cache.LRU{Size: idCacheSize},
-		funds:    &cache.LRU{Size: idCacheSize},
+		tx:        &cache.LRU{Size: idCacheSize},
+		utxo:      &cache.LRU{Size: idCacheSize},
+		txStatus:  &cache.LRU{Size: idCacheSize},
+		funds:     &cache.LRU{Size: idCacheSize},
+		utxoIndex: &cache.LRU{Size: idCacheSize},
+
+		addressTxs:     &cache.LRU{Size: idCacheSize},
+		addressTxCount: &cache.LRU{Size: idCacheSize},
 
 		uniqueTx: &cache.EvictableLRU{Size: txCacheSize},
 	}
@@ -180,12 +219,20 @@ func (vm *VM) Initialize(
 		return err
 	}
 
-	if dbStatus, err := vm.state.DBInitialized(); err != nil || dbStatus == choices.Unknown {
+	dbStatus, err := vm.state.DBInitialized()
+	if err != nil || dbStatus == choices.Unknown {
 		if err := vm.initState(genesisBytes); err != nil {
 			return err
 		}
 	}
 
+	if err := vm.initTxIndex(dbStatus); err != nil {
+		return err
+	}
+	if err := vm.initUTXOIndex(dbStatus); err != nil {
+		return err
+	}
+
 	vm.timer = timer.NewTimer(func() {
 		ctx.Lock.Lock()
 		defer ctx.Lock.Unlock()
@@ -250,6 +297,21 @@ func (vm *VM) PendingTxs() []snowstorm.Tx {
 	return txs
 }
 
+// SetGossiper implements the avalanche.GossipingDAGVM interface
+func (vm *VM) SetGossiper(gossiper common.Gossiper) { vm.gossiper = gossiper }
+
+// IssueGossipedTx implements the avalanche.GossipingDAGVM interface
+func (vm *VM) IssueGossipedTx(b []byte) error {
+	tx, err := vm.parseTx(b)
+	if err != nil {
+		return err
+	}
+	if tx.Status().Decided() {
+		return errDecidedTx
+	}
+	return vm.addPendingTx(tx)
+}
+
 // ParseTx implements the avalanche.DAGVM interface
 func (vm *VM) ParseTx(b []byte) (snowstorm.Tx, error) { return vm.parseTx(b) }
 
@@ -279,14 +341,26 @@ func (vm *VM) IssueTx(b []byte, onDecide func(choices.Status)) (ids.ID, error) {
 	if err != nil {
 		return ids.ID{}, err
 	}
-	if err := tx.Verify(); err != nil {
+	if tx.Status().Decided() {
+		return tx.ID(), nil
+	}
+	if err := vm.addPendingTx(tx); err != nil {
 		return ids.ID{}, err
 	}
-	vm.issueTx(tx)
 	tx.onDecide = onDecide
 	return tx.ID(), nil
 }
 
+// PendingTxIDs returns the IDs of the transactions that have been issued to
+// this node but not yet decided, in the order they were issued
+func (vm *VM) PendingTxIDs() []ids.ID {
+	txIDs := []ids.ID{}
+	for _, tx := range vm.mempool.Txs() {
+		txIDs = append(txIDs, tx.ID())
+	}
+	return txIDs
+}
+
 // GetAtomicUTXOs returns the utxos that at least one of the provided addresses is
 // referenced in.
 func (vm *VM) GetAtomicUTXOs(addrs ids.Set) ([]*ava.UTXO, error) {
@@ -332,6 +406,91 @@ func (vm *VM) GetUTXOs(addrs ids.Set) ([]*ava.UTXO, error) {
 	return utxos, nil
 }
 
+// GetPaginatedUTXOs returns up to [limit] utxos that at least one of the
+// provided addresses is referenced in. Addresses are visited in ascending
+// order, and each address's utxos are visited in the order of the utxo index.
+// A utxo that references several of the addresses is only returned once.
+// If [startAddr] is non-zero, utxos are only returned starting after
+// [startUTXOID] of [startAddr]. The address and utxo ID of the last utxo that
+// was visited are returned so they can be used as the start of the next call.
+func (vm *VM) GetPaginatedUTXOs(
+	addrs ids.Set,
+	startAddr ids.ID,
+	startUTXOID ids.ID,
+	limit int,
+) ([]*ava.UTXO, ids.ID, ids.ID, error) {
+	if limit <= 0 || limit > maxUTXOsToFetch {
+		limit = maxUTXOsToFetch
+	}
+
+	addrList := addrs.List()
+	ids.SortIDs(addrList)
+
+	lastAddr := startAddr
+	lastUTXOID := startUTXOID
+
+	utxos := []*ava.UTXO{}
+	for _, addr := range addrList {
+		start := ids.ID{}
+		if !startAddr.IsZero() {
+			switch bytes.Compare(addr.Bytes(), startAddr.Bytes()) {
+			case -1:
+				continue
+			case 0:
+				start = startUTXOID
+			}
+		}
+
+		for len(utxos) < limit {
+			toFetch := limit - len(utxos)
+			utxoIDs, err := vm.state.UTXOIDs(addr, start, toFetch)
+			if err != nil {
+				return nil, ids.ID{}, ids.ID{}, err
+			}
+			for _, utxoID := range utxoIDs {
+				lastAddr = addr
+				lastUTXOID = utxoID
+				start = utxoID
+
+				utxo, err := vm.state.UTXO(utxoID)
+				if err != nil {
+					return nil, ids.ID{}, ids.ID{}, err
+				}
+				// A utxo referenced by multiple of the addresses is only
+				// returned under the first of them, so it isn't returned again
+				// on a later page
+				if referencesEarlierAddr(utxo, addr, addrs) {
+					continue
+				}
+				utxos = append(utxos, utxo)
+			}
+			if len(utxoIDs) < toFetch {
+				break // This address doesn't have any more utxos
+			}
+		}
+		if len(utxos) >= limit {
+			break
+		}
+	}
+	return utxos, lastAddr, lastUTXOID, nil
+}
+
+// referencesEarlierAddr returns true if [utxo] references an address in [addrs]
+// that's ordered before [addr]
+func referencesEarlierAddr(utxo *ava.UTXO, addr ids.ID, addrs ids.Set) bool {
+	addressable, ok := utxo.Out.(ava.Addressable)
+	if !ok {
+		return false
+	}
+	for _, utxoAddr := range addressable.Addresses() {
+		utxoAddrID := ids.NewID(hashing.ComputeHash256Array(utxoAddr))
+		if addrs.Contains(utxoAddrID) && bytes.Compare(utxoAddrID.Bytes(), addr.Bytes()) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
 /*
  ******************************************************************************
  *********************************** Fx API ***********************************
@@ -344,6 +503,9 @@ func (vm *VM) Clock() *timer.Clock { return &vm.clock }
 // Codec returns a reference to the internal codec of this VM
 func (vm *VM) Codec() codec.Codec { return vm.codec }
 
+// Schema returns the versioned layout of this VM's database
+func (vm *VM) Schema() *migration.Schema { return schema }
+
 // Logger returns a reference to the internal logger of this VM
 func (vm *VM) Logger() logging.Logger { return vm.ctx.Log }
 
@@ -359,10 +521,12 @@ func (vm *VM) FlushTxs() {
 	if len(vm.txs) != 0 {
 		select {
 		case vm.toEngine <- common.PendingTxs:
+			// If the engine doesn't take the transactions, for example because
+			// it is bootstrapping, they are announced again.
 		default:
 			vm.ctx.Log.Warn("Delaying issuance of transactions due to contention")
-			vm.timer.SetTimeoutIn(vm.batchTimeout)
 		}
+		vm.timer.SetTimeoutIn(vm.batchTimeout)
 	}
 }
 
@@ -442,6 +606,114 @@ func (vm *VM) initState(genesisBytes []byte) error {
 	return vm.state.SetDBInitialized(choices.Processing)
 }
 
+func (vm *VM) initTxIndex(dbStatus choices.Status) error {
+	if !vm.indexTxs {
+		// If the index was previously populated, it will now miss transactions.
+		// Forget it so that re-enabling the index is reported as incomplete.
+		return vm.state.SetTxsIndexed(choices.Unknown)
+	}
+
+	if indexStatus, err := vm.state.TxsIndexed(); (err != nil || indexStatus == choices.Unknown) && dbStatus != choices.Unknown {
+		vm.ctx.Log.Warn("Transaction indexing was enabled after transactions were accepted. The index will not contain transactions accepted before now.")
+	}
+	return vm.state.SetTxsIndexed(choices.Processing)
+}
+
+// initUTXOIndex fills in the utxo index of a database whose utxos were stored
+// before the index was added
+func (vm *VM) initUTXOIndex(dbStatus choices.Status) error {
+	if indexStatus, err := vm.state.UTXOsIndexed(); err == nil && indexStatus != choices.Unknown {
+		return nil
+	}
+	if dbStatus != choices.Unknown {
+		numIndexed, err := vm.indexStoredUTXOs()
+		if err != nil {
+			return fmt.Errorf("couldn't index the stored utxos: %w", err)
+		}
+		vm.ctx.Log.Info("Indexed %d utxos that were stored before the utxo index was added", numIndexed)
+	}
+	return vm.state.SetUTXOsIndexed(choices.Processing)
+}
+
+// indexStoredUTXOs adds every utxo in the committed database to the utxo index,
+// and returns how many utxos were indexed. UTXOs are keyed by a hash of their
+// ID, so each stored value that parses as a utxo is only indexed if it's
+// stored under that utxo's key.
+func (vm *VM) indexStoredUTXOs() (int, error) {
+	iter := vm.baseDB.NewIterator()
+	defer iter.Release()
+
+	numIndexed := 0
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) != hashing.HashLen {
+			continue
+		}
+		utxo := &ava.UTXO{}
+		if err := vm.codec.Unmarshal(iter.Value(), utxo); err != nil {
+			continue
+		}
+		if !bytes.Equal(utxo.InputID().Prefix(utxoID).Bytes(), key) {
+			continue
+		}
+		if err := vm.state.IndexUTXO(utxo); err != nil {
+			return numIndexed, err
+		}
+		numIndexed++
+	}
+	return numIndexed, iter.Error()
+}
+
+// indexTx records that [tx] references each of the addresses of its inputs and
+// outputs. Must be called before the inputs of [tx] are spent.
+func (vm *VM) indexTx(tx *UniqueTx) error {
+	// address ID -> the assets of that address referenced by this tx
+	addrAssets := map[[32]byte]ids.Set{}
+	addrs := []ids.ID{}
+	index := func(utxo *ava.UTXO) {
+		addressable, ok := utxo.Out.(ava.Addressable)
+		if !ok {
+			return
+		}
+		for _, addr := range addressable.Addresses() {
+			addrID := ids.NewID(hashing.ComputeHash256Array(addr))
+			assetIDs, exists := addrAssets[addrID.Key()]
+			if !exists {
+				addrs = append(addrs, addrID)
+			}
+			assetIDs.Add(utxo.AssetID())
+			addrAssets[addrID.Key()] = assetIDs
+		}
+	}
+
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		utxo, err := vm.state.UTXO(utxoID.InputID())
+		if err != nil {
+			return err
+		}
+		index(utxo)
+	}
+	for _, utxo := range tx.UTXOs() {
+		index(utxo)
+	}
+
+	txID := tx.ID()
+	for _, addrID := range addrs {
+		if err := vm.state.AddAddressTx(addrID, ids.Empty, txID); err != nil {
+			return err
+		}
+		for _, assetID := range addrAssets[addrID.Key()].List() {
+			if err := vm.state.AddAddressTx(addrID, assetID, txID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (vm *VM) parseTx(b []byte) (*UniqueTx, error) {
 	rawTx := &Tx{}
 	err := vm.codec.Unmarshal(b, rawTx)
@@ -474,6 +746,33 @@ func (vm *VM) parseTx(b []byte) (*UniqueTx, error) {
 	return tx, nil
 }
 
+// addPendingTx verifies [tx], adds it to the mempool, gossips it to peers and
+// queues it to be sent to consensus
+func (vm *VM) addPendingTx(tx *UniqueTx) error {
+	if err := tx.Verify(); err != nil {
+		return err
+	}
+	// Verification results are cached, so make sure the inputs weren't spent
+	// since [tx] was last verified
+	for _, utxoID := range tx.InputUTXOs() {
+		if utxoID.Symbolic() {
+			continue
+		}
+		if _, err := vm.getUTXO(utxoID); err != nil {
+			return err
+		}
+	}
+	if err := vm.mempool.Add(tx); err != nil {
+		return err
+	}
+	vm.issueTx(tx)
+
+	if vm.gossiper != nil {
+		vm.gossiper.Gossip(tx.ID(), tx.Bytes())
+	}
+	return nil
+}
+
 func (vm *VM) issueTx(tx snowstorm.Tx) {
 	vm.txs = append(vm.txs, tx)
 	switch {
@@ -519,6 +818,18 @@ func (vm *VM) getFx(val interface{}) (int, error) {
 	return fx, nil
 }
 
+// getFxIndex returns the index of the registered fx that has the same type as
+// [fx]
+func (vm *VM) getFxIndex(fx Fx) (int, error) {
+	fxType := reflect.TypeOf(fx)
+	for i, parsedFx := range vm.fxs {
+		if reflect.TypeOf(parsedFx.Fx) == fxType {
+			return i, nil
+		}
+	}
+	return 0, errUnknownFx
+}
+
 func (vm *VM) verifyFxUsage(fxID int, assetID ids.ID) bool {
 	tx := &UniqueTx{
 		vm:   vm,
@@ -559,8 +870,20 @@ func (vm *VM) Parse(addrStr string) ([]byte, error) {
 	if !bcID.Equals(vm.ctx.ChainID) {
 		return nil, errWrongBlockchainID
 	}
+	return vm.parseRawAddress(rawAddr)
+}
+
+// parseRawAddress parses an address without a chain prefix. The address may be
+// either bech32, in which case it must use this network's HRP, or CB58.
+func (vm *VM) parseRawAddress(rawAddr string) ([]byte, error) {
+	if hrp, b, err := formatting.ParseBech32(rawAddr); err == nil {
+		if hrp != vm.hrp {
+			return nil, errWrongHRP
+		}
+		return b, nil
+	}
 	cb58 := formatting.CB58{}
-	err = cb58.FromString(rawAddr)
+	err := cb58.FromString(rawAddr)
 	return cb58.Bytes, err
 }
 
@@ -572,5 +895,16 @@ func (vm *VM) Format(b []byte) string {
 	} else {
 		bcAlias = vm.ctx.ChainID.String()
 	}
-	return fmt.Sprintf("%s%s%s", bcAlias, addressSep, formatting.CB58{Bytes: b})
+	return fmt.Sprintf("%s%s%s", bcAlias, addressSep, vm.formatRawAddress(b))
+}
+
+// formatRawAddress formats an address without a chain prefix, in bech32 if
+// configured to and CB58 otherwise
+func (vm *VM) formatRawAddress(b []byte) string {
+	if vm.bech32Addresses {
+		if addr, err := formatting.FormatBech32(vm.hrp, b); err == nil {
+			return addr
+		}
+	}
+	return formatting.CB58{Bytes: b}.String()
 }
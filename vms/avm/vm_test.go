@@ -6,10 +6,13 @@ package avm
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/prefixdb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
+	"github.com/ava-labs/gecko/snow/choices"
 	"github.com/ava-labs/gecko/snow/engine/common"
 	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/formatting"
@@ -491,6 +494,137 @@ func TestIssueTx(t *testing.T) {
 	}
 }
 
+func TestIssueTxMempool(t *testing.T) {
+	genesisBytes, _, vm := GenesisVM(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	gossiped := ids.Set{}
+	vm.SetGossiper(&common.SenderTest{
+		T: t,
+		GossipF: func(containerID ids.ID, _ []byte) {
+			gossiped.Add(containerID)
+		},
+	})
+
+	newTx := NewTx(t, genesisBytes, vm)
+	if _, err := vm.IssueTx(newTx.Bytes(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if !gossiped.Contains(newTx.ID()) {
+		t.Fatalf("Should have gossiped the issued tx")
+	}
+	if txIDs := vm.PendingTxIDs(); len(txIDs) != 1 || !txIDs[0].Equals(newTx.ID()) {
+		t.Fatalf("Should have %s pending", newTx.ID())
+	}
+
+	if _, err := vm.IssueTx(newTx.Bytes(), nil); err != errDuplicateTx {
+		t.Fatalf("Should have errored with %s, got %v", errDuplicateTx, err)
+	}
+	if err := vm.IssueGossipedTx(newTx.Bytes()); err != errDuplicateTx {
+		t.Fatalf("Should have errored with %s, got %v", errDuplicateTx, err)
+	}
+
+	b := newConflictingTx(t, genesisBytes, vm, newTx)
+	if _, err := vm.IssueTx(b, nil); err != errConflictingTx {
+		t.Fatalf("Should have errored with %s, got %v", errConflictingTx, err)
+	}
+
+	txs := vm.PendingTxs()
+	if len(txs) != 1 {
+		t.Fatalf("Should have returned %d tx(s)", 1)
+	}
+	txs[0].Accept()
+
+	if txIDs := vm.PendingTxIDs(); len(txIDs) != 0 {
+		t.Fatalf("Accepted tx should have been removed from the mempool")
+	}
+	if _, err := vm.IssueTx(b, nil); err == nil {
+		t.Fatalf("Should have errored because the UTXO was spent")
+	}
+}
+
+// newConflictingTx returns the bytes of a tx that spends the same UTXO as
+// [tx], but burns less of it
+func newConflictingTx(t *testing.T, genesisBytes []byte, vm *VM, tx *Tx) []byte {
+	genesisTx := GetFirstTxFromGenesisTest(genesisBytes, t)
+	conflictingTx := &Tx{UnsignedTx: &BaseTx{
+		NetID: networkID,
+		BCID:  chainID,
+		Outs: []*ava.TransferableOutput{&ava.TransferableOutput{
+			Asset: ava.Asset{ID: genesisTx.ID()},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+				},
+			},
+		}},
+		Ins: tx.UnsignedTx.(*BaseTx).Ins,
+	}}
+	if err := conflictingTx.SignSECP256K1Fx(vm.codec, [][]*crypto.PrivateKeySECP256K1R{{keys[0]}}); err != nil {
+		t.Fatal(err)
+	}
+	b, err := vm.codec.Marshal(conflictingTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestMempoolDrains(t *testing.T) {
+	genesisBytes, _, vm := GenesisVM(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	now := time.Now()
+	vm.clock.Set(now)
+	vm.mempool = newMempool(1, mempoolTxExpiry, &vm.clock)
+
+	newTx := NewTx(t, genesisBytes, vm)
+	if _, err := vm.IssueTx(newTx.Bytes(), nil); err != nil {
+		t.Fatal(err)
+	}
+	conflictingTxBytes := newConflictingTx(t, genesisBytes, vm, newTx)
+	if _, err := vm.IssueTx(conflictingTxBytes, nil); err != errMempoolFull {
+		t.Fatalf("Should have errored with %s, got %v", errMempoolFull, err)
+	}
+
+	// A tx that the engine dropped without deciding is evicted once it expires,
+	// which frees its inputs
+	vm.clock.Set(now.Add(mempoolTxExpiry - time.Second))
+	if txIDs := vm.PendingTxIDs(); len(txIDs) != 1 {
+		t.Fatalf("Tx shouldn't have expired yet")
+	}
+	vm.clock.Set(now.Add(mempoolTxExpiry))
+	if txIDs := vm.PendingTxIDs(); len(txIDs) != 0 {
+		t.Fatalf("Expired tx should have been evicted from the mempool")
+	}
+	if _, err := vm.IssueTx(conflictingTxBytes, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A rejected tx is removed from the mempool
+	txs := vm.PendingTxs()
+	if len(txs) != 2 {
+		t.Fatalf("Should have returned %d tx(s)", 2)
+	}
+	for _, tx := range txs {
+		if tx.ID().Equals(newTx.ID()) {
+			continue
+		}
+		tx.Reject()
+	}
+	if txIDs := vm.PendingTxIDs(); len(txIDs) != 0 {
+		t.Fatalf("Rejected tx should have been removed from the mempool")
+	}
+}
+
 func TestGenesisGetUTXOs(t *testing.T) {
 	_, _, vm := GenesisVM(t)
 	defer func() {
@@ -513,6 +647,121 @@ func TestGenesisGetUTXOs(t *testing.T) {
 	}
 }
 
+// Test that the utxo index of a database whose utxos were stored before the
+// index was added is filled in when the VM is initialized.
+func TestUTXOIndexFilledIn(t *testing.T) {
+	genesisBytes := BuildGenesisTest(t)
+	fxs := []*common.Fx{&common.Fx{
+		ID: ids.Empty,
+		Fx: &secp256k1fx.Fx{},
+	}}
+	addr := ids.NewID(hashing.ComputeHash256Array(keys[0].PublicKey().Address().Bytes()))
+
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	// Each VM closes its own view of the database when it's shut down
+	db := memdb.New()
+	vm := &VM{}
+	if err := vm.Initialize(ctx, prefixdb.New([]byte("vm"), db), genesisBytes, make(chan common.Message, 1), fxs); err != nil {
+		t.Fatal(err)
+	}
+	utxoIDs, err := vm.state.UTXOIDs(addr, ids.ID{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utxoIDs) != 7 {
+		t.Fatalf("Wrong number of indexed utxos. Expected (%d) returned (%d)", 7, len(utxoIDs))
+	}
+
+	// Remove the index, as if the utxos were stored before it was added
+	for _, utxoID := range utxoIDs {
+		if err := vm.state.state.RemoveIndexedID(uniqueID(addr, utxoIndexID, vm.state.utxoIndex), utxoID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := vm.state.SetUTXOsIndexed(choices.Unknown); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.db.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Shutdown()
+
+	vm = &VM{}
+	if err := vm.Initialize(ctx, prefixdb.New([]byte("vm"), db), genesisBytes, make(chan common.Message, 1), fxs); err != nil {
+		t.Fatal(err)
+	}
+	defer vm.Shutdown()
+
+	addrs := ids.Set{}
+	addrs.Add(addr)
+	utxos, _, _, err := vm.GetPaginatedUTXOs(addrs, ids.ID{}, ids.ID{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utxos) != 7 {
+		t.Fatalf("Wrong number of utxos. Expected (%d) returned (%d)", 7, len(utxos))
+	}
+}
+
+// Test that a utxo referenced by multiple of the requested addresses is only
+// returned once across pages.
+func TestGetPaginatedUTXOsSharedUTXOs(t *testing.T) {
+	_, _, vm := GenesisVM(t)
+	defer func() {
+		vm.Shutdown()
+		ctx.Lock.Unlock()
+	}()
+
+	shortAddrs := []ids.ShortID{
+		ids.NewShortID([20]byte{1}),
+		ids.NewShortID([20]byte{2}),
+	}
+	addrs := ids.Set{}
+	for _, shortAddr := range shortAddrs {
+		addrs.Add(ids.NewID(hashing.ComputeHash256Array(shortAddr.Bytes())))
+	}
+	for i := uint32(0); i < 3; i++ {
+		utxo := &ava.UTXO{
+			UTXOID: ava.UTXOID{TxID: ids.NewID([32]byte{9}), OutputIndex: i},
+			Asset:  ava.Asset{ID: asset},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     shortAddrs,
+				},
+			},
+		}
+		if err := vm.state.FundUTXO(utxo); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fetched := ids.Set{}
+	startAddr, startUTXOID := ids.ID{}, ids.ID{}
+	for {
+		utxos, endAddr, endUTXOID, err := vm.GetPaginatedUTXOs(addrs, startAddr, startUTXOID, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(utxos) == 0 {
+			break
+		}
+		for _, utxo := range utxos {
+			if fetched.Contains(utxo.InputID()) {
+				t.Fatalf("UTXO %s was returned twice", utxo.InputID())
+			}
+			fetched.Add(utxo.InputID())
+		}
+		startAddr, startUTXOID = endAddr, endUTXOID
+	}
+	if fetched.Len() != 3 {
+		t.Fatalf("Expected 3 distinct utxos, got %d", fetched.Len())
+	}
+}
+
 // Test issuing a transaction that consumes a currently pending UTXO. The
 // transaction should be issued successfully.
 func TestIssueDependentTx(t *testing.T) {
@@ -57,6 +57,7 @@ func (b *Block) Accept() {
 	b.SetStatus(choices.Accepted)                           // Change state of this block
 	b.VM.State.PutStatus(b.VM.DB, b.ID(), choices.Accepted) // Persist data
 	b.VM.State.PutLastAccepted(b.VM.DB, b.ID())
+	b.VM.indexHeight(b)
 	b.VM.LastAcceptedID = b.ID() // Change state of VM
 }
 
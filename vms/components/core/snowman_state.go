@@ -17,6 +17,10 @@ var errWrongType = errors.New("got unexpected type from database")
 // state.Get(Db, IDTypeID, lastAcceptedID) == ID of last accepted block
 var lastAcceptedID = ids.NewID([32]byte{'l', 'a', 's', 't'})
 
+// state.Get(Db, IDTypeID, heightIndexID.Prefix(height)) == ID of the block
+// accepted at height [height]
+var heightIndexID = ids.NewID([32]byte{'h', 'e', 'i', 'g', 'h', 't'})
+
 // SnowmanState is a wrapper around state.State
 // In additions to the methods exposed by state.State,
 // SnowmanState exposes a few methods needed for managing
@@ -27,6 +31,9 @@ type SnowmanState interface {
 	PutBlock(database.Database, snowman.Block) error
 	GetLastAccepted(database.Database) (ids.ID, error)
 	PutLastAccepted(database.Database, ids.ID) error
+	GetBlockIDAtHeight(database.Database, uint64) (ids.ID, error)
+	GetBlockHeight(database.Database, ids.ID) (uint64, error)
+	PutBlockHeight(database.Database, ids.ID, uint64) error
 }
 
 // implements SnowmanState
@@ -66,6 +73,25 @@ func (s *snowmanState) PutLastAccepted(db database.Database, lastAccepted ids.ID
 	return s.PutID(db, lastAcceptedID, lastAccepted)
 }
 
+// GetBlockIDAtHeight returns the ID of the block accepted at [height] in [db]
+func (s *snowmanState) GetBlockIDAtHeight(db database.Database, height uint64) (ids.ID, error) {
+	return s.GetID(db, heightIndexID.Prefix(height))
+}
+
+// GetBlockHeight returns the height of the accepted block with ID [ID] in [db]
+func (s *snowmanState) GetBlockHeight(db database.Database, ID ids.ID) (uint64, error) {
+	return s.GetUint64(db, ID)
+}
+
+// PutBlockHeight records in [db] that the block with ID [ID] was accepted at
+// [height]
+func (s *snowmanState) PutBlockHeight(db database.Database, ID ids.ID, height uint64) error {
+	if err := s.PutID(db, heightIndexID.Prefix(height), ID); err != nil {
+		return err
+	}
+	return s.PutUint64(db, ID, height)
+}
+
 // NewSnowmanState returns a new SnowmanState
 func NewSnowmanState(unmarshalBlockFunc func([]byte) (snowman.Block, error)) (SnowmanState, error) {
 	rawState := state.NewState()
@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/gecko/snow/choices"
 	"github.com/ava-labs/gecko/snow/consensus/snowman"
 	"github.com/ava-labs/gecko/snow/engine/common"
+	smeng "github.com/ava-labs/gecko/snow/engine/snowman"
 	"github.com/ava-labs/gecko/utils/json"
 	"github.com/ava-labs/gecko/vms/components/state"
 )
@@ -81,6 +82,79 @@ func (svm *SnowmanVM) GetBlock(ID ids.ID) (snowman.Block, error) {
 	return nil, errBadData // Should never happen
 }
 
+// GetBlockIDAtHeight returns the ID of the block accepted at [height]
+func (svm *SnowmanVM) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	if err := svm.VerifyHeightIndex(); err != nil {
+		return ids.ID{}, err
+	}
+	return svm.State.GetBlockIDAtHeight(svm.DB, height)
+}
+
+// GetBlockHeight returns the height of the accepted block with ID [ID]
+func (svm *SnowmanVM) GetBlockHeight(ID ids.ID) (uint64, error) {
+	return svm.State.GetBlockHeight(svm.DB, ID)
+}
+
+// VerifyHeightIndex returns nil if every accepted block is indexed by height.
+// The persisted last accepted block is checked, rather than LastAcceptedID,
+// because a VM may accept a block before persisting it.
+func (svm *SnowmanVM) VerifyHeightIndex() error {
+	lastAcceptedID, err := svm.State.GetLastAccepted(svm.DB)
+	if err != nil {
+		return smeng.ErrIndexIncomplete
+	}
+	if _, err := svm.State.GetBlockHeight(svm.DB, lastAcceptedID); err != nil {
+		return smeng.ErrIndexIncomplete
+	}
+	return nil
+}
+
+// IndexHeights indexes by height the blocks that were accepted before
+// accepted blocks were indexed by height. It walks back from the last accepted
+// block to the genesis block, so it should only be called once [svm] can get
+// its blocks.
+func (svm *SnowmanVM) IndexHeights() error {
+	if !svm.DBInitialized() || svm.VerifyHeightIndex() == nil {
+		return nil
+	}
+
+	blkIDs := []ids.ID(nil)
+	for blkID := svm.LastAcceptedID; ; {
+		blk, err := svm.GetBlock(blkID)
+		if err == database.ErrNotFound {
+			break // [blkID] is the genesis block's parent
+		}
+		if err != nil {
+			return err
+		}
+		blkIDs = append(blkIDs, blkID)
+		blkID = blk.Parent().ID()
+	}
+
+	for i, blkID := range blkIDs {
+		if err := svm.State.PutBlockHeight(svm.DB, blkID, uint64(len(blkIDs)-1-i)); err != nil {
+			return err
+		}
+	}
+	svm.Ctx.Log.Info("Indexed %d accepted blocks by height", len(blkIDs))
+	return svm.DB.Commit()
+}
+
+// indexHeight records the height of [blk], which is being accepted. If
+// [blk]'s parent wasn't indexed, the chain predates the height index and
+// IndexHeights will index [blk].
+func (svm *SnowmanVM) indexHeight(blk *Block) {
+	height := uint64(0)
+	if parentHeight, err := svm.State.GetBlockHeight(svm.DB, blk.ParentID()); err == nil {
+		height = parentHeight + 1
+	} else if !svm.LastAcceptedID.IsZero() { // Only the genesis block has no accepted parent
+		return
+	}
+	if err := svm.State.PutBlockHeight(svm.DB, blk.ID(), height); err != nil {
+		svm.Ctx.Log.Error("couldn't index block %s at height %d: %s", blk.ID(), height, err)
+	}
+}
+
 // Shutdown this vm
 func (svm *SnowmanVM) Shutdown() {
 	if svm.DB == nil {
@@ -435,3 +435,26 @@ func TestHappyPath(t *testing.T) {
 		t.Fatal("values should be same")
 	}
 }
+
+// Ensure uint64s can be put and gotten without registering a type
+func TestPutGetUint64(t *testing.T) {
+	state := NewState()
+	db := memdb.New()
+	defer db.Close()
+
+	key := ids.NewID([32]byte{1, 2, 3})
+	if _, err := state.GetUint64(db, key); err == nil {
+		t.Fatal("should have failed because no such key exists")
+	}
+
+	if err := state.PutUint64(db, key, 1<<40+5); err != nil {
+		t.Fatal(err)
+	}
+	value, err := state.GetUint64(db, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 1<<40+5 {
+		t.Fatalf("expected %d but got %d", uint64(1<<40+5), value)
+	}
+}
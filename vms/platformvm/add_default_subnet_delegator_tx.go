@@ -4,13 +4,21 @@
 package platformvm
 
 import (
+	"errors"
 	"fmt"
+	stdmath "math"
+	"time"
 
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/database/versiondb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/utils/math"
+)
+
+var (
+	errOverDelegated = errors.New("validator would have too much stake delegated to it")
 )
 
 // UnsignedAddDefaultSubnetDelegatorTx is an unsigned addDefaultSubnetDelegatorTx
@@ -137,30 +145,32 @@ func (tx *addDefaultSubnetDelegatorTx) SemanticVerify(db database.Database) (*ve
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("couldn't get current validators of default subnet: %v", err)
 	}
-	if dsValidator, err := currentEvents.getDefaultSubnetStaker(tx.NodeID); err == nil {
-		if !tx.DurationValidator.BoundedBy(dsValidator.StartTime(), dsValidator.EndTime()) {
-			return nil, nil, nil, nil, errDSValidatorSubset
-		}
-	} else {
+	pendingEvents, err := tx.vm.getPendingValidators(db, DefaultSubnetID)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("couldn't get pending validators of default subnet: %v", err)
+	}
+	dsValidator, err := currentEvents.getDefaultSubnetStaker(tx.NodeID)
+	if err != nil {
 		// They aren't currently validating the default subnet.
 		// See if they will validate the default subnet in the future.
-		pendingDSValidators, err := tx.vm.getPendingValidators(db, DefaultSubnetID)
+		dsValidator, err = pendingEvents.getDefaultSubnetStaker(tx.NodeID)
 		if err != nil {
-			return nil, nil, nil, nil, fmt.Errorf("couldn't get pending validators of default subnet: %v", err)
-		}
-		dsValidator, err := pendingDSValidators.getDefaultSubnetStaker(tx.NodeID)
-		if err != nil {
-			return nil, nil, nil, nil, errDSValidatorSubset
-		}
-		if !tx.DurationValidator.BoundedBy(dsValidator.StartTime(), dsValidator.EndTime()) {
 			return nil, nil, nil, nil, errDSValidatorSubset
 		}
 	}
+	if !tx.DurationValidator.BoundedBy(dsValidator.StartTime(), dsValidator.EndTime()) {
+		return nil, nil, nil, nil, errDSValidatorSubset
+	}
 
-	pendingEvents, err := tx.vm.getPendingValidators(db, DefaultSubnetID)
+	// Ensure that, at every point during the delegation period, the total
+	// stake delegated to the validator is no more than it is allowed
+	delegatedWeight, err := maxDelegatedWeight(tx.NodeID, tx.StartTime(), tx.EndTime(), currentEvents, pendingEvents)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
+	if delegatedWeight, err = math.Add64(delegatedWeight, tx.Wght); err != nil || delegatedWeight > maxDelegationWeight(dsValidator) {
+		return nil, nil, nil, nil, errOverDelegated
+	}
 
 	pendingEvents.Add(tx) // add validator to set of pending validators
 
@@ -186,6 +196,61 @@ func (tx *addDefaultSubnetDelegatorTx) InitiallyPrefersCommit() bool {
 	return tx.StartTime().After(tx.vm.clock.Time())
 }
 
+// maxDelegationWeight returns the most stake that can be delegated to
+// [validator] at any one time
+func maxDelegationWeight(validator *addDefaultSubnetValidatorTx) uint64 {
+	weight, err := math.Mul64(MaximumDelegationFactor, validator.Wght)
+	if err != nil {
+		return stdmath.MaxUint64
+	}
+	return weight
+}
+
+// maxDelegatedWeight returns the greatest total stake delegated to [nodeID] at
+// any time in [startTime, endTime) by the delegators in [stakers]
+func maxDelegatedWeight(nodeID ids.ShortID, startTime, endTime time.Time, stakers ...*EventHeap) (uint64, error) {
+	// Delegators to [nodeID] whose delegation overlaps [startTime, endTime),
+	// in the order they start
+	starting := &EventHeap{SortByStartTime: true}
+	for _, events := range stakers {
+		for _, tx := range events.Txs {
+			delegator, ok := tx.(*addDefaultSubnetDelegatorTx)
+			if !ok || !delegator.NodeID.Equals(nodeID) {
+				continue
+			}
+			if !delegator.StartTime().Before(endTime) || !delegator.EndTime().After(startTime) {
+				continue
+			}
+			starting.Add(delegator)
+		}
+	}
+
+	// Delegators that have started, in the order they end
+	ending := &EventHeap{SortByStartTime: false}
+
+	weight := uint64(0)
+	maxWeight := uint64(0)
+	for starting.Len() > 0 {
+		next := starting.Remove()
+		for ending.Len() > 0 && !ending.Peek().EndTime().After(next.StartTime()) {
+			// Because each delegator's weight was added before it's removed,
+			// this never underflows
+			weight -= ending.Remove().Vdr().Weight()
+		}
+
+		newWeight, err := math.Add64(weight, next.Vdr().Weight())
+		if err != nil {
+			return 0, err
+		}
+		weight = newWeight
+		if weight > maxWeight {
+			maxWeight = weight
+		}
+		ending.Add(next)
+	}
+	return maxWeight, nil
+}
+
 func (vm *VM) newAddDefaultSubnetDelegatorTx(
 	nonce,
 	weight,
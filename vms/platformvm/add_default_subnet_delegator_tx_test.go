@@ -387,3 +387,158 @@ func TestAddDefaultSubnetDelegatorTxSemanticVerify(t *testing.T) {
 	}
 	txFee = txFeeSaved // Reset tx fee
 }
+
+// Test that a validator can't have more than MaximumDelegationFactor times its
+// stake delegated to it at any one time
+func TestAddDefaultSubnetDelegatorTxOverDelegated(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	// (note that defaultKey is a genesis validator)
+	nodeID := defaultKey.PublicKey().Address()
+	startTime := defaultGenesisTime.Add(time.Second)
+	midTime := startTime.Add(MinimumStakingDuration)
+	endTime := midTime.Add(MinimumStakingDuration)
+
+	// Delegates most of the validator's capacity for the first half of the period
+	pendingTx, err := vm.newAddDefaultSubnetDelegatorTx(
+		defaultNonce+1,
+		(MaximumDelegationFactor-1)*defaultStakeAmount,
+		uint64(startTime.Unix()),
+		uint64(midTime.Unix()),
+		nodeID,
+		nodeID,
+		testNetworkID,
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pendingValidators, err := vm.getPendingValidators(vm.DB, DefaultSubnetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pendingValidators.Add(pendingTx)
+	if err := vm.putPendingValidators(vm.DB, pendingValidators, DefaultSubnetID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Case 1: overlaps the first half of the period, and together they exceed
+	// the validator's capacity
+	tx, err := vm.newAddDefaultSubnetDelegatorTx(
+		defaultNonce+1,
+		2*defaultStakeAmount,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		nodeID,
+		testNetworkID,
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, _, err := tx.SemanticVerify(vm.DB); err != errOverDelegated {
+		t.Fatalf("should have failed with %s but got %v", errOverDelegated, err)
+	}
+
+	// Case 2: overlaps the first half of the period, but fits in the remaining
+	// capacity
+	tx, err = vm.newAddDefaultSubnetDelegatorTx(
+		defaultNonce+1,
+		defaultStakeAmount,
+		uint64(startTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		nodeID,
+		testNetworkID,
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, _, err := tx.SemanticVerify(vm.DB); err != nil {
+		t.Fatal(err)
+	}
+
+	// Case 3: starts when the existing delegation ends
+	tx, err = vm.newAddDefaultSubnetDelegatorTx(
+		defaultNonce+1,
+		MaximumDelegationFactor*defaultStakeAmount,
+		uint64(midTime.Unix()),
+		uint64(endTime.Unix()),
+		nodeID,
+		nodeID,
+		testNetworkID,
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, _, err := tx.SemanticVerify(vm.DB); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMaxDelegatedWeight(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[0].PublicKey().Address()
+	start := defaultGenesisTime
+
+	newDelegator := func(weight uint64, startOffset, endOffset time.Duration, nodeID ids.ShortID) TimedTx {
+		tx, err := vm.newAddDefaultSubnetDelegatorTx(
+			defaultNonce+1,
+			weight,
+			uint64(start.Add(startOffset).Unix()),
+			uint64(start.Add(endOffset).Unix()),
+			nodeID,
+			nodeID,
+			testNetworkID,
+			keys[0],
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	current := &EventHeap{SortByStartTime: false}
+	current.Add(newDelegator(1, 0, 4*time.Hour, nodeID))
+	current.Add(newDelegator(2, time.Hour, 2*time.Hour, nodeID))
+	current.Add(newDelegator(100, 0, 4*time.Hour, keys[1].PublicKey().Address()))
+
+	pending := &EventHeap{SortByStartTime: true}
+	pending.Add(newDelegator(4, 2*time.Hour, 3*time.Hour, nodeID))
+	pending.Add(newDelegator(8, 5*time.Hour, 6*time.Hour, nodeID))
+
+	tests := []struct {
+		startOffset, endOffset time.Duration
+		expected               uint64
+	}{
+		{startOffset: 0, endOffset: time.Hour, expected: 1},
+		{startOffset: 0, endOffset: 2 * time.Hour, expected: 3},
+		{startOffset: 0, endOffset: 4 * time.Hour, expected: 5},
+		{startOffset: 3 * time.Hour, endOffset: 5 * time.Hour, expected: 1},
+		{startOffset: 0, endOffset: 6 * time.Hour, expected: 8},
+		{startOffset: 6 * time.Hour, endOffset: 7 * time.Hour, expected: 0},
+	}
+	for _, test := range tests {
+		weight, err := maxDelegatedWeight(nodeID, start.Add(test.startOffset), start.Add(test.endOffset), current, pending)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if weight != test.expected {
+			t.Fatalf("expected max delegated weight %d during [%s, %s) but got %d", test.expected, test.startOffset, test.endOffset, weight)
+		}
+	}
+}
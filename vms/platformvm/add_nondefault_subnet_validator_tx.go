@@ -171,16 +171,21 @@ func (tx *addNonDefaultSubnetValidatorTx) SemanticVerify(db database.Database) (
 		return nil, nil, nil, nil, fmt.Errorf("there is no subnet with ID %s", tx.SubnetID())
 	}
 
+	control, err := tx.vm.getSubnetControl(db, subnet)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
 	// Ensure the sigs on [tx] are valid
-	if len(tx.ControlSigs) != int(subnet.Threshold) {
-		return nil, nil, nil, nil, fmt.Errorf("expected tx to have %d control sigs but has %d", subnet.Threshold, len(tx.ControlSigs))
+	if len(tx.ControlSigs) != int(control.Threshold) {
+		return nil, nil, nil, nil, fmt.Errorf("expected tx to have %d control sigs but has %d", control.Threshold, len(tx.ControlSigs))
 	}
 	if !crypto.IsSortedAndUniqueSECP2561RSigs(tx.ControlSigs) {
 		return nil, nil, nil, nil, errors.New("control signatures aren't sorted")
 	}
 
 	controlKeys := ids.ShortSet{}
-	controlKeys.Add(subnet.ControlKeys...)
+	controlKeys.Add(control.ControlKeys...)
 	for _, controlID := range tx.controlIDs {
 		if !controlKeys.Contains(controlID) {
 			return nil, nil, nil, nil, errors.New("tx has control signature from key not in subnet's ControlKeys")
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/hashing"
 
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/database/versiondb"
@@ -24,13 +25,19 @@ type advanceTimeTx struct {
 	Time uint64 `serialize:"true"`
 
 	vm *VM
+	id ids.ID
 }
 
 func (tx *advanceTimeTx) initialize(vm *VM) error {
 	tx.vm = vm
-	return nil
+	txBytes, err := Codec.Marshal(tx) // byte repr. of the tx
+	tx.id = ids.NewID(hashing.ComputeHash256Array(txBytes))
+	return err
 }
 
+// ID returns the ID of this tx
+func (tx *advanceTimeTx) ID() ids.ID { return tx.id }
+
 // Timestamp returns the time this block is proposing the chain should be set to
 func (tx *advanceTimeTx) Timestamp() time.Time { return time.Unix(int64(tx.Time), 0) }
 
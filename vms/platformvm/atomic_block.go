@@ -89,6 +89,9 @@ func (ab *AtomicBlock) Verify() error {
 	if err := ab.Tx.SemanticVerify(ab.onAcceptDB); err != nil {
 		return err
 	}
+	if err := ab.vm.putTxStatus(ab.onAcceptDB, ab.Tx.ID(), CommittedTx); err != nil {
+		return err
+	}
 
 	ab.vm.currentBlocks[ab.ID().Key()] = ab
 	ab.parentBlock().addChild(ab)
@@ -0,0 +1,94 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/choices"
+	"github.com/ava-labs/gecko/utils/json"
+)
+
+func TestGetHeightAndBlock(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	service := Service{vm: vm}
+	heightReply := GetHeightReply{}
+	if err := service.GetHeight(nil, nil, &heightReply); err != nil {
+		t.Fatal(err)
+	}
+	if heightReply.Height != 0 {
+		t.Fatalf("expected height 0 at genesis but got %d", heightReply.Height)
+	}
+
+	createSubnetTx, err := vm.newCreateSubnetTx(
+		testNetworkID,
+		defaultNonce+1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		1,       // threshold
+		keys[0], // payer
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.unissuedDecisionTxs = append(vm.unissuedDecisionTxs, createSubnetTx)
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	blk.Accept()
+
+	if err := service.GetHeight(nil, nil, &heightReply); err != nil {
+		t.Fatal(err)
+	}
+	if heightReply.Height != 1 {
+		t.Fatalf("expected height 1 but got %d", heightReply.Height)
+	}
+
+	height := json.Uint64(1)
+	byHeight := GetBlockReply{}
+	if err := service.GetBlock(nil, &GetBlockArgs{Height: &height}, &byHeight); err != nil {
+		t.Fatal(err)
+	}
+	if !byHeight.ID.Equals(blk.ID()) {
+		t.Fatalf("expected block %s at height 1 but got %s", blk.ID(), byHeight.ID)
+	}
+	if byHeight.Type != "standard" {
+		t.Fatalf("expected a standard block but got %q", byHeight.Type)
+	}
+	if byHeight.Status != choices.Accepted {
+		t.Fatalf("expected the block to be accepted but it is %s", byHeight.Status)
+	}
+	if byHeight.Height == nil || *byHeight.Height != 1 {
+		t.Fatal("expected the block's height to be 1")
+	}
+	if len(byHeight.Txs) != 1 {
+		t.Fatalf("expected 1 tx but got %d", len(byHeight.Txs))
+	}
+
+	byID := GetBlockReply{}
+	if err := service.GetBlock(nil, &GetBlockArgs{ID: blk.ID()}, &byID); err != nil {
+		t.Fatal(err)
+	}
+	if !byID.ParentID.Equals(byHeight.ParentID) || byID.Type != byHeight.Type {
+		t.Fatal("expected the same block whether fetched by ID or by height")
+	}
+
+	if err := service.GetBlock(nil, &GetBlockArgs{}, &byID); err == nil {
+		t.Fatal("should have failed because neither ID nor height was given")
+	}
+	height = 2
+	if err := service.GetBlock(nil, &GetBlockArgs{Height: &height}, &byID); err == nil {
+		t.Fatal("should have failed because no block was accepted at height 2")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ava-labs/gecko/chains"
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/utils/crypto"
@@ -17,6 +18,7 @@ var (
 	errInvalidVMID                   = errors.New("invalid VM ID")
 	errFxIDsNotSortedAndUnique       = errors.New("feature extensions IDs must be sorted and unique")
 	errControlSigsNotSortedAndUnique = errors.New("control signatures must be sorted and unique")
+	errPlatformChainGenesis          = errors.New("can't do a dry run of a platform chain's genesis")
 )
 
 // UnsignedCreateChainTx is an unsigned CreateChainTx
@@ -165,8 +167,12 @@ func (tx *CreateChainTx) SemanticVerify(db database.Database) (func(), error) {
 	if subnet == nil {
 		return nil, fmt.Errorf("there is no subnet with ID %s", tx.SubnetID)
 	}
-	if len(tx.ControlSigs) != int(subnet.Threshold) {
-		return nil, fmt.Errorf("expected tx to have %d control sigs but has %d", subnet.Threshold, len(tx.ControlSigs))
+	control, err := tx.vm.getSubnetControl(db, subnet)
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.ControlSigs) != int(control.Threshold) {
+		return nil, fmt.Errorf("expected tx to have %d control sigs but has %d", control.Threshold, len(tx.ControlSigs))
 	}
 
 	unsignedIntf := interface{}(&tx.UnsignedCreateChainTx)
@@ -188,7 +194,7 @@ func (tx *CreateChainTx) SemanticVerify(db database.Database) (func(), error) {
 
 	// Verify each control signature on this tx is from a control key
 	controlKeys := ids.ShortSet{}
-	controlKeys.Add(subnet.ControlKeys...)
+	controlKeys.Add(control.ControlKeys...)
 	for _, controlID := range controlIDs {
 		if !controlKeys.Contains(controlID) {
 			return nil, errors.New("tx has control signature from key not in subnet's ControlKeys")
@@ -260,3 +266,32 @@ func (vm *VM) newCreateChainTx(nonce uint64, subnetID ids.ID, genesisData []byte
 
 	return tx, tx.initialize(vm)
 }
+
+// verifyGenesis returns an error if this node is running the chain's VM and
+// the VM rejects the chain's genesis data.
+//
+// This isn't part of SemanticVerify, as its result depends on which VMs are
+// registered on this node. Every node must agree on the validity of a block.
+func (tx *CreateChainTx) verifyGenesis() error {
+	return tx.vm.verifyGenesis(tx.VMID, tx.FxIDs, tx.GenesisData)
+}
+
+// verifyGenesis is like validateGenesis, but it succeeds if the genesis data
+// can't be checked on this node
+func (vm *VM) verifyGenesis(vmID ids.ID, fxIDs []ids.ID, genesisData []byte) error {
+	err := vm.validateGenesis(vmID, fxIDs, genesisData)
+	if errors.Is(err, chains.ErrUnknownVM) || err == errPlatformChainGenesis {
+		return nil
+	}
+	return err
+}
+
+// validateGenesis does a dry run of starting a chain running the VM [vmID]
+// from [genesisData]
+func (vm *VM) validateGenesis(vmID ids.ID, fxIDs []ids.ID, genesisData []byte) error {
+	// Initializing a platform chain would create the chains in its genesis
+	if vmID.Equals(ID) {
+		return errPlatformChainGenesis
+	}
+	return vm.chainManager.ValidateGenesis(vmID, fxIDs, genesisData)
+}
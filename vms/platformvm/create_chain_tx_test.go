@@ -4,10 +4,14 @@
 package platformvm
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 
+	"github.com/ava-labs/gecko/chains"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/vms/avm"
 )
 
@@ -328,3 +332,118 @@ func TestCreateChainTxValid(t *testing.T) {
 		t.Fatalf("expected tx to pass verification but got error: %v", err)
 	}
 }
+
+// genesisManager is a chains.Manager that only runs [vmID], and accepts only
+// [validGenesis] as its genesis data
+type genesisManager struct {
+	chains.MockManager
+	vmID         ids.ID
+	validGenesis []byte
+}
+
+func (m genesisManager) LookupVM(alias string) (ids.ID, error) {
+	return ids.FromString(alias)
+}
+
+func (m genesisManager) ValidateGenesis(vmID ids.ID, _ []ids.ID, genesisData []byte) error {
+	switch {
+	case !vmID.Equals(m.vmID):
+		return chains.ErrUnknownVM
+	case !bytes.Equal(genesisData, m.validGenesis):
+		return errors.New("invalid genesis")
+	default:
+		return nil
+	}
+}
+
+func TestCreateChainTxVerifyGenesis(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+	localVMID := ids.NewID([32]byte{'l', 'o', 'c', 'a', 'l'})
+	vm.chainManager = genesisManager{vmID: localVMID, validGenesis: []byte("valid")}
+
+	newTx := func(vmID ids.ID, genesisData []byte) *CreateChainTx {
+		tx, err := vm.newCreateChainTx(
+			defaultNonce+1,
+			testSubnet1.id,
+			genesisData,
+			vmID,
+			nil,
+			"chain name",
+			testNetworkID,
+			[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+			defaultKey,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	if err := newTx(localVMID, []byte("valid")).verifyGenesis(); err != nil {
+		t.Fatal(err)
+	}
+	if err := newTx(localVMID, []byte("invalid")).verifyGenesis(); err == nil {
+		t.Fatal("should have failed because the VM rejects the genesis data")
+	}
+	// Genesis data of VMs this node doesn't run can't be checked
+	if err := newTx(avm.ID, []byte("invalid")).verifyGenesis(); err != nil {
+		t.Fatal(err)
+	}
+	if err := newTx(ID, []byte("invalid")).verifyGenesis(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Issuing a chain with invalid genesis data fails
+	service := Service{vm: vm}
+	txBytes, err := Codec.Marshal(genericTx{Tx: newTx(localVMID, []byte("invalid"))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	issueArgs := IssueTxArgs{Tx: formatting.CB58{Bytes: txBytes}}
+	if err := service.IssueTx(nil, &issueArgs, &IssueTxResponse{}); err == nil {
+		t.Fatal("should have failed because the VM rejects the genesis data")
+	}
+	if len(vm.unissuedDecisionTxs) != 0 {
+		t.Fatal("shouldn't have added the tx to the mempool")
+	}
+}
+
+func TestValidateGenesis(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+	localVMID := ids.NewID([32]byte{'l', 'o', 'c', 'a', 'l'})
+	vm.chainManager = genesisManager{vmID: localVMID, validGenesis: []byte("valid")}
+
+	service := Service{vm: vm}
+	reply := ValidateGenesisReply{}
+	args := ValidateGenesisArgs{
+		VMID:        localVMID.String(),
+		GenesisData: formatting.CB58{Bytes: []byte("valid")},
+	}
+	if err := service.ValidateGenesis(nil, &args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !reply.Valid {
+		t.Fatal("expected the genesis data to be valid")
+	}
+
+	args.GenesisData.Bytes = []byte("invalid")
+	if err := service.ValidateGenesis(nil, &args, &reply); err == nil {
+		t.Fatal("should have failed because the VM rejects the genesis data")
+	}
+
+	// Unlike when issuing a tx, the VM must be registered on this node
+	args.VMID = ids.NewID([32]byte{1}).String()
+	if err := service.ValidateGenesis(nil, &args, &reply); !errors.Is(err, chains.ErrUnknownVM) {
+		t.Fatalf("expected ErrUnknownVM but got %v", err)
+	}
+}
@@ -80,6 +80,21 @@ func (h *EventHeap) Peek() TimedTx { return h.Txs[0] }
 // Remove ...
 func (h *EventHeap) Remove() TimedTx { return heap.Pop(h).(TimedTx) }
 
+// removeValidator removes each tx in the heap that adds the validator with ID
+// [nodeID]. Returns true if any tx was removed.
+func (h *EventHeap) removeValidator(nodeID ids.ShortID) bool {
+	txs := h.Txs[:0]
+	for _, tx := range h.Txs {
+		if !tx.Vdr().ID().Equals(nodeID) {
+			txs = append(txs, tx)
+		}
+	}
+	removed := len(txs) != len(h.Txs)
+	h.Txs = txs
+	heap.Init(h)
+	return removed
+}
+
 // Push implements the heap interface
 func (h *EventHeap) Push(x interface{}) { h.Txs = append(h.Txs, x.(TimedTx)) }
 
@@ -5,7 +5,9 @@ package platformvm
 
 import (
 	"github.com/ava-labs/gecko/chains"
+	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/uptime"
 	"github.com/ava-labs/gecko/snow/validators"
 )
 
@@ -21,6 +23,30 @@ type Factory struct {
 	StakingEnabled bool
 	AVA            ids.ID
 	AVM            ids.ID
+
+	// Human readable part of bech32 addresses on this network
+	HRP string
+
+	// If true, addresses are returned in bech32 rather than CB58
+	Bech32Addresses bool
+
+	// Records when this node was connected to each of its peers. If nil, the
+	// VM only knows that this node is connected to itself.
+	Uptimes *uptime.Tracker
+
+	// Persists the uptimes this node has recorded. Uptimes are specific to this
+	// node, so they're kept out of the chain's state. If nil, they're kept in
+	// memory.
+	UptimeDB database.Database
+
+	// Persists the txs this node hasn't put into blocks yet. The mempool is
+	// specific to this node, so it's kept out of the chain's state. If nil,
+	// it's kept in memory.
+	MempoolDB database.Database
+
+	// Fraction of its staking period a validator must have been connected to
+	// this node for this node to initially prefer rewarding it
+	UptimeRequirement float64
 }
 
 // New returns a new instance of the Platform Chain
@@ -31,5 +57,14 @@ func (f *Factory) New() (interface{}, error) {
 		stakingEnabled: f.StakingEnabled,
 		ava:            f.AVA,
 		avm:            f.AVM,
+
+		hrp:             f.HRP,
+		bech32Addresses: f.Bech32Addresses,
+
+		uptimes:           f.Uptimes,
+		uptimeDB:          f.UptimeDB,
+		uptimeRequirement: f.UptimeRequirement,
+
+		mempoolDB: f.MempoolDB,
 	}, nil
 }
@@ -0,0 +1,338 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/versiondb"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/engine/common"
+)
+
+const (
+	// maxMempoolSize is the most txs that can be pending at once
+	maxMempoolSize = 4096
+)
+
+var (
+	errUnknownTxType = errors.New("could not parse given tx. Must be a TimedTx, DecisionTx, or AtomicTx")
+	errMempoolFull   = errors.New("mempool is full")
+	errDecidedTx     = errors.New("tx has already been decided")
+	errLateStartTime = errors.New("tx's start time has passed")
+)
+
+// issueTx initializes [tx] and adds it to the mempool. The tx is persisted, so
+// it's still pending if this node restarts before the tx is put into a block.
+// If the tx is valid on the preferred chain, it's gossiped to the other
+// validators. Otherwise, it's only kept locally, as it may become valid once
+// other pending txs are accepted.
+func (vm *VM) issueTx(tx interface{}) (ids.ID, error) {
+	if tx, ok := tx.(*CreateChainTx); ok {
+		if err := tx.initialize(vm); err != nil {
+			return ids.ID{}, fmt.Errorf("error initializing tx: %s", err)
+		}
+		if err := tx.verifyGenesis(); err != nil {
+			return ids.ID{}, fmt.Errorf("invalid genesis data: %w", err)
+		}
+	}
+
+	txID, err := vm.initializeTx(tx)
+	if err != nil {
+		return ids.ID{}, err
+	}
+	err = vm.verifyUnissuedTx(tx)
+	if err != nil {
+		vm.Ctx.Log.Debug("not gossiping tx %s because it's invalid on the preferred chain: %s", txID, err)
+	}
+	return txID, vm.addToMempool(tx, err == nil)
+}
+
+// addToMempool adds [tx], which has been initialized, to the mempool and
+// persists it. If [gossip], the tx is also gossiped to the other validators.
+func (vm *VM) addToMempool(tx interface{}, gossip bool) error {
+	txID, added, err := vm.addUnissuedTx(tx)
+	if err != nil || !added {
+		return err
+	}
+
+	txBytes, err := Codec.Marshal(genericTx{Tx: tx})
+	if err != nil {
+		return err
+	}
+	if err := vm.mempoolDB.Put(txID.Bytes(), txBytes); err != nil {
+		return err
+	}
+
+	if gossip && vm.gossiper != nil {
+		vm.gossiper.Gossip(txID, txBytes)
+	}
+	return nil
+}
+
+// verifyUnissuedTx returns nil if [tx], which has been initialized, could be
+// put into a block built on the preferred block. The check is cheap: the
+// genesis data of a chain isn't dry run.
+func (vm *VM) verifyUnissuedTx(tx interface{}) error {
+	preferredDB, err := vm.preferredState()
+	if err != nil {
+		return err
+	}
+	db := versiondb.New(preferredDB)
+	defer db.Abort()
+
+	switch tx := tx.(type) {
+	case TimedTx:
+		if syncTime := vm.clock.Time().Add(Delta); syncTime.After(tx.StartTime()) {
+			return errLateStartTime
+		}
+		_, _, _, _, err := tx.SemanticVerify(db)
+		return err
+	case DecisionTx:
+		_, err := tx.SemanticVerify(db)
+		return err
+	case AtomicTx:
+		return tx.SemanticVerify(db)
+	default:
+		return errUnknownTxType
+	}
+}
+
+// preferredState returns the state of the chain if the preferred decision
+// block were accepted. If the preferred block is a proposal, its parent's state
+// is returned, as the proposal's outcome isn't known.
+func (vm *VM) preferredState() (database.Database, error) {
+	preferred, err := vm.getBlock(vm.Preferred())
+	if err != nil {
+		return nil, err
+	}
+	blk, ok := preferred.(Block)
+	if !ok {
+		return nil, errInvalidBlockType
+	}
+	if proposal, ok := blk.(*ProposalBlock); ok {
+		blk = proposal.parentBlock()
+	}
+	decision, ok := blk.(decision)
+	if !ok {
+		return nil, errInvalidBlockType
+	}
+	return decision.onAccept(), nil
+}
+
+// initializeTx initializes [tx] and returns its ID
+func (vm *VM) initializeTx(tx interface{}) (ids.ID, error) {
+	switch tx := tx.(type) {
+	case TimedTx:
+		if err := tx.initialize(vm); err != nil {
+			return ids.ID{}, fmt.Errorf("error initializing tx: %s", err)
+		}
+		return tx.ID(), nil
+	case DecisionTx:
+		if err := tx.initialize(vm); err != nil {
+			return ids.ID{}, fmt.Errorf("error initializing tx: %s", err)
+		}
+		return tx.ID(), nil
+	case AtomicTx:
+		if err := tx.initialize(vm); err != nil {
+			return ids.ID{}, fmt.Errorf("error initializing tx: %s", err)
+		}
+		return tx.ID(), nil
+	default:
+		return ids.ID{}, errUnknownTxType
+	}
+}
+
+// addUnissuedTx initializes [tx] and adds it to the mempool, unless it's
+// already there. Returns true if [tx] was added.
+func (vm *VM) addUnissuedTx(tx interface{}) (ids.ID, bool, error) {
+	txID, err := vm.initializeTx(tx)
+	switch {
+	case err != nil:
+		return ids.ID{}, false, err
+	case vm.pendingTxIDs.Contains(txID):
+		return txID, false, nil
+	case vm.pendingTxIDs.Len() >= maxMempoolSize:
+		return ids.ID{}, false, errMempoolFull
+	}
+
+	switch tx := tx.(type) {
+	case TimedTx:
+		vm.unissuedEvents.Add(tx)
+	case DecisionTx:
+		vm.unissuedDecisionTxs = append(vm.unissuedDecisionTxs, tx)
+	case AtomicTx:
+		vm.unissuedAtomicTxs = append(vm.unissuedAtomicTxs, tx)
+	}
+	vm.pendingTxIDs.Add(txID)
+	return txID, true, nil
+}
+
+// removeUnissuedTx records that the tx with ID [txID] has been taken out of
+// the mempool, so it isn't reloaded after a restart
+func (vm *VM) removeUnissuedTx(txID ids.ID) {
+	vm.pendingTxIDs.Remove(txID)
+	if err := vm.mempoolDB.Delete(txID.Bytes()); err != nil {
+		vm.Ctx.Log.Error("failed to remove tx %s from the persisted mempool: %s", txID, err)
+	}
+}
+
+// pendingTxs returns the txs in the mempool
+func (vm *VM) pendingTxs() []interface{} {
+	txs := make([]interface{}, 0, vm.pendingTxIDs.Len())
+	for _, tx := range vm.unissuedDecisionTxs {
+		txs = append(txs, tx)
+	}
+	for _, tx := range vm.unissuedAtomicTxs {
+		txs = append(txs, tx)
+	}
+	for _, tx := range vm.unissuedEvents.Txs {
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+// isProcessing returns true if the tx with ID [txID] is in a block that has
+// been verified but not yet decided
+func (vm *VM) isProcessing(txID ids.ID) bool {
+	for _, blk := range vm.currentBlocks {
+		switch blk := blk.(type) {
+		case *ProposalBlock:
+			if txID.Equals(blk.Tx.ID()) {
+				return true
+			}
+		case *StandardBlock:
+			for _, tx := range blk.Txs {
+				if txID.Equals(tx.ID()) {
+					return true
+				}
+			}
+		case *AtomicBlock:
+			if txID.Equals(blk.Tx.ID()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dropTx records that the tx with ID [txID] was removed from the mempool
+// without being issued
+func (vm *VM) dropTx(txID ids.ID, reason string) {
+	vm.Ctx.Log.Debug("dropping tx %s because %s", txID, reason)
+	if err := vm.putTxStatus(vm.DB, txID, DroppedTx); err != nil {
+		vm.Ctx.Log.Error("failed to record that tx %s was dropped: %s", txID, err)
+	}
+}
+
+// getTxStatus returns the status of the tx with ID [txID]
+func (vm *VM) getTxStatus(txID ids.ID) (TxStatus, error) {
+	status, err := vm.getStoredTxStatus(vm.DB, txID)
+	switch {
+	case err != nil:
+		return UnknownTx, err
+	case status == CommittedTx || status == AbortedTx:
+		return status, nil
+	case vm.isProcessing(txID):
+		return ProcessingTx, nil
+	case vm.pendingTxIDs.Contains(txID):
+		return PendingTx, nil
+	default: // Either dropped or unknown
+		return status, nil
+	}
+}
+
+// loadMempool adds the txs that were in the mempool when this node last shut
+// down back into the mempool. Txs to add validators whose start times have
+// passed are dropped.
+func (vm *VM) loadMempool() error {
+	syncTime := vm.clock.Time().Add(Delta)
+
+	iter := vm.mempoolDB.NewIterator()
+	defer iter.Release()
+
+	for iter.Next() {
+		genTx := genericTx{}
+		if err := Codec.Unmarshal(iter.Value(), &genTx); err != nil {
+			return err
+		}
+		if tx, ok := genTx.Tx.(TimedTx); ok && syncTime.After(tx.StartTime()) {
+			if err := tx.initialize(vm); err != nil {
+				return err
+			}
+			vm.dropTx(tx.ID(), "its start time has passed")
+			if err := vm.mempoolDB.Delete(iter.Key()); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, _, err := vm.addUnissuedTx(genTx.Tx); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	// Persist the statuses of the dropped txs
+	return vm.DB.Commit()
+}
+
+// SetGossiper implements the snowman.GossipingChainVM interface
+func (vm *VM) SetGossiper(gossiper common.Gossiper) { vm.gossiper = gossiper }
+
+// IssueGossipedTx implements the snowman.GossipingChainVM interface.
+// Gossiped txs are only added to the mempool, and gossiped again, if they're
+// valid on the preferred chain.
+func (vm *VM) IssueGossipedTx(txBytes []byte) error {
+	genTx := genericTx{}
+	if err := Codec.Unmarshal(txBytes, &genTx); err != nil {
+		return err
+	}
+	txID, err := vm.initializeTx(genTx.Tx)
+	if err != nil {
+		return err
+	}
+	if vm.pendingTxIDs.Contains(txID) {
+		return nil
+	}
+	if status, err := vm.getStoredTxStatus(vm.DB, txID); err != nil {
+		return err
+	} else if status == CommittedTx || status == AbortedTx {
+		return errDecidedTx
+	}
+	if err := vm.verifyUnissuedTx(genTx.Tx); err != nil {
+		return err
+	}
+	if err := vm.addToMempool(genTx.Tx, true); err != nil {
+		return err
+	}
+	vm.resetTimer()
+	return nil
+}
+
+// put the status of the tx with ID [txID] in [db]
+func (vm *VM) putTxStatus(db database.Database, txID ids.ID, status TxStatus) error {
+	return vm.State.Put(db, txStatusTypeID, txID, status)
+}
+
+// get the status of the tx with ID [txID] that was stored in [db]. Returns
+// UnknownTx if none was stored.
+func (vm *VM) getStoredTxStatus(db database.Database, txID ids.ID) (TxStatus, error) {
+	exists, err := vm.State.Has(db, txStatusTypeID, txID)
+	if err != nil || !exists {
+		return UnknownTx, err
+	}
+	statusIntf, err := vm.State.Get(db, txStatusTypeID, txID)
+	if err != nil {
+		return UnknownTx, err
+	}
+	status, ok := statusIntf.(TxStatus)
+	if !ok {
+		vm.Ctx.Log.Warn("expected to retrieve TxStatus from database but got different type")
+		return UnknownTx, errDB
+	}
+	return status, nil
+}
@@ -0,0 +1,412 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/gecko/chains"
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/engine/common"
+	"github.com/ava-labs/gecko/snow/validators"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/formatting"
+	"github.com/ava-labs/gecko/vms/components/core"
+	"github.com/ava-labs/gecko/vms/timestampvm"
+)
+
+// initVM returns a VM that was initialized from the default genesis on [db]
+// at time [now], and that persists its mempool in [mempoolDB]. The VM sends its
+// messages to the engine on [toEngine]. The VM's context lock is held.
+func initVM(t *testing.T, db, mempoolDB database.Database, now time.Time, toEngine chan common.Message) *VM {
+	genesisBytes, err := Codec.Marshal(Genesis{
+		Accounts:   GenesisAccounts(),
+		Validators: GenesisCurrentValidators(),
+		Chains:     []*CreateChainTx{},
+		Timestamp:  uint64(defaultGenesisTime.Unix()),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm := &VM{
+		SnowmanVM:    &core.SnowmanVM{},
+		chainManager: chains.MockManager{},
+		mempoolDB:    mempoolDB,
+	}
+	vm.validators = validators.NewManager()
+	vm.validators.PutValidatorSet(DefaultSubnetID, validators.NewSet())
+	vm.clock.Set(now)
+
+	ctx := defaultContext()
+	ctx.Lock.Lock()
+	if err := vm.Initialize(ctx, db, genesisBytes, toEngine, nil); err != nil {
+		t.Fatal(err)
+	}
+	return vm
+}
+
+// issue [tx] through the API
+func issue(t *testing.T, service *Service, tx interface{}) {
+	txBytes, err := Codec.Marshal(genericTx{Tx: tx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := IssueTxArgs{Tx: formatting.CB58{Bytes: txBytes}}
+	if err := service.IssueTx(nil, &args, &IssueTxResponse{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertTxStatus(t *testing.T, service *Service, txID ids.ID, expected TxStatus) {
+	reply := GetTxStatusReply{}
+	if err := service.GetTxStatus(nil, &GetTxStatusArgs{TxID: txID}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Status != expected {
+		t.Fatalf("expected tx %s to be %s but it's %s", txID, expected, reply.Status)
+	}
+}
+
+func TestMempoolPersistsAcrossRestarts(t *testing.T) {
+	db := memdb.New()
+	mempoolDB := memdb.New()
+	firstVM := initVM(t, db, mempoolDB, defaultGenesisTime, make(chan common.Message, 1))
+	firstService := &Service{vm: firstVM}
+
+	newValidatorTx := func(startTime time.Time) *addDefaultSubnetValidatorTx {
+		key, err := firstVM.factory.NewPrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodeID := key.PublicKey().Address()
+		tx, err := firstVM.newAddDefaultSubnetValidatorTx(
+			defaultNonce+1,
+			defaultStakeAmount,
+			uint64(startTime.Unix()),
+			uint64(startTime.Add(MinimumStakingDuration).Unix()),
+			nodeID,
+			nodeID,
+			NumberOfShares,
+			testNetworkID,
+			defaultKey,
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	// [staleTx]'s start time will have passed by the time the node restarts
+	staleTx := newValidatorTx(defaultGenesisTime.Add(Delta).Add(time.Second))
+	validatorTx := newValidatorTx(defaultGenesisTime.Add(time.Hour))
+	createSubnetTx, err := firstVM.newCreateSubnetTx(
+		testNetworkID,
+		defaultNonce+1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		1,       // threshold
+		keys[0], // payer
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issue(t, firstService, staleTx)
+	issue(t, firstService, validatorTx)
+	issue(t, firstService, createSubnetTx)
+	issue(t, firstService, createSubnetTx) // Issuing a tx twice doesn't duplicate it
+
+	pendingReply := GetPendingTxsReply{}
+	if err := firstService.GetPendingTxs(nil, nil, &pendingReply); err != nil {
+		t.Fatal(err)
+	}
+	if len(pendingReply.Txs) != 3 {
+		t.Fatalf("expected 3 pending txs but got %d", len(pendingReply.Txs))
+	}
+	assertTxStatus(t, firstService, staleTx.ID(), PendingTx)
+	assertTxStatus(t, firstService, ids.NewID([32]byte{1}), UnknownTx)
+
+	firstVM.Shutdown()
+	firstVM.Ctx.Lock.Unlock()
+
+	toEngine := make(chan common.Message, 1)
+	secondVM := initVM(t, db, mempoolDB, defaultGenesisTime.Add(time.Minute), toEngine)
+	defer func() {
+		secondVM.Shutdown()
+		secondVM.Ctx.Lock.Unlock()
+	}()
+	secondService := &Service{vm: secondVM}
+
+	if err := secondService.GetPendingTxs(nil, nil, &pendingReply); err != nil {
+		t.Fatal(err)
+	}
+	if len(pendingReply.Txs) != 2 {
+		t.Fatalf("expected 2 pending txs after restarting but got %d", len(pendingReply.Txs))
+	}
+	assertTxStatus(t, secondService, staleTx.ID(), DroppedTx)
+	assertTxStatus(t, secondService, validatorTx.ID(), PendingTx)
+	assertTxStatus(t, secondService, createSubnetTx.ID(), PendingTx)
+
+	// The reloaded txs should be put into a block without waiting for new txs
+	select {
+	case msg := <-toEngine:
+		if msg != common.PendingTxs {
+			t.Fatalf("expected the engine to be told about pending txs but got %s", msg)
+		}
+	default:
+		t.Fatalf("the engine wasn't told about the reloaded txs")
+	}
+
+	blk, err := secondVM.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	assertTxStatus(t, secondService, createSubnetTx.ID(), ProcessingTx)
+	blk.Accept()
+	assertTxStatus(t, secondService, createSubnetTx.ID(), CommittedTx)
+
+	if err := secondService.GetPendingTxs(nil, nil, &pendingReply); err != nil {
+		t.Fatal(err)
+	}
+	if len(pendingReply.Txs) != 1 || !pendingReply.Txs[0].ID.Equals(validatorTx.ID()) || pendingReply.Txs[0].Kind != "proposal" {
+		t.Fatalf("expected only the validator tx to be pending but got %+v", pendingReply.Txs)
+	}
+}
+
+func TestIssueTxGossips(t *testing.T) {
+	vm := initVM(t, memdb.New(), memdb.New(), defaultGenesisTime, make(chan common.Message, 1))
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+	service := &Service{vm: vm}
+
+	sender := &common.SenderTest{T: t}
+	sender.Default(true)
+	vm.SetGossiper(sender)
+
+	tx, err := vm.newCreateSubnetTx(
+		testNetworkID,
+		defaultNonce+1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		1,       // threshold
+		keys[0], // payer
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txBytes, err := Codec.Marshal(genericTx{Tx: tx})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gossiped := 0
+	sender.GossipF = func(txID ids.ID, b []byte) {
+		gossiped++
+		if !txID.Equals(tx.ID()) {
+			t.Fatalf("gossiped %s but expected %s", txID, tx.ID())
+		}
+		if !bytes.Equal(b, txBytes) {
+			t.Fatalf("gossiped the wrong tx bytes")
+		}
+	}
+
+	issue(t, service, tx)
+	if gossiped != 1 {
+		t.Fatalf("expected the issued tx to be gossiped once but it was gossiped %d times", gossiped)
+	}
+
+	// Txs that are already pending aren't gossiped again
+	if err := vm.IssueGossipedTx(txBytes); err != nil {
+		t.Fatal(err)
+	}
+	if gossiped != 1 {
+		t.Fatalf("expected a pending tx not to be gossiped again")
+	}
+	assertTxStatus(t, service, tx.ID(), PendingTx)
+
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	blk.Accept()
+
+	// Decided txs can't be put back into the mempool by peers
+	if err := vm.IssueGossipedTx(txBytes); err != errDecidedTx {
+		t.Fatalf("expected %s but got %v", errDecidedTx, err)
+	}
+	if err := vm.IssueGossipedTx([]byte{1, 2, 3}); err == nil {
+		t.Fatalf("should have failed to parse the gossiped bytes")
+	}
+}
+
+func TestMempoolFull(t *testing.T) {
+	vm := initVM(t, memdb.New(), memdb.New(), defaultGenesisTime, make(chan common.Message, 1))
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	for i := 0; i < maxMempoolSize; i++ {
+		vm.pendingTxIDs.Add(ids.Empty.Prefix(uint64(i)))
+	}
+
+	tx, err := vm.newCreateSubnetTx(
+		testNetworkID,
+		defaultNonce+1,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		1,       // threshold
+		keys[0], // payer
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.issueTx(tx); err != errMempoolFull {
+		t.Fatalf("expected %s but got %v", errMempoolFull, err)
+	}
+
+	vm.removeUnissuedTx(ids.Empty.Prefix(0))
+	if _, err := vm.issueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// genesisCountingManager counts the chain genesis dry runs it's asked to do
+type genesisCountingManager struct {
+	chains.MockManager
+	validations int
+}
+
+func (m *genesisCountingManager) ValidateGenesis(ids.ID, []ids.ID, []byte) error {
+	m.validations++
+	return nil
+}
+
+func TestGossipedTxsAreVerified(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+	service := &Service{vm: vm}
+	manager := &genesisCountingManager{}
+	vm.chainManager = manager
+
+	sender := &common.SenderTest{T: t}
+	sender.Default(true)
+	vm.SetGossiper(sender)
+	gossiped := 0
+	sender.GossipF = func(ids.ID, []byte) { gossiped++ }
+
+	// The payer's nonce is wrong
+	invalidTx, err := vm.newCreateSubnetTx(
+		testNetworkID,
+		defaultNonce,
+		[]ids.ShortID{keys[0].PublicKey().Address()},
+		1,       // threshold
+		keys[0], // payer
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalidTxBytes, err := Codec.Marshal(genericTx{Tx: invalidTx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.IssueGossipedTx(invalidTxBytes); err == nil {
+		t.Fatal("should have failed because the tx is invalid")
+	}
+	if gossiped != 0 {
+		t.Fatal("an invalid tx shouldn't be gossiped")
+	}
+	assertTxStatus(t, service, invalidTx.ID(), UnknownTx)
+
+	validTx, err := vm.newCreateChainTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		nil,
+		timestampvm.ID,
+		nil,
+		"name",
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0],
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validTxBytes, err := Codec.Marshal(genericTx{Tx: validTx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.IssueGossipedTx(validTxBytes); err != nil {
+		t.Fatal(err)
+	}
+	if gossiped != 1 {
+		t.Fatalf("expected the valid tx to be gossiped once but it was gossiped %d times", gossiped)
+	}
+	if manager.validations != 0 {
+		t.Fatal("the genesis data of a gossiped chain shouldn't be dry run")
+	}
+	assertTxStatus(t, service, validTx.ID(), PendingTx)
+}
+
+func TestBuildBlockDropsOnlyInvalidTxs(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+	service := &Service{vm: vm}
+
+	newCreateSubnetTx := func(nonce uint64, controlKey ids.ShortID) *CreateSubnetTx {
+		tx, err := vm.newCreateSubnetTx(
+			testNetworkID,
+			nonce,
+			[]ids.ShortID{controlKey},
+			1,       // threshold
+			keys[0], // payer
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	// The payer's nonce is wrong
+	invalidTx := newCreateSubnetTx(defaultNonce, keys[0].PublicKey().Address())
+	validTx := newCreateSubnetTx(defaultNonce+1, keys[0].PublicKey().Address())
+	// Uses the same nonce as [validTx], so it's only valid on its own
+	conflictingTx := newCreateSubnetTx(defaultNonce+1, keys[1].PublicKey().Address())
+
+	for _, tx := range []*CreateSubnetTx{invalidTx, validTx, conflictingTx} {
+		if _, _, err := vm.addUnissuedTx(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if txs := blk.(*StandardBlock).Txs; len(txs) != 1 || !txs[0].ID().Equals(validTx.ID()) {
+		t.Fatalf("expected the block to contain only the valid tx but it has %d txs", len(txs))
+	}
+	assertTxStatus(t, service, validTx.ID(), ProcessingTx)
+	assertTxStatus(t, service, invalidTx.ID(), DroppedTx)
+	assertTxStatus(t, service, conflictingTx.ID(), DroppedTx)
+}
@@ -14,6 +14,8 @@ import (
 
 // ProposalTx is an operation that can be proposed
 type ProposalTx interface {
+	ID() ids.ID
+
 	initialize(vm *VM) error
 	// Attempts to verify this transaction with the provided state.
 	SemanticVerify(database.Database) (onCommitDB *versiondb.Database, onAbortDB *versiondb.Database, onCommitFunc func(), onAbortFunc func(), err error)
@@ -108,6 +110,12 @@ func (pb *ProposalBlock) Verify() error {
 	if err != nil {
 		return err
 	}
+	if err := pb.vm.putTxStatus(pb.onCommitDB, pb.Tx.ID(), CommittedTx); err != nil {
+		return err
+	}
+	if err := pb.vm.putTxStatus(pb.onAbortDB, pb.Tx.ID(), AbortedTx); err != nil {
+		return err
+	}
 
 	pb.vm.currentBlocks[pb.ID().Key()] = pb
 	parentIntf.addChild(pb)
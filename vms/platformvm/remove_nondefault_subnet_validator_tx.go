@@ -0,0 +1,210 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/hashing"
+)
+
+var (
+	errNotSubnetValidator = errors.New("node isn't a current or pending validator of the subnet")
+)
+
+// UnsignedRemoveNonDefaultSubnetValidatorTx is an unsigned
+// removeNonDefaultSubnetValidatorTx
+type UnsignedRemoveNonDefaultSubnetValidatorTx struct {
+	// ID of the network this tx was issued on
+	NetworkID uint32 `serialize:"true"`
+
+	// ID of the Subnet the validator is removed from
+	SubnetID ids.ID `serialize:"true"`
+
+	// ID of the node being removed
+	NodeID ids.ShortID `serialize:"true"`
+
+	// Next unused nonce of account paying the transaction fee for this transaction.
+	// The account's nonce is incremented when this transaction is accepted, so
+	// the transaction can't be replayed.
+	Nonce uint64 `serialize:"true"`
+}
+
+// removeNonDefaultSubnetValidatorTx removes a validator from the current and
+// pending validators of a Subnet before the end of its validation period. It
+// must be signed by the Subnet's current threshold of control keys.
+type removeNonDefaultSubnetValidatorTx struct {
+	UnsignedRemoveNonDefaultSubnetValidatorTx `serialize:"true"`
+
+	// Address of the account that provides the transaction fee
+	// Set in SyntacticVerify
+	PayerAddress ids.ShortID
+
+	// Signatures from the Subnet's control keys
+	ControlSigs [][crypto.SECP256K1RSigLen]byte `serialize:"true"`
+
+	// Signature of key whose account provides the transaction fee
+	PayerSig [crypto.SECP256K1RSigLen]byte `serialize:"true"`
+
+	vm    *VM
+	id    ids.ID
+	bytes []byte
+}
+
+func (tx *removeNonDefaultSubnetValidatorTx) initialize(vm *VM) error {
+	tx.vm = vm
+	txBytes, err := Codec.Marshal(tx) // byte repr. of the signed tx
+	tx.bytes = txBytes
+	tx.id = ids.NewID(hashing.ComputeHash256Array(txBytes))
+	return err
+}
+
+// ID of this transaction
+func (tx *removeNonDefaultSubnetValidatorTx) ID() ids.ID { return tx.id }
+
+// Bytes returns the byte representation of this transaction
+func (tx *removeNonDefaultSubnetValidatorTx) Bytes() []byte { return tx.bytes }
+
+// SyntacticVerify this transaction is well-formed
+// Also populates [tx.PayerAddress] with the address of the key that pays the
+// transaction fee
+func (tx *removeNonDefaultSubnetValidatorTx) SyntacticVerify() error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case !tx.PayerAddress.IsZero(): // Only verify the transaction once
+		return nil
+	case tx.NetworkID != tx.vm.Ctx.NetworkID: // verify the transaction is on this network
+		return errWrongNetworkID
+	case tx.id.IsZero():
+		return errInvalidID
+	case tx.SubnetID.Equals(DefaultSubnetID):
+		return errDSCantValidate
+	case tx.NodeID.IsZero():
+		return errInvalidID
+	case !crypto.IsSortedAndUniqueSECP2561RSigs(tx.ControlSigs):
+		return errControlSigsNotSortedAndUnique
+	}
+
+	unsignedIntf := interface{}(&tx.UnsignedRemoveNonDefaultSubnetValidatorTx)
+	unsignedBytes, err := Codec.Marshal(&unsignedIntf) // byte repr of unsigned tx
+	if err != nil {
+		return err
+	}
+
+	payerKey, err := tx.vm.factory.RecoverPublicKey(unsignedBytes, tx.PayerSig[:])
+	if err != nil {
+		return err
+	}
+	tx.PayerAddress = payerKey.Address()
+
+	return nil
+}
+
+// SemanticVerify this transaction is valid.
+func (tx *removeNonDefaultSubnetValidatorTx) SemanticVerify(db database.Database) (func(), error) {
+	if err := tx.SyntacticVerify(); err != nil {
+		return nil, err
+	}
+
+	unsignedIntf := interface{}(&tx.UnsignedRemoveNonDefaultSubnetValidatorTx)
+	unsignedBytes, err := Codec.Marshal(&unsignedIntf) // Byte representation of the unsigned transaction
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.vm.verifyControlSigs(db, tx.SubnetID, unsignedBytes, tx.ControlSigs); err != nil {
+		return nil, err
+	}
+
+	// Remove the validator from the subnet's current and pending validators
+	currentValidators, err := tx.vm.getCurrentValidators(db, tx.SubnetID)
+	if err != nil {
+		return nil, errDBCurrentValidators
+	}
+	removedCurrent := currentValidators.removeValidator(tx.NodeID)
+
+	pendingValidators, err := tx.vm.getPendingValidators(db, tx.SubnetID)
+	if err != nil {
+		return nil, errDBPendingValidators
+	}
+	removedPending := pendingValidators.removeValidator(tx.NodeID)
+
+	if !removedCurrent && !removedPending {
+		return nil, errNotSubnetValidator
+	}
+	if err := tx.vm.putCurrentValidators(db, currentValidators, tx.SubnetID); err != nil {
+		return nil, err
+	}
+	if err := tx.vm.putPendingValidators(db, pendingValidators, tx.SubnetID); err != nil {
+		return nil, err
+	}
+
+	// Deduct tx fee from payer's account
+	account, err := tx.vm.getAccount(db, tx.PayerAddress)
+	if err != nil {
+		return nil, err
+	}
+	account, err = account.Remove(0, tx.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.vm.putAccount(db, account); err != nil {
+		return nil, err
+	}
+
+	// Stop treating the node as a validator of the subnet
+	onAccept := func() {
+		if err := tx.vm.updateValidators(tx.SubnetID); err != nil {
+			tx.vm.Ctx.Log.Error("failed to update validators of subnet %s: %s", tx.SubnetID, err)
+		}
+	}
+	return onAccept, nil
+}
+
+// [controlKeys] are the Subnet's current control keys that sign this tx.
+func (vm *VM) newRemoveNonDefaultSubnetValidatorTx(nonce uint64, subnetID ids.ID, nodeID ids.ShortID,
+	networkID uint32, controlKeys []*crypto.PrivateKeySECP256K1R, payerKey *crypto.PrivateKeySECP256K1R,
+) (*removeNonDefaultSubnetValidatorTx, error) {
+	tx := &removeNonDefaultSubnetValidatorTx{
+		UnsignedRemoveNonDefaultSubnetValidatorTx: UnsignedRemoveNonDefaultSubnetValidatorTx{
+			NetworkID: networkID,
+			SubnetID:  subnetID,
+			NodeID:    nodeID,
+			Nonce:     nonce,
+		},
+	}
+
+	// Generate byte repr. of unsigned transaction
+	unsignedIntf := interface{}(&tx.UnsignedRemoveNonDefaultSubnetValidatorTx)
+	unsignedBytes, err := Codec.Marshal(&unsignedIntf)
+	if err != nil {
+		return nil, err
+	}
+	unsignedBytesHash := hashing.ComputeHash256(unsignedBytes)
+
+	// Sign the tx with the control keys
+	tx.ControlSigs = make([][crypto.SECP256K1RSigLen]byte, len(controlKeys))
+	for i, key := range controlKeys {
+		sig, err := key.SignHash(unsignedBytesHash)
+		if err != nil {
+			return nil, err
+		}
+		copy(tx.ControlSigs[i][:], sig)
+	}
+
+	// Sort the control signatures
+	crypto.SortSECP2561RSigs(tx.ControlSigs)
+
+	// Sign with the payer key
+	payerSig, err := payerKey.Sign(unsignedBytes)
+	if err != nil {
+		return nil, err
+	}
+	copy(tx.PayerSig[:], payerSig)
+
+	return tx, tx.initialize(vm)
+}
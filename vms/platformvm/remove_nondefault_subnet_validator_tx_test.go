@@ -0,0 +1,334 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/database/versiondb"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+)
+
+// test method SyntacticVerify
+func TestRemoveNonDefaultSubnetValidatorTxSyntacticVerify(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[0].PublicKey().Address()
+	signers := []*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]}
+
+	// Case 1: tx is nil
+	var tx *removeNonDefaultSubnetValidatorTx
+	if err := tx.SyntacticVerify(); err == nil {
+		t.Fatal("should have failed because tx is nil")
+	}
+
+	// Case 2: ID is nil
+	tx, err := vm.newRemoveNonDefaultSubnetValidatorTx(defaultNonce+1, testSubnet1.id, nodeID, testNetworkID, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.id = ids.ID{}
+	if err := tx.SyntacticVerify(); err == nil {
+		t.Fatal("should have failed because ID is nil")
+	}
+
+	// Case 3: network ID is wrong
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(defaultNonce+1, testSubnet1.id, nodeID, testNetworkID+1, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(); err == nil {
+		t.Fatal("should've errored because network ID is wrong")
+	}
+
+	// Case 4: validators can't be removed from the default subnet
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(defaultNonce+1, DefaultSubnetID, nodeID, testNetworkID, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(); err != errDSCantValidate {
+		t.Fatalf("should've errored with %s but got %v", errDSCantValidate, err)
+	}
+
+	// Case 5: node ID is empty
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(defaultNonce+1, testSubnet1.id, nodeID, testNetworkID, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.NodeID = ids.ShortID{}
+	if err := tx.SyntacticVerify(); err == nil {
+		t.Fatal("should've errored because node ID is empty")
+	}
+
+	// Case 6: control signatures aren't sorted
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(defaultNonce+1, testSubnet1.id, nodeID, testNetworkID, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.ControlSigs[0], tx.ControlSigs[1] = tx.ControlSigs[1], tx.ControlSigs[0]
+	if err := tx.SyntacticVerify(); err != errControlSigsNotSortedAndUnique {
+		t.Fatalf("should've errored with %s but got %v", errControlSigsNotSortedAndUnique, err)
+	}
+
+	// Case 7: valid
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(defaultNonce+1, testSubnet1.id, nodeID, testNetworkID, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(); err != nil {
+		t.Fatal(err)
+	}
+	if !tx.PayerAddress.Equals(defaultKey.PublicKey().Address()) {
+		t.Fatalf("expected payer %s but got %s", defaultKey.PublicKey().Address(), tx.PayerAddress)
+	}
+}
+
+// test method SemanticVerify
+func TestRemoveNonDefaultSubnetValidatorTxSemanticVerify(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[0].PublicKey().Address()
+	addPendingSubnetValidator(t, vm, nodeID)
+
+	// Case 1: too few control signatures
+	tx, err := vm.newRemoveNonDefaultSubnetValidatorTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		nodeID,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.SemanticVerify(versiondb.New(vm.DB)); err == nil {
+		t.Fatal("should have failed because there are too few control signatures")
+	}
+
+	// Case 2: signed by keys that don't control the subnet
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		nodeID,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{keys[3], keys[4]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.SemanticVerify(versiondb.New(vm.DB)); err != errUnknownControlKey {
+		t.Fatalf("should have failed with %s but got %v", errUnknownControlKey, err)
+	}
+
+	// Case 3: one control key signs twice, with different nonces, to meet
+	// the threshold of 2
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		nodeID,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsignedIntf := interface{}(&tx.UnsignedRemoveNonDefaultSubnetValidatorTx)
+	unsignedBytes, err := Codec.Marshal(&unsignedIntf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.ControlSigs = [][crypto.SECP256K1RSigLen]byte{
+		signWithNonce(t, testSubnet1ControlKeys[0], unsignedBytes, 1),
+		signWithNonce(t, testSubnet1ControlKeys[0], unsignedBytes, 2),
+	}
+	crypto.SortSECP2561RSigs(tx.ControlSigs)
+	if _, err := tx.SemanticVerify(versiondb.New(vm.DB)); err != errDuplicateControlSig {
+		t.Fatalf("should have failed with %s but got %v", errDuplicateControlSig, err)
+	}
+
+	// Case 4: subnet doesn't exist
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(
+		defaultNonce+1,
+		ids.NewID([32]byte{1}),
+		nodeID,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.SemanticVerify(versiondb.New(vm.DB)); err == nil {
+		t.Fatal("should have failed because the subnet doesn't exist")
+	}
+
+	// Case 5: node isn't a validator of the subnet
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		keys[1].PublicKey().Address(),
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.SemanticVerify(versiondb.New(vm.DB)); err != errNotSubnetValidator {
+		t.Fatalf("should have failed with %s but got %v", errNotSubnetValidator, err)
+	}
+
+	// Case 6: wrong nonce
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(
+		defaultNonce,
+		testSubnet1.id,
+		nodeID,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.SemanticVerify(versiondb.New(vm.DB)); err == nil {
+		t.Fatal("should have failed because the nonce is wrong")
+	}
+
+	// Case 7: valid; the validator is removed from the pending validators
+	tx, err = vm.newRemoveNonDefaultSubnetValidatorTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		nodeID,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := versiondb.New(vm.DB)
+	if _, err := tx.SemanticVerify(db); err != nil {
+		t.Fatal(err)
+	}
+	pendingValidators, err := vm.getPendingValidators(db, testSubnet1.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pendingValidators.Len() != 0 {
+		t.Fatalf("expected no pending validators but got %d", pendingValidators.Len())
+	}
+}
+
+// test that removing a current subnet validator takes it out of the subnet's
+// validator set once the tx is accepted
+func TestRemoveNonDefaultSubnetValidator(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	nodeID := keys[0].PublicKey().Address()
+	addTx, err := vm.newAddNonDefaultSubnetValidatorTx(
+		defaultNonce+1,
+		defaultWeight,
+		uint64(defaultValidateStartTime.Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		nodeID,
+		testSubnet1.id,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	currentValidators := &EventHeap{SortByStartTime: false}
+	currentValidators.Add(addTx)
+	if err := vm.putCurrentValidators(vm.DB, currentValidators, testSubnet1.id); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.updateValidators(testSubnet1.id); err != nil {
+		t.Fatal(err)
+	}
+	validatorSet, ok := vm.validators.GetValidatorSet(testSubnet1.id)
+	if !ok || !validatorSet.Contains(nodeID) {
+		t.Fatal("expected the node to be a validator of the subnet")
+	}
+
+	tx, err := vm.newRemoveNonDefaultSubnetValidatorTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		nodeID,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[1],
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm.unissuedDecisionTxs = append(vm.unissuedDecisionTxs, tx)
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	blk.Accept()
+
+	if validatorSet.Contains(nodeID) {
+		t.Fatal("expected the node to have been removed from the subnet's validator set")
+	}
+	currentValidators, err = vm.getCurrentValidators(vm.DB, testSubnet1.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if currentValidators.Len() != 0 {
+		t.Fatalf("expected no current validators but got %d", currentValidators.Len())
+	}
+}
+
+// addPendingSubnetValidator puts a tx that adds [nodeID] as a validator of
+// testSubnet1 into the subnet's pending validators
+func addPendingSubnetValidator(t *testing.T, vm *VM, nodeID ids.ShortID) {
+	tx, err := vm.newAddNonDefaultSubnetValidatorTx(
+		defaultNonce+1,
+		defaultWeight,
+		uint64(defaultValidateStartTime.Unix())+1,
+		uint64(defaultValidateEndTime.Unix()),
+		nodeID,
+		testSubnet1.id,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pendingValidators, err := vm.getPendingValidators(vm.DB, testSubnet1.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pendingValidators.Add(tx)
+	if err := vm.putPendingValidators(vm.DB, pendingValidators, testSubnet1.id); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -4,22 +4,108 @@
 package platformvm
 
 import (
-	"math"
+	"math/big"
 	"time"
+
+	"github.com/ava-labs/gecko/utils/math"
+)
+
+const (
+	// rewardPrecision is the number of fractional bits used by the fixed-point
+	// arithmetic in the reward calculation
+	rewardPrecision = 192
+
+	// 2^-rewardRoundingBits is added to the value of the stake before it is
+	// truncated. This absorbs the error of the fixed-point arithmetic, so that a
+	// value that is exactly an integer, such as a year of staking at 4%, isn't
+	// truncated to one less.
+	rewardRoundingBits = 64
+
+	// rewardYear is the length of the period over which the inflation rate is
+	// applied
+	rewardYear = 365 * 24 * time.Hour
 )
 
-// reward returns the amount of $AVA to reward the staker with
-func reward(duration time.Duration, amount uint64, inflationRate float64) uint64 {
-	// TODO: Can't use floats here. Need to figure out how to do some integer
-	// approximations
+// reward returns the amount of $AVA to reward the staker with.
+//
+// The staked amount compounds continuously at a rate of
+// [rateNumerator]/[rateDenominator] per year, so the reward is
+// amount * (rateNumerator/rateDenominator)^(duration/year) - amount, rounded
+// down. The calculation uses only integer arithmetic so that every node
+// computes the same result.
+func reward(duration time.Duration, amount, rateNumerator, rateDenominator uint64) uint64 {
+	if duration <= 0 || amount == 0 || rateDenominator == 0 || rateNumerator <= rateDenominator {
+		return 0
+	}
+
+	// exponent = ln(rate) * duration / year
+	exponent := fixedLn(rateNumerator, rateDenominator)
+	exponent.Mul(exponent, big.NewInt(int64(duration)))
+	exponent.Quo(exponent, big.NewInt(int64(rewardYear)))
 
-	years := duration.Hours() / (365. * 24.)
+	// value = amount * e^exponent
+	value := fixedExp(exponent)
+	value.Mul(value, new(big.Int).SetUint64(amount))
+	value.Add(value, new(big.Int).Lsh(big.NewInt(1), rewardPrecision-rewardRoundingBits))
+	value.Rsh(value, rewardPrecision)
+	if !value.IsUint64() {
+		// The value of the stake can't be represented, so neither can the
+		// reward
+		return 0
+	}
 
-	// Total value of this transaction
-	value := float64(amount) * math.Pow(inflationRate, years)
+	// value >= amount because the rate is > 1, so this never underflows
+	reward, err := math.Sub64(value.Uint64(), amount)
+	if err != nil {
+		return 0
+	}
+	return reward
+}
+
+// fixedLn returns ln(numerator/denominator) as a fixed-point number with
+// [rewardPrecision] fractional bits. Assumes numerator > denominator > 0.
+//
+// Uses the series ln(x) = 2 * sum_{k odd} y^k / k, where y = (x-1)/(x+1).
+func fixedLn(numerator, denominator uint64) *big.Int {
+	num := new(big.Int).SetUint64(numerator)
+	denom := new(big.Int).SetUint64(denominator)
+
+	// y = (numerator - denominator) / (numerator + denominator)
+	y := new(big.Int).Sub(num, denom)
+	y.Lsh(y, rewardPrecision)
+	y.Quo(y, new(big.Int).Add(num, denom))
 
-	// Amount of the reward in $AVA
-	reward := value - float64(amount)
+	ySquared := new(big.Int).Mul(y, y)
+	ySquared.Rsh(ySquared, rewardPrecision)
+
+	sum := new(big.Int)
+	power := new(big.Int).Set(y) // y^k
+	term := new(big.Int)
+	for k := int64(1); power.Sign() > 0; k += 2 {
+		term.Quo(power, big.NewInt(k))
+		sum.Add(sum, term)
+
+		power.Mul(power, ySquared)
+		power.Rsh(power, rewardPrecision)
+	}
+	return sum.Lsh(sum, 1)
+}
 
-	return uint64(reward)
+// fixedExp returns e^[exponent], where [exponent] and the result are
+// fixed-point numbers with [rewardPrecision] fractional bits. Assumes
+// exponent >= 0.
+//
+// Uses the series e^x = sum_k x^k / k!
+func fixedExp(exponent *big.Int) *big.Int {
+	sum := new(big.Int).Lsh(big.NewInt(1), rewardPrecision)
+	term := new(big.Int).Set(sum) // x^k / k!
+	for k := int64(1); ; k++ {
+		term.Mul(term, exponent)
+		term.Rsh(term, rewardPrecision)
+		term.Quo(term, big.NewInt(k))
+		if term.Sign() == 0 {
+			return sum
+		}
+		sum.Add(sum, term)
+	}
 }
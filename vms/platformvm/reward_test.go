@@ -0,0 +1,106 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+const (
+	day  = 24 * time.Hour
+	year = 365 * day
+
+	// maxLegacyRewardDeviation bounds how far, relative to the staked amount,
+	// reward may differ from the float64 calculation it replaced. The float64
+	// calculation had 53 bits of precision, so its own error is of this order.
+	maxLegacyRewardDeviation = 1e-14
+)
+
+func TestReward(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		amount   uint64
+		expected uint64
+	}{
+		{duration: 0, amount: 1000, expected: 0},
+		{duration: day, amount: 0, expected: 0},
+		{duration: -day, amount: 1000, expected: 0},
+		{duration: day, amount: 10000, expected: 1},
+		{duration: day, amount: 1000000000000000, expected: 107459782027},
+		{duration: 30 * day, amount: 123456789, expected: 398619},
+		{duration: 182 * day, amount: 5000000000000, expected: 98745565908},
+		{duration: year, amount: 1000000000, expected: 40000000},
+		{duration: year, amount: 360000000000000000, expected: 14400000000000000},
+		{duration: 2 * year, amount: 1000000000, expected: 81600000},
+		{duration: 200 * year, amount: math.MaxUint64, expected: 0},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s-%d", test.duration, test.amount), func(t *testing.T) {
+			if reward := reward(test.duration, test.amount, InflationRateNumerator, InflationRateDenominator); reward != test.expected {
+				t.Fatalf("expected reward %d but got %d", test.expected, reward)
+			}
+		})
+	}
+}
+
+func TestRewardNoInflation(t *testing.T) {
+	tests := []struct {
+		numerator   uint64
+		denominator uint64
+	}{
+		{numerator: 100, denominator: 100},
+		{numerator: 99, denominator: 100},
+		{numerator: 104, denominator: 0},
+	}
+	for _, test := range tests {
+		if reward := reward(year, 1000000000, test.numerator, test.denominator); reward != 0 {
+			t.Fatalf("expected no reward with rate %d/%d but got %d", test.numerator, test.denominator, reward)
+		}
+	}
+}
+
+// legacyReward is the float64 calculation that reward replaced
+func legacyReward(duration time.Duration, amount uint64, inflationRate float64) uint64 {
+	years := duration.Hours() / (365. * 24.)
+	value := float64(amount) * math.Pow(inflationRate, years)
+	return uint64(value - float64(amount))
+}
+
+// TestRewardMatchesLegacy guards against the reward of any staker that could
+// exist changing by more than the float64 calculation's own error
+func TestRewardMatchesLegacy(t *testing.T) {
+	durations := []time.Duration{
+		MinimumStakingDuration,
+		MinimumStakingDuration + time.Second,
+		7 * day,
+		30 * day,
+		90*day + 12*time.Hour,
+		180 * day,
+		364 * day,
+		MaximumStakingDuration,
+	}
+	amounts := []uint64{
+		MinimumStakeAmount,
+		MinimumStakeAmount + 1,
+		123456789012,
+		defaultBalance,
+		45000000000000000,
+		360000000000000000,
+	}
+	for _, duration := range durations {
+		for _, amount := range amounts {
+			expected := legacyReward(duration, amount, 1.04)
+			reward := reward(duration, amount, InflationRateNumerator, InflationRateDenominator)
+
+			diff := math.Abs(float64(reward) - float64(expected))
+			if bound := math.Max(1, float64(amount)*maxLegacyRewardDeviation); diff > bound {
+				t.Fatalf("reward for staking %d for %s is %d, which differs from the legacy reward %d by more than %f",
+					amount, duration, reward, expected, bound)
+			}
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/database/versiondb"
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/hashing"
 	"github.com/ava-labs/gecko/utils/math"
 )
 
@@ -33,13 +34,19 @@ type rewardValidatorTx struct {
 	TxID ids.ID `serialize:"true"`
 
 	vm *VM
+	id ids.ID
 }
 
 func (tx *rewardValidatorTx) initialize(vm *VM) error {
 	tx.vm = vm
-	return nil
+	txBytes, err := Codec.Marshal(tx) // byte repr. of the tx
+	tx.id = ids.NewID(hashing.ComputeHash256Array(txBytes))
+	return err
 }
 
+// ID returns the ID of this tx
+func (tx *rewardValidatorTx) ID() ids.ID { return tx.id }
+
 // SyntacticVerify that this transaction is well formed
 func (tx *rewardValidatorTx) SyntacticVerify() error {
 	switch {
@@ -109,11 +116,14 @@ func (tx *rewardValidatorTx) SemanticVerify(db database.Database) (*versiondb.Da
 		return nil, nil, nil, nil, errDBPutCurrentValidators
 	}
 
+	// The amount of $AVA minted if this tx's proposal is committed
+	minted := uint64(0)
+
 	switch vdrTx := vdrTx.(type) {
 	case *addDefaultSubnetValidatorTx:
 		duration := vdrTx.Duration()
 		amount := vdrTx.Wght
-		reward := reward(duration, amount, InflationRate)
+		reward := reward(duration, amount, InflationRateNumerator, InflationRateDenominator)
 		amountWithReward, err := math.Add64(amount, reward)
 		if err != nil {
 			amountWithReward = amount
@@ -149,6 +159,7 @@ func (tx *rewardValidatorTx) SemanticVerify(db database.Database) (*versiondb.Da
 		if err := tx.vm.putAccount(onAbortDB, accountNoReward); err != nil {
 			return nil, nil, nil, nil, errDBPutAccount
 		}
+		minted = accountWithReward.Balance - accountNoReward.Balance
 	case *addDefaultSubnetDelegatorTx:
 		parentTx, err := currentEvents.getDefaultSubnetStaker(vdrTx.NodeID)
 		if err != nil {
@@ -157,7 +168,7 @@ func (tx *rewardValidatorTx) SemanticVerify(db database.Database) (*versiondb.Da
 
 		duration := vdrTx.Duration()
 		amount := vdrTx.Wght
-		reward := reward(duration, amount, InflationRate)
+		reward := reward(duration, amount, InflationRateNumerator, InflationRateDenominator)
 
 		// Because parentTx.Shares <= NumberOfShares this will never underflow
 		delegatorShares := NumberOfShares - uint64(parentTx.Shares)
@@ -227,10 +238,17 @@ func (tx *rewardValidatorTx) SemanticVerify(db database.Database) (*versiondb.Da
 		if err := tx.vm.putAccount(onCommitDB, validatorAccountWithReward); err != nil {
 			return nil, nil, nil, nil, errDBPutAccount
 		}
+		minted = delegatorAccountWithReward.Balance - delegatorAccountNoReward.Balance +
+			validatorAccountWithReward.Balance - validatorAccount.Balance
 	default:
 		return nil, nil, nil, nil, errShouldBeDSValidator
 	}
 
+	// The reward is newly minted $AVA
+	if err := tx.vm.increaseCurrentSupply(onCommitDB, minted); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
 	// Regardless of whether this tx is committed or aborted, update the
 	// validator set to remove the staker. onAbortDB or onCommitDB should commit
 	// (flush to vm.DB) before this is called
@@ -238,19 +256,54 @@ func (tx *rewardValidatorTx) SemanticVerify(db database.Database) (*versiondb.Da
 		if err := tx.vm.updateValidators(DefaultSubnetID); err != nil {
 			tx.vm.Ctx.Log.Fatal("failed to update validators on the default subnet: %s", err)
 		}
+		if err := tx.vm.flushUptimes(); err != nil {
+			tx.vm.Ctx.Log.Error("failed to persist validator uptimes: %s", err)
+		}
 	}
 
 	return onCommitDB, onAbortDB, updateValidators, updateValidators, nil
 }
 
-// InitiallyPrefersCommit returns true.
-//
-// Right now, *Commit (that is, remove the validator and reward them) is always
-// preferred over *Abort (remove the validator but don't reward them.)
+// InitiallyPrefersCommit returns true if the validator being removed was
+// connected to this node for at least [vm.uptimeRequirement] of its staking
+// period. If a delegator is being removed, the uptime of the validator it
+// delegated to is used.
 //
-// TODO: A validator should receive a reward only if they are sufficiently
-// responsive and correct during the time they are validating.
-func (tx *rewardValidatorTx) InitiallyPrefersCommit() bool { return true }
+// That is, *Commit (remove the staker and reward them) is preferred over *Abort
+// (remove the staker but don't reward them) only if the validator was
+// sufficiently responsive.
+func (tx *rewardValidatorTx) InitiallyPrefersCommit() bool {
+	currentValidators, err := tx.vm.getCurrentValidators(tx.vm.DB, DefaultSubnetID)
+	if err != nil {
+		tx.vm.Ctx.Log.Error("failed to get the current validators: %s", err)
+		return true
+	}
+
+	var vdrTx *addDefaultSubnetValidatorTx
+	for _, staker := range currentValidators.Txs {
+		if !staker.ID().Equals(tx.TxID) {
+			continue
+		}
+		switch staker := staker.(type) {
+		case *addDefaultSubnetValidatorTx:
+			vdrTx = staker
+		case *addDefaultSubnetDelegatorTx:
+			vdrTx, err = currentValidators.getDefaultSubnetStaker(staker.NodeID)
+		}
+		break
+	}
+	if vdrTx == nil {
+		tx.vm.Ctx.Log.Warn("couldn't find the validator of staker %s: %v", tx.TxID, err)
+		return true
+	}
+
+	meetsRequirement, err := tx.vm.meetsUptimeRequirement(vdrTx)
+	if err != nil {
+		tx.vm.Ctx.Log.Error("failed to calculate the uptime of %s: %s", vdrTx.NodeID, err)
+		return true
+	}
+	return meetsRequirement
+}
 
 // RewardStakerTx creates a new transaction that proposes to remove the staker
 // [validatorID] from the default validator set.
@@ -8,10 +8,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/choices"
 	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/utils/hashing"
@@ -56,8 +58,8 @@ type APISubnet struct {
 	// Each element of [ControlKeys] the address of a public key.
 	// A transaction to add a validator to this subnet requires
 	// signatures from [Threshold] of these keys to be valid.
-	ControlKeys []ids.ShortID `json:"controlKeys"`
-	Threshold   json.Uint16   `json:"threshold"`
+	ControlKeys []string    `json:"controlKeys"`
+	Threshold   json.Uint16 `json:"threshold"`
 }
 
 // GetSubnetsArgs are the arguments to GetSubnet
@@ -87,10 +89,14 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 	if getAll {
 		response.Subnets = make([]APISubnet, len(subnets))
 		for i, subnet := range subnets {
+			control, err := service.vm.getSubnetControl(service.vm.DB, subnet)
+			if err != nil {
+				return fmt.Errorf("error getting control keys of subnet %s: %w", subnet.id, err)
+			}
 			response.Subnets[i] = APISubnet{
 				ID:          subnet.id,
-				ControlKeys: subnet.ControlKeys,
-				Threshold:   json.Uint16(subnet.Threshold),
+				ControlKeys: service.vm.formatAddresses(control.ControlKeys),
+				Threshold:   json.Uint16(control.Threshold),
 			}
 		}
 		return nil
@@ -100,11 +106,15 @@ func (service *Service) GetSubnets(_ *http.Request, args *GetSubnetsArgs, respon
 	idsSet.Add(args.IDs...)
 	for _, subnet := range subnets {
 		if idsSet.Contains(subnet.id) {
+			control, err := service.vm.getSubnetControl(service.vm.DB, subnet)
+			if err != nil {
+				return fmt.Errorf("error getting control keys of subnet %s: %w", subnet.id, err)
+			}
 			response.Subnets = append(response.Subnets,
 				APISubnet{
 					ID:          subnet.id,
-					ControlKeys: subnet.ControlKeys,
-					Threshold:   json.Uint16(subnet.Threshold),
+					ControlKeys: service.vm.formatAddresses(control.ControlKeys),
+					Threshold:   json.Uint16(control.Threshold),
 				},
 			)
 		}
@@ -125,9 +135,25 @@ type GetCurrentValidatorsArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
 }
 
+// APICurrentValidator is a staker returned by GetCurrentValidators
+type APICurrentValidator struct {
+	APIValidator
+
+	// Set only for validators of the default subnet. The number of shares, out
+	// of NumberOfShares, of its delegators' rewards that the validator receives,
+	// and how much more stake can be delegated to it for the rest of its
+	// staking period.
+	DelegationFeeRate  *json.Uint32 `json:"delegationFeeRate,omitempty"`
+	DelegationCapacity *json.Uint64 `json:"delegationCapacity,omitempty"`
+
+	// Set only for stakers of the default subnet. The address the staked $AVA
+	// (and, if applicable, reward) is sent to when the staker is done staking.
+	Destination string `json:"destination,omitempty"`
+}
+
 // GetCurrentValidatorsReply are the results from calling GetCurrentValidators
 type GetCurrentValidatorsReply struct {
-	Validators []APIValidator `json:"validators"`
+	Validators []APICurrentValidator `json:"validators"`
 }
 
 // GetCurrentValidators returns the list of current validators
@@ -143,24 +169,54 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 		return fmt.Errorf("couldn't get validators of subnet with ID %s. Does it exist?", args.SubnetID)
 	}
 
-	reply.Validators = make([]APIValidator, validators.Len())
+	var pendingValidators *EventHeap
+	var currentTime time.Time
+	if args.SubnetID.Equals(DefaultSubnetID) {
+		if pendingValidators, err = service.vm.getPendingValidators(service.vm.DB, DefaultSubnetID); err != nil {
+			return fmt.Errorf("couldn't get pending validators of the default subnet: %w", err)
+		}
+		if currentTime, err = service.vm.getTimestamp(service.vm.DB); err != nil {
+			return fmt.Errorf("couldn't get the current chain time: %w", err)
+		}
+	}
+
+	reply.Validators = make([]APICurrentValidator, validators.Len())
 	for i, tx := range validators.Txs {
 		vdr := tx.Vdr()
 		weight := json.Uint64(vdr.Weight())
 		if args.SubnetID.Equals(DefaultSubnetID) {
-			reply.Validators[i] = APIValidator{
+			reply.Validators[i] = APICurrentValidator{APIValidator: APIValidator{
 				ID:          vdr.ID(),
 				StartTime:   json.Uint64(tx.StartTime().Unix()),
 				EndTime:     json.Uint64(tx.EndTime().Unix()),
 				StakeAmount: &weight,
+			}}
+			if destination, ok := stakerDestination(tx); ok {
+				reply.Validators[i].Destination = service.vm.formatAddress(destination)
 			}
+
+			validator, ok := tx.(*addDefaultSubnetValidatorTx)
+			if !ok { // This staker is a delegator
+				continue
+			}
+			delegatedWeight, err := maxDelegatedWeight(validator.NodeID, currentTime, validator.EndTime(), validators, pendingValidators)
+			if err != nil {
+				return fmt.Errorf("couldn't calculate the stake delegated to %s: %w", validator.NodeID, err)
+			}
+			capacity := json.Uint64(0)
+			if maxWeight := maxDelegationWeight(validator); delegatedWeight < maxWeight {
+				capacity = json.Uint64(maxWeight - delegatedWeight)
+			}
+			feeRate := json.Uint32(validator.Shares)
+			reply.Validators[i].DelegationFeeRate = &feeRate
+			reply.Validators[i].DelegationCapacity = &capacity
 		} else {
-			reply.Validators[i] = APIValidator{
+			reply.Validators[i] = APICurrentValidator{APIValidator: APIValidator{
 				ID:        vdr.ID(),
 				StartTime: json.Uint64(tx.StartTime().Unix()),
 				EndTime:   json.Uint64(tx.EndTime().Unix()),
 				Weight:    &weight,
-			}
+			}}
 		}
 	}
 
@@ -174,9 +230,18 @@ type GetPendingValidatorsArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
 }
 
+// APIPendingValidator is a staker returned by GetPendingValidators
+type APIPendingValidator struct {
+	APIValidator
+
+	// Set only for stakers of the default subnet. The address the staked $AVA
+	// (and, if applicable, reward) is sent to when the staker is done staking.
+	Destination string `json:"destination,omitempty"`
+}
+
 // GetPendingValidatorsReply are the results from calling GetPendingValidators
 type GetPendingValidatorsReply struct {
-	Validators []APIValidator `json:"validators"`
+	Validators []APIPendingValidator `json:"validators"`
 }
 
 // GetPendingValidators returns the list of current validators
@@ -192,30 +257,229 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 		return fmt.Errorf("couldn't get validators of subnet with ID %s. Does it exist?", args.SubnetID)
 	}
 
-	reply.Validators = make([]APIValidator, validators.Len())
+	reply.Validators = make([]APIPendingValidator, validators.Len())
 	for i, tx := range validators.Txs {
 		vdr := tx.Vdr()
 		weight := json.Uint64(vdr.Weight())
 		if args.SubnetID.Equals(DefaultSubnetID) {
-			reply.Validators[i] = APIValidator{
+			reply.Validators[i] = APIPendingValidator{APIValidator: APIValidator{
 				ID:          vdr.ID(),
 				StartTime:   json.Uint64(tx.StartTime().Unix()),
 				EndTime:     json.Uint64(tx.EndTime().Unix()),
 				StakeAmount: &weight,
+			}}
+			if destination, ok := stakerDestination(tx); ok {
+				reply.Validators[i].Destination = service.vm.formatAddress(destination)
 			}
 		} else {
-			reply.Validators[i] = APIValidator{
+			reply.Validators[i] = APIPendingValidator{APIValidator: APIValidator{
 				ID:        vdr.ID(),
 				StartTime: json.Uint64(tx.StartTime().Unix()),
 				EndTime:   json.Uint64(tx.EndTime().Unix()),
 				Weight:    &weight,
-			}
+			}}
 		}
 	}
 
 	return nil
 }
 
+/*
+ ******************************************************
+ ****************** Supply and Stake ******************
+ ******************************************************
+ */
+
+// GetCurrentSupplyReply are the results from calling GetCurrentSupply
+type GetCurrentSupplyReply struct {
+	Supply json.Uint64 `json:"supply"`
+}
+
+// GetCurrentSupply returns the amount of $AVA in existence: the $AVA that
+// existed on the Platform Chain at genesis plus the staking rewards that have
+// been minted since
+func (service *Service) GetCurrentSupply(_ *http.Request, _ *struct{}, reply *GetCurrentSupplyReply) error {
+	service.vm.Ctx.Log.Debug("GetCurrentSupply called")
+
+	supply, err := service.vm.getCurrentSupply(service.vm.DB)
+	if err != nil {
+		return fmt.Errorf("couldn't get the current supply: %w", err)
+	}
+	reply.Supply = json.Uint64(supply)
+	return nil
+}
+
+// GetTotalStakeArgs are the arguments for calling GetTotalStake
+type GetTotalStakeArgs struct {
+	// Subnet we're getting the total stake of
+	// If omitted, defaults to default subnet
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// GetTotalStakeReply are the results from calling GetTotalStake
+type GetTotalStakeReply struct {
+	// Total weight of the current validators. On the default subnet, this is
+	// the $AVA staked by the current validators and delegators.
+	Stake json.Uint64 `json:"stake"`
+
+	// Total weight of the pending validators
+	PendingStake json.Uint64 `json:"pendingStake"`
+}
+
+// GetTotalStake returns the total weight of the current and pending validators
+// of a subnet
+func (service *Service) GetTotalStake(_ *http.Request, args *GetTotalStakeArgs, reply *GetTotalStakeReply) error {
+	service.vm.Ctx.Log.Debug("GetTotalStake called")
+
+	if args.SubnetID.IsZero() {
+		args.SubnetID = DefaultSubnetID
+	}
+
+	stake, err := service.vm.getCurrentStake(service.vm.DB, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the stake of subnet with ID %s: %w", args.SubnetID, err)
+	}
+	pendingStake, err := service.vm.getPendingStake(service.vm.DB, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the pending stake of subnet with ID %s: %w", args.SubnetID, err)
+	}
+
+	reply.Stake = json.Uint64(stake)
+	reply.PendingStake = json.Uint64(pendingStake)
+	return nil
+}
+
+// GetStakeArgs are the arguments for calling GetStake
+type GetStakeArgs struct {
+	// Addresses the staked $AVA will be returned to
+	Addresses []string `json:"addresses"`
+}
+
+// GetStakeReply are the results from calling GetStake
+type GetStakeReply struct {
+	// $AVA staked by current validators and delegators
+	Staked json.Uint64 `json:"staked"`
+
+	// $AVA staked by pending validators and delegators
+	PendingStaked json.Uint64 `json:"pendingStaked"`
+}
+
+// GetStake returns the amount of $AVA staked on the default subnet that will be
+// returned to any of the given addresses
+func (service *Service) GetStake(_ *http.Request, args *GetStakeArgs, reply *GetStakeReply) error {
+	service.vm.Ctx.Log.Debug("GetStake called")
+
+	addresses := ids.ShortSet{}
+	for _, addrStr := range args.Addresses {
+		address, err := service.vm.parseAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse address %s: %w", addrStr, err)
+		}
+		addresses.Add(address)
+	}
+
+	currentValidators, err := service.vm.getCurrentValidators(service.vm.DB, DefaultSubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the current validators: %w", err)
+	}
+	staked, err := stakedTo(addresses, currentValidators)
+	if err != nil {
+		return err
+	}
+
+	pendingValidators, err := service.vm.getPendingValidators(service.vm.DB, DefaultSubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the pending validators: %w", err)
+	}
+	pendingStaked, err := stakedTo(addresses, pendingValidators)
+	if err != nil {
+		return err
+	}
+
+	reply.Staked = json.Uint64(staked)
+	reply.PendingStaked = json.Uint64(pendingStaked)
+	return nil
+}
+
+// stakedTo returns the $AVA staked by the default subnet stakers in [stakers]
+// that will be returned to one of [addresses]
+func stakedTo(addresses ids.ShortSet, stakers *EventHeap) (uint64, error) {
+	staked := uint64(0)
+	for _, tx := range stakers.Txs {
+		destination, ok := stakerDestination(tx)
+		if !ok || !addresses.Contains(destination) {
+			continue
+		}
+		newStaked, err := math.Add64(staked, tx.Vdr().Weight())
+		if err != nil {
+			return 0, err
+		}
+		staked = newStaked
+	}
+	return staked, nil
+}
+
+// stakerDestination returns the address that [tx]'s stake is returned to, if
+// [tx] stakes on the default subnet
+func stakerDestination(tx TimedTx) (ids.ShortID, bool) {
+	switch tx := tx.(type) {
+	case *addDefaultSubnetValidatorTx:
+		return tx.Destination, true
+	case *addDefaultSubnetDelegatorTx:
+		return tx.Destination, true
+	default:
+		return ids.ShortID{}, false
+	}
+}
+
+// GetValidatorsAtArgs are the arguments for calling GetValidatorsAt
+type GetValidatorsAtArgs struct {
+	// Subnet we're listing the validators of
+	// If omitted, defaults to default subnet
+	SubnetID ids.ID `json:"subnetID"`
+
+	// Height of the accepted block after which the validator set is returned
+	Height json.Uint64 `json:"height"`
+}
+
+// APIValidatorWeight is a validator returned by GetValidatorsAt
+type APIValidatorWeight struct {
+	ID     ids.ShortID `json:"id"`
+	Weight json.Uint64 `json:"weight"`
+}
+
+// GetValidatorsAtReply are the results from calling GetValidatorsAt
+type GetValidatorsAtReply struct {
+	Validators []APIValidatorWeight `json:"validators"`
+}
+
+// GetValidatorsAt returns the validators of a subnet, and their weights, as
+// of the accepted block at the given height
+func (service *Service) GetValidatorsAt(_ *http.Request, args *GetValidatorsAtArgs, reply *GetValidatorsAtReply) error {
+	service.vm.Ctx.Log.Debug("GetValidatorsAt called")
+
+	if args.SubnetID.IsZero() {
+		args.SubnetID = DefaultSubnetID
+	}
+
+	validators, err := service.vm.GetValidatorSetAt(args.SubnetID, uint64(args.Height))
+	if err != nil {
+		return fmt.Errorf("couldn't get validators of subnet %s at height %d: %w", args.SubnetID, args.Height, err)
+	}
+
+	reply.Validators = make([]APIValidatorWeight, len(validators))
+	for i, vdr := range validators {
+		reply.Validators[i] = APIValidatorWeight{
+			ID:     vdr.ID(),
+			Weight: json.Uint64(vdr.Weight()),
+		}
+	}
+	sort.Slice(reply.Validators, func(i, j int) bool {
+		return bytes.Compare(reply.Validators[i].ID.Bytes(), reply.Validators[j].ID.Bytes()) == -1
+	})
+	return nil
+}
+
 // SampleValidatorsArgs are the arguments for calling SampleValidators
 type SampleValidatorsArgs struct {
 	// Number of validators in the sample
@@ -258,6 +522,68 @@ func (service *Service) SampleValidators(_ *http.Request, args *SampleValidators
 	return nil
 }
 
+// GetUptimeArgs are the arguments for calling GetUptime
+type GetUptimeArgs struct {
+	// ID of the default subnet validator
+	ID ids.ShortID `json:"id"`
+}
+
+// GetUptimeReply are the results from calling GetUptime
+type GetUptimeReply struct {
+	// Unix time the validator's staking period starts and ends
+	StartTime json.Uint64 `json:"startTime"`
+	EndTime   json.Uint64 `json:"endTime"`
+
+	// Seconds of the staking period that have elapsed, and how many of those
+	// the validator was connected to this node
+	ElapsedDuration json.Uint64 `json:"elapsedDuration"`
+	UpDuration      json.Uint64 `json:"upDuration"`
+
+	// Fraction of the elapsed staking period the validator was connected to
+	// this node, and the fraction required for this node to prefer rewarding it
+	Uptime            float64 `json:"uptime"`
+	UptimeRequirement float64 `json:"uptimeRequirement"`
+
+	// True if the validator is currently connected to this node
+	Connected bool `json:"connected"`
+}
+
+// GetUptime returns how long a current default subnet validator has been
+// connected to this node during its staking period
+func (service *Service) GetUptime(_ *http.Request, args *GetUptimeArgs, reply *GetUptimeReply) error {
+	service.vm.Ctx.Log.Debug("GetUptime called")
+
+	if args.ID.IsZero() {
+		return errors.New("validator ID not specified")
+	}
+
+	validators, err := service.vm.getCurrentValidators(service.vm.DB, DefaultSubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get validators of the default subnet: %w", err)
+	}
+	tx, err := validators.getDefaultSubnetStaker(args.ID)
+	if err != nil {
+		return fmt.Errorf("%s isn't a current validator of the default subnet", args.ID)
+	}
+
+	upDuration, elapsed, err := service.vm.calculateUptime(tx, service.vm.clock.Time())
+	if err != nil {
+		return fmt.Errorf("couldn't calculate uptime: %w", err)
+	}
+
+	reply.StartTime = json.Uint64(tx.StartTime().Unix())
+	reply.EndTime = json.Uint64(tx.EndTime().Unix())
+	reply.ElapsedDuration = json.Uint64(elapsed / time.Second)
+	reply.UpDuration = json.Uint64(upDuration / time.Second)
+	reply.Uptime = 1
+	if elapsed > 0 {
+		reply.Uptime = float64(upDuration) / float64(elapsed)
+	}
+	reply.UptimeRequirement = service.vm.uptimeRequirement
+	reply.Connected = service.vm.uptimes.IsConnected(args.ID)
+	return nil
+}
+
 /*
  ******************************************************
  *************** Get/Create Accounts ******************
@@ -267,26 +593,31 @@ func (service *Service) SampleValidators(_ *http.Request, args *SampleValidators
 // GetAccountArgs are the arguments for calling GetAccount
 type GetAccountArgs struct {
 	// Address of the account we want the information about
-	Address ids.ShortID `json:"address"`
+	Address string `json:"address"`
 }
 
 // GetAccountReply is the response from calling GetAccount
 type GetAccountReply struct {
-	Address ids.ShortID `json:"address"`
+	Address string      `json:"address"`
 	Nonce   json.Uint64 `json:"nonce"`
 	Balance json.Uint64 `json:"balance"`
 }
 
 // GetAccount details given account ID
 func (service *Service) GetAccount(_ *http.Request, args *GetAccountArgs, reply *GetAccountReply) error {
-	account, err := service.vm.getAccount(service.vm.DB, args.Address)
+	address, err := service.vm.parseAddress(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address: %w", err)
+	}
+
+	account, err := service.vm.getAccount(service.vm.DB, address)
 	if err != nil && err != database.ErrNotFound {
 		return fmt.Errorf("couldn't get account: %w", err)
 	} else if err == database.ErrNotFound {
-		account = newAccount(args.Address, 0, 0)
+		account = newAccount(address, 0, 0)
 	}
 
-	reply.Address = account.Address
+	reply.Address = service.vm.formatAddress(account.Address)
 	reply.Balance = json.Uint64(account.Balance)
 	reply.Nonce = json.Uint64(account.Nonce)
 	return nil
@@ -335,7 +666,7 @@ func (service *Service) ListAccounts(_ *http.Request, args *ListAccountsArgs, re
 			account = newAccount(accountID, 0, 0)
 		}
 		reply.Accounts = append(reply.Accounts, APIAccount{
-			Address: accountID,
+			Address: service.vm.formatAddress(accountID),
 			Nonce:   json.Uint64(account.Nonce),
 			Balance: json.Uint64(account.Balance),
 		})
@@ -360,7 +691,7 @@ type CreateAccountArgs struct {
 // CreateAccountReply are the response from calling CreateAccount
 type CreateAccountReply struct {
 	// Address of the newly created account
-	Address ids.ShortID `json:"address"`
+	Address string `json:"address"`
 }
 
 // CreateAccount creates a new account on the Platform Chain
@@ -407,7 +738,7 @@ func (service *Service) CreateAccount(_ *http.Request, args *CreateAccountArgs,
 		return errors.New("problem saving account")
 	}
 
-	reply.Address = privKey.PublicKey().Address()
+	reply.Address = service.vm.formatAddress(privKey.PublicKey().Address())
 
 	return nil
 }
@@ -429,7 +760,11 @@ type CreateTxResponse struct {
 
 // AddDefaultSubnetValidatorArgs are the arguments to AddDefaultSubnetValidator
 type AddDefaultSubnetValidatorArgs struct {
-	APIDefaultSubnetValidator
+	APIValidator
+
+	// Address that receives the staked $AVA and reward. May be CB58 or bech32.
+	Destination       string      `json:"destination"`
+	DelegationFeeRate json.Uint32 `json:"delegationFeeRate"`
 
 	// Next nonce of the sender
 	PayerNonce json.Uint64 `json:"payerNonce"`
@@ -449,6 +784,11 @@ func (service *Service) AddDefaultSubnetValidator(_ *http.Request, args *AddDefa
 		return fmt.Errorf("start time must be in the future")
 	}
 
+	destination, err := service.vm.parseAddress(args.Destination)
+	if err != nil {
+		return fmt.Errorf("couldn't parse destination: %w", err)
+	}
+
 	// Create the transaction
 	tx := addDefaultSubnetValidatorTx{UnsignedAddDefaultSubnetValidatorTx: UnsignedAddDefaultSubnetValidatorTx{
 		DurationValidator: DurationValidator{
@@ -460,7 +800,7 @@ func (service *Service) AddDefaultSubnetValidator(_ *http.Request, args *AddDefa
 			End:   uint64(args.EndTime),
 		},
 		Nonce:       uint64(args.PayerNonce),
-		Destination: args.Destination,
+		Destination: destination,
 		NetworkID:   service.vm.Ctx.NetworkID,
 		Shares:      uint32(args.DelegationFeeRate),
 	}}
@@ -478,7 +818,8 @@ func (service *Service) AddDefaultSubnetValidator(_ *http.Request, args *AddDefa
 type AddDefaultSubnetDelegatorArgs struct {
 	APIValidator
 
-	Destination ids.ShortID `json:"destination"`
+	// Address that receives the staked $AVA. May be CB58 or bech32.
+	Destination string `json:"destination"`
 
 	// Next unused nonce of the account the staked $AVA and tx fee are paid from
 	PayerNonce json.Uint64 `json:"payerNonce"`
@@ -499,6 +840,11 @@ func (service *Service) AddDefaultSubnetDelegator(_ *http.Request, args *AddDefa
 		return fmt.Errorf("start time must be in the future")
 	}
 
+	destination, err := service.vm.parseAddress(args.Destination)
+	if err != nil {
+		return fmt.Errorf("couldn't parse destination: %w", err)
+	}
+
 	// Create the transaction
 	tx := addDefaultSubnetDelegatorTx{UnsignedAddDefaultSubnetDelegatorTx: UnsignedAddDefaultSubnetDelegatorTx{
 		DurationValidator: DurationValidator{
@@ -511,7 +857,7 @@ func (service *Service) AddDefaultSubnetDelegator(_ *http.Request, args *AddDefa
 		},
 		NetworkID:   service.vm.Ctx.NetworkID,
 		Nonce:       uint64(args.PayerNonce),
-		Destination: args.Destination,
+		Destination: destination,
 	}}
 
 	txBytes, err := Codec.Marshal(genericTx{Tx: &tx})
@@ -589,12 +935,21 @@ func (service *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, re
 		return fmt.Errorf("sender's next nonce not specified")
 	}
 
+	controlKeys := make([]ids.ShortID, len(args.ControlKeys))
+	for i, controlKey := range args.ControlKeys {
+		key, err := service.vm.parseAddress(controlKey)
+		if err != nil {
+			return fmt.Errorf("couldn't parse control key: %w", err)
+		}
+		controlKeys[i] = key
+	}
+
 	// Create the transaction
 	tx := CreateSubnetTx{
 		UnsignedCreateSubnetTx: UnsignedCreateSubnetTx{
 			NetworkID:   service.vm.Ctx.NetworkID,
 			Nonce:       uint64(args.PayerNonce),
-			ControlKeys: args.ControlKeys,
+			ControlKeys: controlKeys,
 			Threshold:   uint16(args.Threshold),
 		},
 		key:   nil,
@@ -611,10 +966,121 @@ func (service *Service) CreateSubnet(_ *http.Request, args *CreateSubnetArgs, re
 	return nil
 }
 
+// UpdateSubnetControlKeysArgs are the arguments to UpdateSubnetControlKeys
+type UpdateSubnetControlKeysArgs struct {
+	// ID of the subnet whose control keys are being replaced
+	SubnetID ids.ID `json:"subnetID"`
+
+	// The subnet's new control keys. May be CB58 or bech32.
+	ControlKeys []string `json:"controlKeys"`
+
+	// The number of the new control keys that must sign a tx that requires the
+	// subnet's control signatures
+	Threshold json.Uint16 `json:"threshold"`
+
+	// Nonce of the account that pays the transaction fee
+	PayerNonce json.Uint64 `json:"payerNonce"`
+}
+
+// UpdateSubnetControlKeys returns an unsigned transaction to replace the control
+// keys and threshold of a subnet.
+// The unsigned transaction must be signed with the subnet's current threshold of
+// control keys and with a key that pays the transaction fee before issuance
+func (service *Service) UpdateSubnetControlKeys(_ *http.Request, args *UpdateSubnetControlKeysArgs, response *CreateTxResponse) error {
+	service.vm.Ctx.Log.Debug("platform.updateSubnetControlKeys called")
+
+	switch {
+	case args.PayerNonce == 0:
+		return fmt.Errorf("sender's next nonce not specified")
+	case args.SubnetID.IsZero():
+		return errors.New("subnet not specified")
+	case args.SubnetID.Equals(DefaultSubnetID):
+		return errDSCantValidate
+	}
+
+	controlKeys := make([]ids.ShortID, len(args.ControlKeys))
+	for i, controlKey := range args.ControlKeys {
+		key, err := service.vm.parseAddress(controlKey)
+		if err != nil {
+			return fmt.Errorf("couldn't parse control key: %w", err)
+		}
+		controlKeys[i] = key
+	}
+	ids.SortShortIDs(controlKeys)
+	if !ids.IsSortedAndUniqueShortIDs(controlKeys) {
+		return errControlKeysNotSortedAndUnique
+	}
+
+	// Create the transaction
+	tx := UpdateSubnetControlKeysTx{UnsignedUpdateSubnetControlKeysTx: UnsignedUpdateSubnetControlKeysTx{
+		NetworkID:   service.vm.Ctx.NetworkID,
+		SubnetID:    args.SubnetID,
+		Nonce:       uint64(args.PayerNonce),
+		ControlKeys: controlKeys,
+		Threshold:   uint16(args.Threshold),
+	}}
+
+	txBytes, err := Codec.Marshal(genericTx{Tx: &tx})
+	if err != nil {
+		return errCreatingTransaction
+	}
+
+	response.UnsignedTx.Bytes = txBytes
+	return nil
+}
+
+// RemoveNonDefaultSubnetValidatorArgs are the arguments to RemoveNonDefaultSubnetValidator
+type RemoveNonDefaultSubnetValidatorArgs struct {
+	// ID of the node being removed
+	ID ids.ShortID `json:"id"`
+
+	// ID of the subnet the node is removed from
+	SubnetID ids.ID `json:"subnetID"`
+
+	// Nonce of the account that pays the transaction fee
+	PayerNonce json.Uint64 `json:"payerNonce"`
+}
+
+// RemoveNonDefaultSubnetValidator returns an unsigned transaction to remove a
+// validator from the current and pending validators of a subnet.
+// The unsigned transaction must be signed with the subnet's threshold of control
+// keys and with a key that pays the transaction fee before issuance
+func (service *Service) RemoveNonDefaultSubnetValidator(_ *http.Request, args *RemoveNonDefaultSubnetValidatorArgs, response *CreateTxResponse) error {
+	service.vm.Ctx.Log.Debug("platform.removeNonDefaultSubnetValidator called")
+
+	switch {
+	case args.PayerNonce == 0:
+		return fmt.Errorf("sender's next nonce not specified")
+	case args.ID.IsZero():
+		return errors.New("node ID not specified")
+	case args.SubnetID.IsZero():
+		return errors.New("subnet not specified")
+	case args.SubnetID.Equals(DefaultSubnetID):
+		return errDSCantValidate
+	}
+
+	// Create the transaction
+	tx := removeNonDefaultSubnetValidatorTx{UnsignedRemoveNonDefaultSubnetValidatorTx: UnsignedRemoveNonDefaultSubnetValidatorTx{
+		NetworkID: service.vm.Ctx.NetworkID,
+		SubnetID:  args.SubnetID,
+		NodeID:    args.ID,
+		Nonce:     uint64(args.PayerNonce),
+	}}
+
+	txBytes, err := Codec.Marshal(genericTx{Tx: &tx})
+	if err != nil {
+		return errCreatingTransaction
+	}
+
+	response.UnsignedTx.Bytes = txBytes
+	return nil
+}
+
 // ExportAVAArgs are the arguments to ExportAVA
 type ExportAVAArgs struct {
-	// X-Chain address (without prepended X-) that will receive the exported AVA
-	To ids.ShortID `json:"to"`
+	// X-Chain address (without prepended X-) that will receive the exported AVA.
+	// May be CB58 or bech32.
+	To string `json:"to"`
 
 	// Nonce of the account that pays the transaction fee and provides the export AVA
 	PayerNonce json.Uint64 `json:"payerNonce"`
@@ -637,6 +1103,11 @@ func (service *Service) ExportAVA(_ *http.Request, args *ExportAVAArgs, response
 		return fmt.Errorf("amount must be >0")
 	}
 
+	to, err := service.vm.parseAddress(args.To)
+	if err != nil {
+		return fmt.Errorf("couldn't parse to address: %w", err)
+	}
+
 	// Create the transaction
 	tx := ExportTx{UnsignedExportTx: UnsignedExportTx{
 		NetworkID: service.vm.Ctx.NetworkID,
@@ -647,7 +1118,7 @@ func (service *Service) ExportAVA(_ *http.Request, args *ExportAVAArgs, response
 				Amt: uint64(args.Amount),
 				OutputOwners: secp256k1fx.OutputOwners{
 					Threshold: 1,
-					Addrs:     []ids.ShortID{args.To},
+					Addrs:     []ids.ShortID{to},
 				},
 			},
 		}},
@@ -674,8 +1145,8 @@ type SignArgs struct {
 	// Must be the output of AddDefaultSubnetValidator
 	Tx formatting.CB58 `json:"tx"`
 
-	// The address of the key signing the bytes
-	Signer ids.ShortID `json:"signer"`
+	// The address of the key signing the bytes. May be CB58 or bech32.
+	Signer string `json:"signer"`
 
 	// User that controls Signer
 	Username string `json:"username"`
@@ -692,7 +1163,11 @@ type SignResponse struct {
 func (service *Service) Sign(_ *http.Request, args *SignArgs, reply *SignResponse) error {
 	service.vm.Ctx.Log.Debug("sign called")
 
-	if args.Signer.IsZero() {
+	signer, err := service.vm.parseAddress(args.Signer)
+	if err != nil {
+		return fmt.Errorf("couldn't parse signer: %w", err)
+	}
+	if signer.IsZero() {
 		return errNilSigner
 	}
 
@@ -703,11 +1178,11 @@ func (service *Service) Sign(_ *http.Request, args *SignArgs, reply *SignRespons
 	}
 	user := user{db: db}
 
-	key, err := user.getKey(args.Signer) // Key of [args.Signer]
+	key, err := user.getKey(signer) // Key of [args.Signer]
 	if err != nil {
 		return errDB
 	}
-	if !bytes.Equal(key.PublicKey().Address().Bytes(), args.Signer.Bytes()) { // sanity check
+	if !bytes.Equal(key.PublicKey().Address().Bytes(), signer.Bytes()) { // sanity check
 		return errors.New("got unexpected key from database")
 	}
 
@@ -727,6 +1202,10 @@ func (service *Service) Sign(_ *http.Request, args *SignArgs, reply *SignRespons
 		genTx.Tx, err = service.signCreateSubnetTx(tx, key)
 	case *CreateChainTx:
 		genTx.Tx, err = service.signCreateChainTx(tx, key)
+	case *UpdateSubnetControlKeysTx:
+		genTx.Tx, err = service.signUpdateSubnetControlKeysTx(tx, key)
+	case *removeNonDefaultSubnetValidatorTx:
+		genTx.Tx, err = service.signRemoveNonDefaultSubnetValidatorTx(tx, key)
 	case *ExportTx:
 		genTx.Tx, err = service.signExportTx(tx, key)
 	default:
@@ -860,18 +1339,22 @@ func (service *Service) signAddNonDefaultSubnetValidatorTx(tx *addNonDefaultSubn
 	if err != nil {
 		return nil, fmt.Errorf("problem getting subnet information: %w", err)
 	}
+	control, err := service.vm.getSubnetControl(service.vm.DB, subnet)
+	if err != nil {
+		return nil, fmt.Errorf("problem getting subnet control keys: %w", err)
+	}
 
 	// Find the location at which [key] should put its signature.
 	// If [key] is a control key for this subnet and there is an empty spot in tx.ControlSigs, sign there
 	// If [key] is a control key for this subnet and there is no empty spot in tx.ControlSigs, sign as payer
 	// If [key] is not a control key, sign as payer (account controlled by [key] pays the tx fee)
 	controlKeySet := ids.ShortSet{}
-	controlKeySet.Add(subnet.ControlKeys...)
+	controlKeySet.Add(control.ControlKeys...)
 	isControlKey := controlKeySet.Contains(key.PublicKey().Address())
 
 	payerSigEmpty := tx.PayerSig == [crypto.SECP256K1RSigLen]byte{} // true if no key has signed to pay the tx fee
 
-	if isControlKey && len(tx.ControlSigs) != int(subnet.Threshold) { // Sign as controlSig
+	if isControlKey && len(tx.ControlSigs) != int(control.Threshold) { // Sign as controlSig
 		tx.ControlSigs = append(tx.ControlSigs, [crypto.SECP256K1RSigLen]byte{})
 		copy(tx.ControlSigs[len(tx.ControlSigs)-1][:], sig)
 	} else if payerSigEmpty { // sign as payer
@@ -887,8 +1370,9 @@ func (service *Service) signAddNonDefaultSubnetValidatorTx(tx *addNonDefaultSubn
 
 // ImportAVAArgs are the arguments to ImportAVA
 type ImportAVAArgs struct {
-	// ID of the account that will receive the imported funds, and pay the transaction fee
-	To ids.ShortID `json:"to"`
+	// ID of the account that will receive the imported funds, and pay the
+	// transaction fee. May be CB58 or bech32.
+	To string `json:"to"`
 
 	// Next nonce of the sender
 	PayerNonce json.Uint64 `json:"payerNonce"`
@@ -904,8 +1388,13 @@ type ImportAVAArgs struct {
 func (service *Service) ImportAVA(_ *http.Request, args *ImportAVAArgs, response *SignResponse) error {
 	service.vm.Ctx.Log.Debug("platform.ImportAVA called")
 
+	to, err := service.vm.parseAddress(args.To)
+	if err != nil {
+		return fmt.Errorf("couldn't parse to address: %w", err)
+	}
+
 	switch {
-	case args.To.IsZero():
+	case to.IsZero():
 		return errNilTo
 	case args.PayerNonce == 0:
 		return fmt.Errorf("sender's next nonce not specified")
@@ -919,14 +1408,14 @@ func (service *Service) ImportAVA(_ *http.Request, args *ImportAVAArgs, response
 	user := user{db: db}
 
 	kc := secp256k1fx.NewKeychain()
-	key, err := user.getKey(args.To)
+	key, err := user.getKey(to)
 	if err != nil {
 		return errDB
 	}
 	kc.Add(key)
 
 	addrSet := ids.Set{}
-	addrSet.Add(ids.NewID(hashing.ComputeHash256Array(args.To.Bytes())))
+	addrSet.Add(ids.NewID(hashing.ComputeHash256Array(to.Bytes())))
 
 	utxos, err := service.vm.GetAtomicUTXOs(addrSet)
 	if err != nil {
@@ -972,7 +1461,7 @@ func (service *Service) ImportAVA(_ *http.Request, args *ImportAVAArgs, response
 	tx := ImportTx{UnsignedImportTx: UnsignedImportTx{
 		NetworkID: service.vm.Ctx.NetworkID,
 		Nonce:     uint64(args.PayerNonce),
-		Account:   args.To,
+		Account:   to,
 		Ins:       ins,
 	}}
 
@@ -1042,18 +1531,128 @@ func (service *Service) signCreateChainTx(tx *CreateChainTx, key *crypto.Private
 	if err != nil {
 		return nil, fmt.Errorf("problem getting subnet information: %w", err)
 	}
+	control, err := service.vm.getSubnetControl(service.vm.DB, subnet)
+	if err != nil {
+		return nil, fmt.Errorf("problem getting subnet control keys: %w", err)
+	}
 
 	// Find the location at which [key] should put its signature.
 	// If [key] is a control key for this subnet and there is an empty spot in tx.ControlSigs, sign there
 	// If [key] is a control key for this subnet and there is no empty spot in tx.ControlSigs, sign as payer
 	// If [key] is not a control key, sign as payer (account controlled by [key] pays the tx fee)
 	controlKeySet := ids.ShortSet{}
-	controlKeySet.Add(subnet.ControlKeys...)
+	controlKeySet.Add(control.ControlKeys...)
+	isControlKey := controlKeySet.Contains(key.PublicKey().Address())
+
+	payerSigEmpty := tx.PayerSig == [crypto.SECP256K1RSigLen]byte{} // true if no key has signed to pay the tx fee
+
+	if isControlKey && len(tx.ControlSigs) != int(control.Threshold) { // Sign as controlSig
+		tx.ControlSigs = append(tx.ControlSigs, [crypto.SECP256K1RSigLen]byte{})
+		copy(tx.ControlSigs[len(tx.ControlSigs)-1][:], sig)
+	} else if payerSigEmpty { // sign as payer
+		copy(tx.PayerSig[:], sig)
+	} else {
+		return nil, errors.New("no place for key to sign")
+	}
+
+	crypto.SortSECP2561RSigs(tx.ControlSigs)
+
+	return tx, nil
+}
+
+// Signs an unsigned or partially signed UpdateSubnetControlKeysTx with [key]
+// If [key] is a current control key for the subnet and there is an empty spot in tx.ControlSigs, signs there
+// If [key] is a current control key for the subnet and there is no empty spot in tx.ControlSigs, signs as payer
+// If [key] is not a current control key, sign as payer (account controlled by [key] pays the tx fee)
+// Sorts tx.ControlSigs before returning
+// Assumes each element of tx.ControlSigs is actually a signature, not just empty bytes
+func (service *Service) signUpdateSubnetControlKeysTx(tx *UpdateSubnetControlKeysTx, key *crypto.PrivateKeySECP256K1R) (*UpdateSubnetControlKeysTx, error) {
+	service.vm.Ctx.Log.Debug("signUpdateSubnetControlKeysTx called")
+
+	// Compute the byte repr. of the unsigned tx and the signature of [key] over it
+	unsignedIntf := interface{}(&tx.UnsignedUpdateSubnetControlKeysTx)
+	unsignedTxBytes, err := Codec.Marshal(&unsignedIntf)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing unsigned tx: %w", err)
+	}
+	sig, err := key.Sign(unsignedTxBytes)
+	if err != nil {
+		return nil, errors.New("error while signing")
+	}
+	if len(sig) != crypto.SECP256K1RSigLen {
+		return nil, fmt.Errorf("expected signature to be length %d but was length %d", crypto.SECP256K1RSigLen, len(sig))
+	}
+
+	// Get the subnet's current control keys
+	subnet, err := service.vm.getSubnet(service.vm.DB, tx.SubnetID)
+	if err != nil {
+		return nil, fmt.Errorf("problem getting subnet information: %w", err)
+	}
+	control, err := service.vm.getSubnetControl(service.vm.DB, subnet)
+	if err != nil {
+		return nil, fmt.Errorf("problem getting subnet control keys: %w", err)
+	}
+
+	controlKeySet := ids.ShortSet{}
+	controlKeySet.Add(control.ControlKeys...)
 	isControlKey := controlKeySet.Contains(key.PublicKey().Address())
 
 	payerSigEmpty := tx.PayerSig == [crypto.SECP256K1RSigLen]byte{} // true if no key has signed to pay the tx fee
 
-	if isControlKey && len(tx.ControlSigs) != int(subnet.Threshold) { // Sign as controlSig
+	if isControlKey && len(tx.ControlSigs) != int(control.Threshold) { // Sign as controlSig
+		tx.ControlSigs = append(tx.ControlSigs, [crypto.SECP256K1RSigLen]byte{})
+		copy(tx.ControlSigs[len(tx.ControlSigs)-1][:], sig)
+	} else if payerSigEmpty { // sign as payer
+		copy(tx.PayerSig[:], sig)
+	} else {
+		return nil, errors.New("no place for key to sign")
+	}
+
+	crypto.SortSECP2561RSigs(tx.ControlSigs)
+
+	return tx, nil
+}
+
+// Signs an unsigned or partially signed removeNonDefaultSubnetValidatorTx with [key]
+// If [key] is a control key for the subnet and there is an empty spot in tx.ControlSigs, signs there
+// If [key] is a control key for the subnet and there is no empty spot in tx.ControlSigs, signs as payer
+// If [key] is not a control key, sign as payer (account controlled by [key] pays the tx fee)
+// Sorts tx.ControlSigs before returning
+// Assumes each element of tx.ControlSigs is actually a signature, not just empty bytes
+func (service *Service) signRemoveNonDefaultSubnetValidatorTx(tx *removeNonDefaultSubnetValidatorTx, key *crypto.PrivateKeySECP256K1R) (*removeNonDefaultSubnetValidatorTx, error) {
+	service.vm.Ctx.Log.Debug("signRemoveNonDefaultSubnetValidatorTx called")
+
+	// Compute the byte repr. of the unsigned tx and the signature of [key] over it
+	unsignedIntf := interface{}(&tx.UnsignedRemoveNonDefaultSubnetValidatorTx)
+	unsignedTxBytes, err := Codec.Marshal(&unsignedIntf)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing unsigned tx: %w", err)
+	}
+	sig, err := key.Sign(unsignedTxBytes)
+	if err != nil {
+		return nil, errors.New("error while signing")
+	}
+	if len(sig) != crypto.SECP256K1RSigLen {
+		return nil, fmt.Errorf("expected signature to be length %d but was length %d", crypto.SECP256K1RSigLen, len(sig))
+	}
+
+	// Get the subnet's control keys
+	subnet, err := service.vm.getSubnet(service.vm.DB, tx.SubnetID)
+	if err != nil {
+		return nil, fmt.Errorf("problem getting subnet information: %w", err)
+	}
+	control, err := service.vm.getSubnetControl(service.vm.DB, subnet)
+	if err != nil {
+		return nil, fmt.Errorf("problem getting subnet control keys: %w", err)
+	}
+
+	controlKeySet := ids.ShortSet{}
+	controlKeySet.Add(control.ControlKeys...)
+	isControlKey := controlKeySet.Contains(key.PublicKey().Address())
+
+	payerSigEmpty := tx.PayerSig == [crypto.SECP256K1RSigLen]byte{} // true if no key has signed to pay the tx fee
+
+	if isControlKey && len(tx.ControlSigs) != int(control.Threshold) { // Sign as controlSig
 		tx.ControlSigs = append(tx.ControlSigs, [crypto.SECP256K1RSigLen]byte{})
 		copy(tx.ControlSigs[len(tx.ControlSigs)-1][:], sig)
 	} else if payerSigEmpty { // sign as payer
@@ -1088,30 +1687,83 @@ func (service *Service) IssueTx(_ *http.Request, args *IssueTxArgs, response *Is
 		return err
 	}
 
-	switch tx := genTx.Tx.(type) {
-	case TimedTx:
-		if err := tx.initialize(service.vm); err != nil {
-			return fmt.Errorf("error initializing tx: %s", err)
-		}
-		service.vm.unissuedEvents.Push(tx)
-		response.TxID = tx.ID()
-	case DecisionTx:
-		if err := tx.initialize(service.vm); err != nil {
-			return fmt.Errorf("error initializing tx: %s", err)
+	txID, err := service.vm.issueTx(genTx.Tx)
+	if err != nil {
+		return err
+	}
+	response.TxID = txID
+
+	service.vm.resetTimer()
+	return nil
+}
+
+// APIPendingTx is a tx in the mempool
+type APIPendingTx struct {
+	ID ids.ID `json:"id"`
+
+	// One of "decision", "atomic" or "proposal"
+	Kind string `json:"kind"`
+
+	Tx formatting.CB58 `json:"tx"`
+}
+
+// GetPendingTxsReply is the response from GetPendingTxs
+type GetPendingTxsReply struct {
+	Txs []APIPendingTx `json:"txs"`
+}
+
+// GetPendingTxs returns the txs that have been issued to this node but not yet
+// put into a block
+func (service *Service) GetPendingTxs(_ *http.Request, _ *struct{}, reply *GetPendingTxsReply) error {
+	service.vm.Ctx.Log.Debug("getPendingTxs called")
+
+	pending := service.vm.pendingTxs()
+	reply.Txs = make([]APIPendingTx, len(pending))
+	for i, tx := range pending {
+		txBytes, err := Codec.Marshal(genericTx{Tx: tx})
+		if err != nil {
+			return fmt.Errorf("couldn't serialize tx: %w", err)
 		}
-		service.vm.unissuedDecisionTxs = append(service.vm.unissuedDecisionTxs, tx)
-		response.TxID = tx.ID()
-	case AtomicTx:
-		if err := tx.initialize(service.vm); err != nil {
-			return fmt.Errorf("error initializing tx: %s", err)
+		apiTx := APIPendingTx{Tx: formatting.CB58{Bytes: txBytes}}
+		switch tx := tx.(type) {
+		case TimedTx:
+			apiTx.ID = tx.ID()
+			apiTx.Kind = "proposal"
+		case DecisionTx:
+			apiTx.ID = tx.ID()
+			apiTx.Kind = "decision"
+		case AtomicTx:
+			apiTx.ID = tx.ID()
+			apiTx.Kind = "atomic"
 		}
-		service.vm.unissuedAtomicTxs = append(service.vm.unissuedAtomicTxs, tx)
-		response.TxID = tx.ID()
-	default:
-		return errors.New("Could not parse given tx. Must be a TimedTx, DecisionTx, or AtomicTx")
+		reply.Txs[i] = apiTx
 	}
+	return nil
+}
 
-	service.vm.resetTimer()
+// GetTxStatusArgs are the arguments for GetTxStatus
+type GetTxStatusArgs struct {
+	TxID ids.ID `json:"txID"`
+}
+
+// GetTxStatusReply is the response from GetTxStatus
+type GetTxStatusReply struct {
+	Status TxStatus `json:"status"`
+}
+
+// GetTxStatus returns the status of the tx with the given ID
+func (service *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, reply *GetTxStatusReply) error {
+	service.vm.Ctx.Log.Debug("getTxStatus called")
+
+	if args.TxID.IsZero() {
+		return errors.New("'txID' not given")
+	}
+
+	status, err := service.vm.getTxStatus(args.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get the status of tx %s: %w", args.TxID, err)
+	}
+	reply.Status = status
 	return nil
 }
 
@@ -1156,31 +1808,19 @@ func (service *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchain
 		return errors.New("subnet not specified")
 	}
 
-	vmID, err := service.vm.chainManager.LookupVM(args.VMID)
+	vmID, fxIDs, err := service.lookupVM(args.VMID, args.FxIDs)
 	if err != nil {
-		return fmt.Errorf("no VM with ID '%s' found", args.VMID)
-	}
-
-	fxIDs := []ids.ID(nil)
-	for _, fxIDStr := range args.FxIDs {
-		fxID, err := service.vm.chainManager.LookupVM(fxIDStr)
-		if err != nil {
-			return fmt.Errorf("no FX with ID '%s' found", fxIDStr)
-		}
-		fxIDs = append(fxIDs, fxID)
-	}
-	// If creating AVM instance, use secp256k1fx
-	// TODO: Document FXs and have user specify them in API call
-	fxIDsSet := ids.Set{}
-	fxIDsSet.Add(fxIDs...)
-	if vmID.Equals(avm.ID) && !fxIDsSet.Contains(secp256k1fx.ID) {
-		fxIDs = append(fxIDs, secp256k1fx.ID)
+		return err
 	}
 
 	if args.SubnetID.Equals(DefaultSubnetID) {
 		return errDSCantValidate
 	}
 
+	if err := service.vm.verifyGenesis(vmID, fxIDs, args.GenesisData.Bytes); err != nil {
+		return fmt.Errorf("invalid genesis data: %w", err)
+	}
+
 	tx := CreateChainTx{
 		UnsignedCreateChainTx: UnsignedCreateChainTx{
 			NetworkID:   service.vm.Ctx.NetworkID,
@@ -1209,6 +1849,70 @@ func (service *Service) CreateBlockchain(_ *http.Request, args *CreateBlockchain
 	return nil
 }
 
+// lookupVM returns the IDs of the VM and feature extensions with the given
+// aliases
+func (service *Service) lookupVM(vmAlias string, fxAliases []string) (ids.ID, []ids.ID, error) {
+	vmID, err := service.vm.chainManager.LookupVM(vmAlias)
+	if err != nil {
+		return ids.ID{}, nil, fmt.Errorf("no VM with ID '%s' found", vmAlias)
+	}
+
+	fxIDs := []ids.ID(nil)
+	for _, fxIDStr := range fxAliases {
+		fxID, err := service.vm.chainManager.LookupVM(fxIDStr)
+		if err != nil {
+			return ids.ID{}, nil, fmt.Errorf("no FX with ID '%s' found", fxIDStr)
+		}
+		fxIDs = append(fxIDs, fxID)
+	}
+	// If creating AVM instance, use secp256k1fx
+	// TODO: Document FXs and have user specify them in API call
+	fxIDsSet := ids.Set{}
+	fxIDsSet.Add(fxIDs...)
+	if vmID.Equals(avm.ID) && !fxIDsSet.Contains(secp256k1fx.ID) {
+		fxIDs = append(fxIDs, secp256k1fx.ID)
+	}
+	return vmID, fxIDs, nil
+}
+
+// ValidateGenesisArgs are the arguments for calling ValidateGenesis
+type ValidateGenesisArgs struct {
+	// ID of the VM the blockchain would run
+	VMID string `json:"vmID"`
+
+	// IDs of the FXs the VM would run
+	FxIDs []string `json:"fxIDs"`
+
+	// Genesis state of the blockchain
+	GenesisData formatting.CB58 `json:"genesisData"`
+}
+
+// ValidateGenesisReply is the reply from calling ValidateGenesis
+type ValidateGenesisReply struct {
+	Valid bool `json:"valid"`
+}
+
+// ValidateGenesis returns an error describing why a blockchain running the
+// given VM couldn't be started from [args.GenesisData]. The VM must be
+// registered on this node. Nothing is issued and no fee is paid.
+func (service *Service) ValidateGenesis(_ *http.Request, args *ValidateGenesisArgs, reply *ValidateGenesisReply) error {
+	service.vm.Ctx.Log.Debug("validateGenesis called")
+
+	if args.VMID == "" {
+		return errors.New("VM not specified")
+	}
+
+	vmID, fxIDs, err := service.lookupVM(args.VMID, args.FxIDs)
+	if err != nil {
+		return err
+	}
+	if err := service.vm.validateGenesis(vmID, fxIDs, args.GenesisData.Bytes); err != nil {
+		return fmt.Errorf("invalid genesis data: %w", err)
+	}
+	reply.Valid = true
+	return nil
+}
+
 // GetBlockchainStatusArgs is the arguments for calling GetBlockchainStatus
 // [BlockchainID] is the blockchain to get the status of.
 type GetBlockchainStatusArgs struct {
@@ -1241,6 +1945,11 @@ func (service *Service) GetBlockchainStatus(_ *http.Request, args *GetBlockchain
 		return fmt.Errorf("problem parsing blockchainID '%s': %w", args.BlockchainID, err)
 	}
 
+	if service.vm.chainManager.IsIgnored(bID) {
+		reply.Status = Ignored
+		return nil
+	}
+
 	lastAcceptedID := service.vm.LastAccepted()
 	if exists, err := service.chainExists(lastAcceptedID, bID); err != nil {
 		return fmt.Errorf("problem looking up blockchain: %w", err)
@@ -1395,3 +2104,107 @@ func (service *Service) GetBlockchains(_ *http.Request, args *struct{}, response
 	}
 	return nil
 }
+
+/*
+ ******************************************************
+ ******************** Get Blocks **********************
+ ******************************************************
+ */
+
+// GetHeightReply is the response from GetHeight
+type GetHeightReply struct {
+	Height json.Uint64 `json:"height"`
+}
+
+// GetHeight returns the height of the last accepted block
+func (service *Service) GetHeight(_ *http.Request, _ *struct{}, reply *GetHeightReply) error {
+	service.vm.Ctx.Log.Debug("GetHeight called")
+
+	height, err := service.vm.GetBlockHeight(service.vm.LastAccepted())
+	if err != nil {
+		return fmt.Errorf("couldn't get the height of the last accepted block: %w", err)
+	}
+	reply.Height = json.Uint64(height)
+	return nil
+}
+
+// GetBlockArgs are the arguments to GetBlock. Exactly one of [ID] and [Height]
+// should be given.
+type GetBlockArgs struct {
+	ID     ids.ID       `json:"id"`
+	Height *json.Uint64 `json:"height"`
+}
+
+// GetBlockReply is the response from GetBlock
+type GetBlockReply struct {
+	ID       ids.ID         `json:"id"`
+	ParentID ids.ID         `json:"parentID"`
+	Status   choices.Status `json:"status"`
+
+	// Set only if the block has been accepted
+	Height *json.Uint64 `json:"height,omitempty"`
+
+	// One of "proposal", "commit", "abort", "standard" or "atomic"
+	Type string `json:"type"`
+
+	// The transactions in the block. Commit and abort blocks have none.
+	Txs []interface{} `json:"txs"`
+
+	Bytes formatting.CB58 `json:"bytes"`
+}
+
+// GetBlock returns the block with the given ID, or the accepted block at the
+// given height
+func (service *Service) GetBlock(_ *http.Request, args *GetBlockArgs, reply *GetBlockReply) error {
+	service.vm.Ctx.Log.Debug("GetBlock called")
+
+	blkID := args.ID
+	switch {
+	case args.ID.IsZero() && args.Height == nil:
+		return errors.New("either the block's ID or its height must be given")
+	case !args.ID.IsZero() && args.Height != nil:
+		return errors.New("only one of the block's ID and its height may be given")
+	case args.Height != nil:
+		id, err := service.vm.GetBlockIDAtHeight(uint64(*args.Height))
+		if err != nil {
+			return fmt.Errorf("couldn't get the block at height %d: %w", *args.Height, err)
+		}
+		blkID = id
+	}
+
+	blk, err := service.vm.getBlock(blkID)
+	if err != nil {
+		return fmt.Errorf("couldn't get block %s: %w", blkID, err)
+	}
+
+	reply.ID = blk.ID()
+	reply.ParentID = blk.Parent().ID()
+	reply.Status = blk.Status()
+	if height, err := service.vm.GetBlockHeight(blkID); err == nil {
+		h := json.Uint64(height)
+		reply.Height = &h
+	}
+	reply.Bytes = formatting.CB58{Bytes: blk.Bytes()}
+	reply.Txs = []interface{}{}
+
+	switch blk := blk.(type) {
+	case *ProposalBlock:
+		reply.Type = "proposal"
+		reply.Txs = append(reply.Txs, blk.Tx)
+	case *Commit:
+		reply.Type = "commit"
+	case *Abort:
+		reply.Type = "abort"
+	case *StandardBlock:
+		reply.Type = "standard"
+		for _, tx := range blk.Txs {
+			reply.Txs = append(reply.Txs, tx)
+		}
+	case *AtomicBlock:
+		reply.Type = "atomic"
+		reply.Txs = append(reply.Txs, blk.Tx)
+	default:
+		return fmt.Errorf("block %s has unexpected type %T", blkID, blk)
+	}
+	return nil
+}
@@ -5,11 +5,16 @@ package platformvm
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
+
+	"github.com/ava-labs/gecko/chains"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/formatting"
 )
 
 func TestAddDefaultSubnetValidator(t *testing.T) {
-	expectedJSONString := `{"startTime":"0","endTime":"0","id":null,"destination":null,"delegationFeeRate":"0","payerNonce":"0"}`
+	expectedJSONString := `{"startTime":"0","endTime":"0","id":null,"destination":"","delegationFeeRate":"0","payerNonce":"0"}`
 	args := AddDefaultSubnetValidatorArgs{}
 	bytes, err := json.Marshal(&args)
 	if err != nil {
@@ -32,3 +37,174 @@ func TestCreateBlockchainArgsParsing(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestGetAccountBech32(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+	vm.hrp = "local"
+	vm.bech32Addresses = true
+
+	service := Service{vm: vm}
+	addr := keys[0].PublicKey().Address()
+	bech32Addr, err := formatting.FormatBech32("local", addr.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, addrStr := range []string{addr.String(), bech32Addr} {
+		reply := GetAccountReply{}
+		if err := service.GetAccount(nil, &GetAccountArgs{Address: addrStr}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		if reply.Address != bech32Addr {
+			t.Fatalf("Expected address %s, got %s", bech32Addr, reply.Address)
+		}
+		if uint64(reply.Balance) != defaultBalance {
+			t.Fatalf("Expected balance %d, got %d", defaultBalance, reply.Balance)
+		}
+	}
+
+	otherNetworkAddr, err := formatting.FormatBech32("ava", addr.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := service.GetAccount(nil, &GetAccountArgs{Address: otherNetworkAddr}, &GetAccountReply{}); err == nil {
+		t.Fatal("should have rejected an address from a different network")
+	}
+}
+
+func TestGetCurrentValidatorsDelegationCapacity(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	// (note that defaultKey is a genesis validator)
+	nodeID := defaultKey.PublicKey().Address()
+	delegatorTx, err := vm.newAddDefaultSubnetDelegatorTx(
+		defaultNonce+1,
+		defaultStakeAmount,
+		uint64(defaultValidateStartTime.Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		nodeID,
+		nodeID,
+		testNetworkID,
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pendingValidators, err := vm.getPendingValidators(vm.DB, DefaultSubnetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pendingValidators.Add(delegatorTx)
+	if err := vm.putPendingValidators(vm.DB, pendingValidators, DefaultSubnetID); err != nil {
+		t.Fatal(err)
+	}
+
+	service := Service{vm: vm}
+	reply := GetCurrentValidatorsReply{}
+	if err := service.GetCurrentValidators(nil, &GetCurrentValidatorsArgs{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Validators) != len(keys) {
+		t.Fatalf("expected %d validators but got %d", len(keys), len(reply.Validators))
+	}
+	for _, vdr := range reply.Validators {
+		if vdr.DelegationFeeRate == nil || vdr.DelegationCapacity == nil {
+			t.Fatalf("expected validator %s to report its delegation fee rate and capacity", vdr.ID)
+		}
+		if uint32(*vdr.DelegationFeeRate) != NumberOfShares {
+			t.Fatalf("expected delegation fee rate %d but got %d", NumberOfShares, *vdr.DelegationFeeRate)
+		}
+
+		expectedCapacity := uint64(MaximumDelegationFactor * defaultStakeAmount)
+		if vdr.ID.Equals(nodeID) {
+			expectedCapacity -= defaultStakeAmount
+		}
+		if uint64(*vdr.DelegationCapacity) != expectedCapacity {
+			t.Fatalf("expected validator %s to have delegation capacity %d but got %d", vdr.ID, expectedCapacity, *vdr.DelegationCapacity)
+		}
+	}
+}
+
+// ignoringManager is a chains.Manager that doesn't run any chains, and ignores
+// [ignored]
+type ignoringManager struct {
+	chains.MockManager
+	ignored ids.ID
+}
+
+func (m ignoringManager) Lookup(alias string) (ids.ID, error) {
+	return ids.ID{}, errors.New("unknown chain")
+}
+
+func (m ignoringManager) IsIgnored(chainID ids.ID) bool {
+	return chainID.Equals(m.ignored)
+}
+
+func TestGetBlockchainStatusIgnored(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	ignoredID := ids.NewID([32]byte{1})
+	vm.chainManager = ignoringManager{ignored: ignoredID}
+	service := Service{vm: vm}
+
+	reply := GetBlockchainStatusReply{}
+	if err := service.GetBlockchainStatus(nil, &GetBlockchainStatusArgs{BlockchainID: ignoredID.String()}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Status != Ignored {
+		t.Fatalf("expected status %s but got %s", Ignored, reply.Status)
+	}
+
+	reply = GetBlockchainStatusReply{}
+	unknownID := ids.NewID([32]byte{2})
+	if err := service.GetBlockchainStatus(nil, &GetBlockchainStatusArgs{BlockchainID: unknownID.String()}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Status != Unknown {
+		t.Fatalf("expected status %s but got %s", Unknown, reply.Status)
+	}
+}
+
+func TestGetCurrentValidatorsBech32(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+	vm.hrp = "local"
+	vm.bech32Addresses = true
+
+	service := Service{vm: vm}
+	reply := GetCurrentValidatorsReply{}
+	if err := service.GetCurrentValidators(nil, &GetCurrentValidatorsArgs{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Validators) == 0 {
+		t.Fatal("expected the genesis validators")
+	}
+	for _, vdr := range reply.Validators {
+		hrp, _, err := formatting.ParseBech32(vdr.Destination)
+		if err != nil {
+			t.Fatalf("destination %q isn't bech32: %s", vdr.Destination, err)
+		}
+		if hrp != "local" {
+			t.Fatalf("expected HRP local, got %s", hrp)
+		}
+	}
+}
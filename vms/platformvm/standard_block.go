@@ -66,6 +66,9 @@ func (sb *StandardBlock) Verify() error {
 		if err != nil {
 			return err
 		}
+		if err := sb.vm.putTxStatus(sb.onAcceptDB, tx.ID(), CommittedTx); err != nil {
+			return err
+		}
 		if onAccept != nil {
 			funcs = append(funcs, onAccept)
 		}
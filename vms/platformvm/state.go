@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/gecko/database"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow/consensus/snowman"
+	"github.com/ava-labs/gecko/vms/components/state"
 )
 
 // This file contains methods of VM that deal with getting/putting values from database
@@ -59,10 +60,13 @@ func (vm *VM) getCurrentValidators(db database.Database, subnetID ids.ID) (*Even
 
 // put the validators currently validating the specified subnet
 func (vm *VM) putCurrentValidators(db database.Database, validators *EventHeap, subnetID ids.ID) error {
-	err := vm.State.Put(db, validatorsTypeID, subnetID.Prefix(currentValidatorsPrefix), validators)
-	if err != nil {
+	key := subnetID.Prefix(currentValidatorsPrefix)
+	if err := vm.State.Put(db, validatorsTypeID, key, validators); err != nil {
 		return errDBPutCurrentValidators
 	}
+	if err := vm.State.Put(db, amountTypeID, key, amount(sumWeights(validators))); err != nil {
+		return errDBPutStake
+	}
 	return nil
 }
 
@@ -97,13 +101,85 @@ func (vm *VM) putPendingValidators(db database.Database, validators *EventHeap,
 	if !validators.SortByStartTime {
 		return errors.New("pending validators should be sorted by start time")
 	}
-	err := vm.State.Put(db, validatorsTypeID, subnetID.Prefix(pendingValidatorsPrefix), validators)
-	if err != nil {
+	key := subnetID.Prefix(pendingValidatorsPrefix)
+	if err := vm.State.Put(db, validatorsTypeID, key, validators); err != nil {
 		return errDBPutPendingValidators
 	}
+	if err := vm.State.Put(db, amountTypeID, key, amount(sumWeights(validators))); err != nil {
+		return errDBPutStake
+	}
 	return nil
 }
 
+// get the total weight of the validators currently validating the specified
+// subnet
+func (vm *VM) getCurrentStake(db database.Database, subnetID ids.ID) (uint64, error) {
+	return vm.getStake(db, subnetID, currentValidatorsPrefix, vm.getCurrentValidators)
+}
+
+// get the total weight of the validators that are slated to validate the
+// specified subnet in the future
+func (vm *VM) getPendingStake(db database.Database, subnetID ids.ID) (uint64, error) {
+	return vm.getStake(db, subnetID, pendingValidatorsPrefix, vm.getPendingValidators)
+}
+
+// get the total weight that was put alongside the validators of [subnetID]
+// with prefix [prefix]. If it wasn't put, because the validators were last put
+// before the stake was tracked, it's summed from the validators returned by
+// [getValidators].
+func (vm *VM) getStake(
+	db database.Database,
+	subnetID ids.ID,
+	prefix uint64,
+	getValidators func(database.Database, ids.ID) (*EventHeap, error),
+) (uint64, error) {
+	key := subnetID.Prefix(prefix)
+	exists, err := vm.State.Has(db, amountTypeID, key)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		return vm.getAmount(db, key)
+	}
+	validators, err := getValidators(db, subnetID)
+	if err != nil {
+		return 0, err
+	}
+	return sumWeights(validators), nil
+}
+
+// put the amount of $AVA in existence in [db]
+func (vm *VM) putCurrentSupply(db database.Database, supply uint64) error {
+	if err := vm.State.Put(db, amountTypeID, currentSupplyKey, amount(supply)); err != nil {
+		return errDBPutCurrentSupply
+	}
+	return nil
+}
+
+// get the amount of $AVA in existence. This is the $AVA that existed at
+// genesis plus the staking rewards that have been minted since.
+func (vm *VM) getCurrentSupply(db database.Database) (uint64, error) {
+	supply, err := vm.getAmount(db, currentSupplyKey)
+	if err != nil {
+		return 0, errDBCurrentSupply
+	}
+	return supply, nil
+}
+
+// get the amount stored with key [key]
+func (vm *VM) getAmount(db database.Database, key ids.ID) (uint64, error) {
+	amountIntf, err := vm.State.Get(db, amountTypeID, key)
+	if err != nil {
+		return 0, err
+	}
+	a, ok := amountIntf.(amount)
+	if !ok {
+		vm.Ctx.Log.Warn("expected to retrieve amount from database but got different type")
+		return 0, errDB
+	}
+	return uint64(a), nil
+}
+
 // get the account with the specified Address
 // If account does not exist in database, return new account
 func (vm *VM) getAccount(db database.Database, address ids.ShortID) (Account, error) {
@@ -161,6 +237,42 @@ func (vm *VM) getChains(db database.Database) ([]*CreateChainTx, error) {
 	return chains, nil
 }
 
+// StoredChains returns the chains that have been created on the platform chain,
+// where [db] is the platform chain's VM database. The VM doesn't need to be
+// running.
+func StoredChains(db database.Database) ([]*CreateChainTx, error) {
+	vm := &VM{}
+	s := state.NewState()
+	unmarshalChainsFunc := func(bytes []byte) (interface{}, error) {
+		var chains []*CreateChainTx
+		if err := Codec.Unmarshal(bytes, &chains); err != nil {
+			return nil, err
+		}
+		for _, chain := range chains {
+			if err := chain.initialize(vm); err != nil {
+				return nil, err
+			}
+		}
+		return chains, nil
+	}
+	if err := s.RegisterType(chainsTypeID, unmarshalChainsFunc); err != nil {
+		return nil, err
+	}
+
+	chainsInterface, err := s.Get(db, chainsTypeID, chainsKey)
+	if err == database.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	chains, ok := chainsInterface.([]*CreateChainTx)
+	if !ok {
+		return nil, errDBChains
+	}
+	return chains, nil
+}
+
 // get a blockchain by its ID
 func (vm *VM) getChain(db database.Database, ID ids.ID) (*CreateChainTx, error) {
 	chains, err := vm.getChains(db)
@@ -240,6 +352,71 @@ func (vm *VM) getSubnet(db database.Database, id ids.ID) (*CreateSubnetTx, error
 	return nil, fmt.Errorf("couldn't find subnet with ID %s", id)
 }
 
+// put the control keys and threshold of the subnet with ID [subnetID] in [db]
+func (vm *VM) putSubnetControl(db database.Database, subnetID ids.ID, control *subnetControl) error {
+	if err := vm.State.Put(db, subnetControlTypeID, subnetID, control); err != nil {
+		return errDBPutSubnetControl
+	}
+	return nil
+}
+
+// get the current control keys and threshold of [subnet]. These are the ones
+// the subnet was created with, unless they have since been replaced.
+func (vm *VM) getSubnetControl(db database.Database, subnet *CreateSubnetTx) (*subnetControl, error) {
+	exists, err := vm.State.Has(db, subnetControlTypeID, subnet.id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &subnetControl{
+			ControlKeys: subnet.ControlKeys,
+			Threshold:   subnet.Threshold,
+		}, nil
+	}
+
+	controlIntf, err := vm.State.Get(db, subnetControlTypeID, subnet.id)
+	if err != nil {
+		return nil, err
+	}
+	control, ok := controlIntf.(*subnetControl)
+	if !ok {
+		vm.Ctx.Log.Warn("expected to retrieve *subnetControl from database but got different type")
+		return nil, errDB
+	}
+	return control, nil
+}
+
+// put the uptime of the staker added by the tx with ID [stakerTxID] in [db]
+func (vm *VM) putUptime(db database.Database, stakerTxID ids.ID, uptime *validatorUptime) error {
+	if err := vm.State.Put(db, uptimeTypeID, stakerTxID, uptime); err != nil {
+		return errDBPutUptime
+	}
+	return nil
+}
+
+// get the uptime of the staker added by the tx with ID [stakerTxID]. If none
+// has been recorded yet, returns an empty uptime.
+func (vm *VM) getUptime(db database.Database, stakerTxID ids.ID) (*validatorUptime, error) {
+	exists, err := vm.State.Has(db, uptimeTypeID, stakerTxID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &validatorUptime{}, nil
+	}
+
+	uptimeIntf, err := vm.State.Get(db, uptimeTypeID, stakerTxID)
+	if err != nil {
+		return nil, err
+	}
+	uptime, ok := uptimeIntf.(*validatorUptime)
+	if !ok {
+		vm.Ctx.Log.Warn("expected to retrieve *validatorUptime from database but got different type")
+		return nil, errDB
+	}
+	return uptime, nil
+}
+
 // register each type that we'll be storing in the database
 // so that [vm.State] knows how to unmarshal these types from bytes
 func (vm *VM) registerDBTypes() {
@@ -301,6 +478,61 @@ func (vm *VM) registerDBTypes() {
 	if err := vm.State.RegisterType(subnetsTypeID, unmarshalSubnetsFunc); err != nil {
 		vm.Ctx.Log.Warn(errRegisteringType.Error())
 	}
+
+	unmarshalSubnetControlFunc := func(bytes []byte) (interface{}, error) {
+		control := &subnetControl{}
+		if err := Codec.Unmarshal(bytes, control); err != nil {
+			return nil, err
+		}
+		return control, nil
+	}
+	if err := vm.State.RegisterType(subnetControlTypeID, unmarshalSubnetControlFunc); err != nil {
+		vm.Ctx.Log.Warn(errRegisteringType.Error())
+	}
+
+	unmarshalUptimeFunc := func(bytes []byte) (interface{}, error) {
+		uptime := &validatorUptime{}
+		if err := Codec.Unmarshal(bytes, uptime); err != nil {
+			return nil, err
+		}
+		return uptime, nil
+	}
+	if err := vm.State.RegisterType(uptimeTypeID, unmarshalUptimeFunc); err != nil {
+		vm.Ctx.Log.Warn(errRegisteringType.Error())
+	}
+
+	unmarshalAmountFunc := func(bytes []byte) (interface{}, error) {
+		var a uint64
+		if err := Codec.Unmarshal(bytes, &a); err != nil {
+			return nil, err
+		}
+		return amount(a), nil
+	}
+	if err := vm.State.RegisterType(amountTypeID, unmarshalAmountFunc); err != nil {
+		vm.Ctx.Log.Warn(errRegisteringType.Error())
+	}
+
+	unmarshalValidatorDiffsFunc := func(bytes []byte) (interface{}, error) {
+		diffs := &validatorDiffs{}
+		if err := Codec.Unmarshal(bytes, diffs); err != nil {
+			return nil, err
+		}
+		return diffs, nil
+	}
+	if err := vm.State.RegisterType(validatorDiffsTypeID, unmarshalValidatorDiffsFunc); err != nil {
+		vm.Ctx.Log.Warn(errRegisteringType.Error())
+	}
+
+	unmarshalTxStatusFunc := func(bytes []byte) (interface{}, error) {
+		var status uint32
+		if err := Codec.Unmarshal(bytes, &status); err != nil {
+			return nil, err
+		}
+		return TxStatus(status), nil
+	}
+	if err := vm.State.RegisterType(txStatusTypeID, unmarshalTxStatusFunc); err != nil {
+		vm.Ctx.Log.Warn(errRegisteringType.Error())
+	}
 }
 
 // Unmarshal a Block from bytes and initialize it
@@ -6,6 +6,7 @@ package platformvm
 import (
 	"container/heap"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/ava-labs/gecko/ids"
@@ -28,10 +29,10 @@ var (
 // StaticService defines the static API methods exposed by the platform VM
 type StaticService struct{}
 
-// APIAccount is an account on the Platform Chain
-// that exists at the chain's genesis.
+// APIAccount is an account on the Platform Chain.
+// [Address] is in bech32 or CB58.
 type APIAccount struct {
-	Address ids.ShortID `json:"address"`
+	Address string      `json:"address"`
 	Nonce   json.Uint64 `json:"nonce"`
 	Balance json.Uint64 `json:"balance"`
 }
@@ -65,7 +66,7 @@ func (v *APIValidator) weight() uint64 {
 type APIDefaultSubnetValidator struct {
 	APIValidator
 
-	Destination       ids.ShortID `json:"destination"`
+	Destination       string      `json:"destination"`
 	DelegationFeeRate json.Uint32 `json:"delegationFeeRate"`
 }
 
@@ -136,9 +137,13 @@ func (*StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, repl
 		if account.Balance == 0 {
 			return errAccountHasNoValue
 		}
+		address, err := parseGenesisAddress(account.Address)
+		if err != nil {
+			return fmt.Errorf("couldn't parse account address %q: %w", account.Address, err)
+		}
 		accounts = append(accounts, newAccount(
-			account.Address, // ID
-			0,               // nonce
+			address,                 // ID
+			0,                       // nonce
 			uint64(account.Balance), // balance
 		))
 	}
@@ -153,6 +158,10 @@ func (*StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, repl
 		if uint64(validator.EndTime) <= uint64(args.Time) {
 			return errValidatorAddsNoValue
 		}
+		destination, err := parseGenesisAddress(validator.Destination)
+		if err != nil {
+			return fmt.Errorf("couldn't parse validator destination %q: %w", validator.Destination, err)
+		}
 
 		tx := &addDefaultSubnetValidatorTx{
 			UnsignedAddDefaultSubnetValidatorTx: UnsignedAddDefaultSubnetValidatorTx{
@@ -166,7 +175,7 @@ func (*StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, repl
 				},
 				NetworkID:   uint32(args.NetworkID),
 				Nonce:       0,
-				Destination: validator.Destination,
+				Destination: destination,
 			},
 		}
 		if err := tx.initialize(nil); err != nil {
@@ -214,3 +223,13 @@ func (*StaticService) BuildGenesis(_ *http.Request, args *BuildGenesisArgs, repl
 	reply.Bytes.Bytes = bytes
 	return err
 }
+
+// parseGenesisAddress parses [addrStr], which may be either bech32 or CB58.
+// The genesis is built without knowing the network's HRP, so any HRP is
+// accepted.
+func parseGenesisAddress(addrStr string) (ids.ShortID, error) {
+	if _, b, err := formatting.ParseBech32(addrStr); err == nil {
+		return ids.ToShortID(b)
+	}
+	return ids.ShortFromString(addrStr)
+}
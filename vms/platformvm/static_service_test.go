@@ -13,7 +13,7 @@ import (
 func TestBuildGenesisInvalidAccountBalance(t *testing.T) {
 	id, _ := ids.ShortFromString("8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
 	account := APIAccount{
-		Address: id,
+		Address: id.String(),
 		Balance: 0,
 	}
 	weight := json.Uint64(987654321)
@@ -23,7 +23,7 @@ func TestBuildGenesisInvalidAccountBalance(t *testing.T) {
 			Weight:  &weight,
 			ID:      id,
 		},
-		Destination: id,
+		Destination: id.String(),
 	}
 
 	args := BuildGenesisArgs{
@@ -46,7 +46,7 @@ func TestBuildGenesisInvalidAccountBalance(t *testing.T) {
 func TestBuildGenesisInvalidAmount(t *testing.T) {
 	id, _ := ids.ShortFromString("8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
 	account := APIAccount{
-		Address: id,
+		Address: id.String(),
 		Balance: 123456789,
 	}
 	weight := json.Uint64(0)
@@ -57,7 +57,7 @@ func TestBuildGenesisInvalidAmount(t *testing.T) {
 			Weight:    &weight,
 			ID:        id,
 		},
-		Destination: id,
+		Destination: id.String(),
 	}
 
 	args := BuildGenesisArgs{
@@ -80,7 +80,7 @@ func TestBuildGenesisInvalidAmount(t *testing.T) {
 func TestBuildGenesisInvalidEndtime(t *testing.T) {
 	id, _ := ids.ShortFromString("8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
 	account := APIAccount{
-		Address: id,
+		Address: id.String(),
 		Balance: 123456789,
 	}
 
@@ -92,7 +92,7 @@ func TestBuildGenesisInvalidEndtime(t *testing.T) {
 			Weight:    &weight,
 			ID:        id,
 		},
-		Destination: id,
+		Destination: id.String(),
 	}
 
 	args := BuildGenesisArgs{
@@ -19,11 +19,13 @@ type Status uint32
 // [Preferred] means the operation is known and preferred, but hasn't been decided yet
 // [Created] means the operation occurred, but isn't managed locally
 // [Validating] means the operation was accepted and is managed locally
+// [Ignored] means the operation was accepted, but its subnet isn't whitelisted
 const (
 	Unknown Status = iota
 	Preferred
 	Created
 	Validating
+	Ignored
 )
 
 // MarshalJSON ...
@@ -49,6 +51,8 @@ func (s *Status) UnmarshalJSON(b []byte) error {
 		*s = Created
 	case "\"Validating\"":
 		*s = Validating
+	case "\"Ignored\"":
+		*s = Ignored
 	default:
 		return errUnknownStatus
 	}
@@ -58,7 +62,7 @@ func (s *Status) UnmarshalJSON(b []byte) error {
 // Valid returns nil if the status is a valid status.
 func (s Status) Valid() error {
 	switch s {
-	case Unknown, Preferred, Created, Validating:
+	case Unknown, Preferred, Created, Validating, Ignored:
 		return nil
 	default:
 		return errUnknownStatus
@@ -75,6 +79,8 @@ func (s Status) String() string {
 		return "Created"
 	case Validating:
 		return "Validating"
+	case Ignored:
+		return "Ignored"
 	default:
 		return "Invalid status"
 	}
@@ -9,7 +9,9 @@ import (
 )
 
 func TestStatusValid(t *testing.T) {
-	if err := Validating.Valid(); err != nil {
+	if err := Ignored.Valid(); err != nil {
+		t.Fatalf("%s failed verification", Ignored)
+	} else if err := Validating.Valid(); err != nil {
 		t.Fatalf("%s failed verification", Validating)
 	} else if err := Created.Valid(); err != nil {
 		t.Fatalf("%s failed verification", Created)
@@ -23,7 +25,9 @@ func TestStatusValid(t *testing.T) {
 }
 
 func TestStatusString(t *testing.T) {
-	if Validating.String() != "Validating" {
+	if Ignored.String() != "Ignored" {
+		t.Fatalf("%s failed printing", Ignored)
+	} else if Validating.String() != "Validating" {
 		t.Fatalf("%s failed printing", Validating)
 	} else if Created.String() != "Created" {
 		t.Fatalf("%s failed printing", Created)
@@ -0,0 +1,148 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	stdmath "math"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/utils/math"
+)
+
+// amount is a quantity of $AVA (or, on a non-default subnet, of weight) that
+// is stored in the database
+type amount uint64
+
+// Bytes returns the byte representation of [a]
+func (a amount) Bytes() []byte {
+	bytes, _ := Codec.Marshal(uint64(a))
+	return bytes
+}
+
+// sumWeights returns the total weight of the stakers in [stakers]. Weights of
+// non-default subnet validators are arbitrary, so the sum saturates at
+// MaxUint64 rather than overflowing.
+func sumWeights(stakers *EventHeap) uint64 {
+	total := uint64(0)
+	for _, staker := range stakers.Txs {
+		newTotal, err := math.Add64(total, staker.Vdr().Weight())
+		if err != nil {
+			return stdmath.MaxUint64
+		}
+		total = newTotal
+	}
+	return total
+}
+
+// stakerReward returns the $AVA minted if the default subnet staker added by
+// [tx] is rewarded. The reward of a delegator is split with its validator, but
+// the total is the same.
+func stakerReward(tx ProposalTx) (uint64, bool) {
+	switch tx := tx.(type) {
+	case *addDefaultSubnetValidatorTx:
+		return reward(tx.Duration(), tx.Wght, InflationRateNumerator, InflationRateDenominator), true
+	case *addDefaultSubnetDelegatorTx:
+		return reward(tx.Duration(), tx.Wght, InflationRateNumerator, InflationRateDenominator), true
+	default:
+		return 0, false
+	}
+}
+
+// genesisSupply returns the $AVA held by the accounts in [genesis] plus the
+// $AVA staked by its validators
+func genesisSupply(genesis *Genesis) (uint64, error) {
+	supply := sumWeights(genesis.Validators)
+	for _, account := range genesis.Accounts {
+		newSupply, err := math.Add64(supply, account.Balance)
+		if err != nil {
+			return 0, err
+		}
+		supply = newSupply
+	}
+	return supply, nil
+}
+
+// initCurrentSupply records the current supply in databases initialized before
+// the supply was tracked. That's the supply at genesis plus the rewards minted
+// by the rewardValidatorTxs that have been committed since.
+func (vm *VM) initCurrentSupply(genesisBytes []byte) error {
+	exists, err := vm.State.Has(vm.DB, amountTypeID, currentSupplyKey)
+	if err != nil || exists {
+		return err
+	}
+
+	genesis := &Genesis{}
+	if err := Codec.Unmarshal(genesisBytes, genesis); err != nil {
+		return err
+	}
+	if err := genesis.Initialize(); err != nil {
+		return err
+	}
+	supply, err := genesisSupply(genesis)
+	if err != nil {
+		return err
+	}
+
+	// staker tx ID --> $AVA minted if the staker is rewarded
+	rewards := make(map[[32]byte]uint64)
+	for _, staker := range genesis.Validators.Txs {
+		if tx, ok := staker.(ProposalTx); ok {
+			if minted, ok := stakerReward(tx); ok {
+				rewards[tx.ID().Key()] = minted
+			}
+		}
+	}
+
+	lastAcceptedHeight, err := vm.GetBlockHeight(vm.LastAccepted())
+	if err != nil {
+		return err
+	}
+	var parent Block
+	for height := uint64(0); height <= lastAcceptedHeight; height++ {
+		blkID, err := vm.GetBlockIDAtHeight(height)
+		if err != nil {
+			return err
+		}
+		blk, err := vm.getBlock(blkID)
+		if err != nil {
+			return err
+		}
+		switch blk := blk.(type) {
+		case *ProposalBlock:
+			if minted, ok := stakerReward(blk.Tx); ok {
+				rewards[blk.Tx.ID().Key()] = minted
+			}
+		case *Commit:
+			proposal, ok := parent.(*ProposalBlock)
+			if !ok {
+				break
+			}
+			if tx, ok := proposal.Tx.(*rewardValidatorTx); ok {
+				if supply, err = math.Add64(supply, rewards[tx.TxID.Key()]); err != nil {
+					return err
+				}
+			}
+		}
+		parent = blk
+	}
+
+	vm.Ctx.Log.Info("Initialized the current supply as %d", supply)
+	if err := vm.putCurrentSupply(vm.DB, supply); err != nil {
+		return err
+	}
+	return vm.DB.Commit()
+}
+
+// increaseCurrentSupply records in [db] that [minted] $AVA have been created
+func (vm *VM) increaseCurrentSupply(db database.Database, minted uint64) error {
+	supply, err := vm.getCurrentSupply(db)
+	if err != nil {
+		return err
+	}
+	newSupply, err := math.Add64(supply, minted)
+	if err != nil {
+		return err
+	}
+	return vm.putCurrentSupply(db, newSupply)
+}
@@ -0,0 +1,217 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+func TestGetCurrentSupply(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	// At genesis, the supply is the $AVA in the genesis accounts plus the $AVA
+	// staked by the genesis validators
+	service := Service{vm: vm}
+	reply := GetCurrentSupplyReply{}
+	if err := service.GetCurrentSupply(nil, nil, &reply); err != nil {
+		t.Fatal(err)
+	}
+	genesisSupply := uint64(len(keys)) * (defaultBalance + defaultStakeAmount)
+	if uint64(reply.Supply) != genesisSupply {
+		t.Fatalf("expected supply %d but got %d", genesisSupply, reply.Supply)
+	}
+
+	// Rewarding a validator mints its reward
+	tx := firstGenesisValidator(t, vm)
+	if err := vm.putTimestamp(vm.DB, defaultValidateEndTime); err != nil {
+		t.Fatal(err)
+	}
+	rewardTx, err := vm.newRewardValidatorTx(tx.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	onCommitDB, onAbortDB, _, _, err := rewardTx.SemanticVerify(vm.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	minted := reward(tx.Duration(), tx.Wght, InflationRateNumerator, InflationRateDenominator)
+	if minted == 0 {
+		t.Fatal("expected the validator to earn a reward")
+	}
+	if supply, err := vm.getCurrentSupply(onCommitDB); err != nil {
+		t.Fatal(err)
+	} else if supply != genesisSupply+minted {
+		t.Fatalf("expected supply %d after the reward but got %d", genesisSupply+minted, supply)
+	}
+	if supply, err := vm.getCurrentSupply(onAbortDB); err != nil {
+		t.Fatal(err)
+	} else if supply != genesisSupply {
+		t.Fatalf("expected supply %d without the reward but got %d", genesisSupply, supply)
+	}
+}
+
+func TestGetTotalStake(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	service := Service{vm: vm}
+	reply := GetTotalStakeReply{}
+	if err := service.GetTotalStake(nil, &GetTotalStakeArgs{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if expected := uint64(len(keys)) * defaultStakeAmount; uint64(reply.Stake) != expected {
+		t.Fatalf("expected stake %d but got %d", expected, reply.Stake)
+	}
+	if reply.PendingStake != 0 {
+		t.Fatalf("expected no pending stake but got %d", reply.PendingStake)
+	}
+
+	// The stake of a non-default subnet is the weight of its validators
+	addPendingSubnetValidator(t, vm, keys[0].PublicKey().Address())
+	if err := service.GetTotalStake(nil, &GetTotalStakeArgs{SubnetID: testSubnet1.id}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Stake != 0 {
+		t.Fatalf("expected no stake but got %d", reply.Stake)
+	}
+	if uint64(reply.PendingStake) != defaultWeight {
+		t.Fatalf("expected pending stake %d but got %d", defaultWeight, reply.PendingStake)
+	}
+}
+
+func TestGetStake(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx := firstGenesisValidator(t, vm)
+
+	service := Service{vm: vm}
+	reply := GetStakeReply{}
+	args := GetStakeArgs{Addresses: []string{tx.Destination.String()}}
+	if err := service.GetStake(nil, &args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if uint64(reply.Staked) != tx.Wght {
+		t.Fatalf("expected %d staked but got %d", tx.Wght, reply.Staked)
+	}
+	if reply.PendingStaked != 0 {
+		t.Fatalf("expected nothing pending but got %d", reply.PendingStaked)
+	}
+
+	args = GetStakeArgs{Addresses: []string{ids.NewShortID([20]byte{1}).String()}}
+	if err := service.GetStake(nil, &args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Staked != 0 {
+		t.Fatalf("expected nothing staked but got %d", reply.Staked)
+	}
+
+	args = GetStakeArgs{Addresses: []string{"not an address"}}
+	if err := service.GetStake(nil, &args, &reply); err == nil {
+		t.Fatal("should have failed because the address is invalid")
+	}
+}
+
+// Accepts the proposal block [blk] and then its commit option
+func acceptCommit(t *testing.T, blk *ProposalBlock) {
+	commit, ok := blk.Options()[0].(*Commit)
+	if !ok {
+		t.Fatal(errShouldPrefCommit)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	blk.Accept()
+	if err := commit.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	commit.Accept()
+}
+
+func TestInitCurrentSupply(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	// Advance the time to the end of the genesis validators' staking period
+	// and then reward the first of them
+	vm.clock.Set(defaultValidateEndTime)
+	tx := firstGenesisValidator(t, vm)
+	for i := 0; i < 2; i++ {
+		blk, err := vm.BuildBlock()
+		if err != nil {
+			t.Fatal(err)
+		}
+		acceptCommit(t, blk.(*ProposalBlock))
+	}
+	supply, err := vm.getCurrentSupply(vm.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	minted := reward(tx.Duration(), tx.Wght, InflationRateNumerator, InflationRateDenominator)
+	if genesisSupply := uint64(len(keys)) * (defaultBalance + defaultStakeAmount); supply != genesisSupply+minted {
+		t.Fatalf("expected supply %d but got %d", genesisSupply+minted, supply)
+	}
+
+	// A database from before the supply was tracked has no supply
+	if err := vm.State.Put(vm.DB, amountTypeID, currentSupplyKey, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := vm.getCurrentSupply(vm.DB); err == nil {
+		t.Fatal("expected the supply to be missing")
+	}
+	if err := vm.increaseCurrentSupply(vm.DB, 1); err == nil {
+		t.Fatal("shouldn't have increased a missing supply")
+	}
+
+	if err := vm.initCurrentSupply(defaultGenesis()); err != nil {
+		t.Fatal(err)
+	}
+	if initialized, err := vm.getCurrentSupply(vm.DB); err != nil {
+		t.Fatal(err)
+	} else if initialized != supply {
+		t.Fatalf("expected the supply to be initialized as %d but got %d", supply, initialized)
+	}
+}
+
+func TestGetStakeNotPut(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	// A database from before the stake was tracked has no stake, so it's
+	// summed from the validators
+	if err := vm.State.Put(vm.DB, amountTypeID, DefaultSubnetID.Prefix(currentValidatorsPrefix), nil); err != nil {
+		t.Fatal(err)
+	}
+	stake, err := vm.getCurrentStake(vm.DB, DefaultSubnetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := uint64(len(keys)) * defaultStakeAmount; stake != expected {
+		t.Fatalf("expected stake %d but got %d", expected, stake)
+	}
+}
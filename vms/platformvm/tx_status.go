@@ -0,0 +1,100 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+)
+
+var (
+	errUnknownTxStatus = errors.New("unknown tx status")
+)
+
+// TxStatus is the status of a transaction
+type TxStatus uint32
+
+// List of possible tx status values
+// [UnknownTx] Zero value, means the tx is not known
+// [PendingTx] means the tx is in this node's mempool
+// [ProcessingTx] means the tx is in a block that hasn't been decided yet
+// [CommittedTx] means the tx was accepted and its changes were made
+// [AbortedTx] means the tx was proposed, but its proposal was rejected
+// [DroppedTx] means the tx was removed from this node's mempool without being
+// accepted, because it was invalid or its start time passed
+const (
+	UnknownTx TxStatus = iota
+	PendingTx
+	ProcessingTx
+	CommittedTx
+	AbortedTx
+	DroppedTx
+)
+
+// MarshalJSON ...
+func (s TxStatus) MarshalJSON() ([]byte, error) {
+	if err := s.Valid(); err != nil {
+		return nil, err
+	}
+	return []byte("\"" + s.String() + "\""), nil
+}
+
+// UnmarshalJSON ...
+func (s *TxStatus) UnmarshalJSON(b []byte) error {
+	str := string(b)
+	if str == "null" {
+		return nil
+	}
+	switch str {
+	case "\"Unknown\"":
+		*s = UnknownTx
+	case "\"Pending\"":
+		*s = PendingTx
+	case "\"Processing\"":
+		*s = ProcessingTx
+	case "\"Committed\"":
+		*s = CommittedTx
+	case "\"Aborted\"":
+		*s = AbortedTx
+	case "\"Dropped\"":
+		*s = DroppedTx
+	default:
+		return errUnknownTxStatus
+	}
+	return nil
+}
+
+// Valid returns nil if the status is a valid status.
+func (s TxStatus) Valid() error {
+	switch s {
+	case UnknownTx, PendingTx, ProcessingTx, CommittedTx, AbortedTx, DroppedTx:
+		return nil
+	default:
+		return errUnknownTxStatus
+	}
+}
+
+// Bytes returns the byte representation of [s]
+func (s TxStatus) Bytes() []byte {
+	bytes, _ := Codec.Marshal(uint32(s))
+	return bytes
+}
+
+func (s TxStatus) String() string {
+	switch s {
+	case UnknownTx:
+		return "Unknown"
+	case PendingTx:
+		return "Pending"
+	case ProcessingTx:
+		return "Processing"
+	case CommittedTx:
+		return "Committed"
+	case AbortedTx:
+		return "Aborted"
+	case DroppedTx:
+		return "Dropped"
+	default:
+		return "Invalid status"
+	}
+}
@@ -0,0 +1,259 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/hashing"
+)
+
+var (
+	errUnknownControlKey   = errors.New("tx has control signature from key not in subnet's ControlKeys")
+	errDuplicateControlSig = errors.New("tx has more than one control signature from the same key")
+)
+
+// UnsignedUpdateSubnetControlKeysTx is an unsigned UpdateSubnetControlKeysTx
+type UnsignedUpdateSubnetControlKeysTx struct {
+	// ID of the network this tx was issued on
+	NetworkID uint32 `serialize:"true"`
+
+	// ID of the Subnet whose control keys are being replaced
+	SubnetID ids.ID `serialize:"true"`
+
+	// Next unused nonce of account paying the transaction fee for this transaction.
+	// The account's nonce is incremented when this transaction is accepted.
+	Nonce uint64 `serialize:"true"`
+
+	// The Subnet's new control keys and threshold. After this tx is accepted,
+	// a tx that requires the Subnet's control signatures must be signed with
+	// Threshold of these keys.
+	ControlKeys []ids.ShortID `serialize:"true"`
+	Threshold   uint16        `serialize:"true"`
+}
+
+// UpdateSubnetControlKeysTx replaces the control keys and threshold of a
+// Subnet. It must be signed by the Subnet's current threshold of control keys.
+type UpdateSubnetControlKeysTx struct {
+	UnsignedUpdateSubnetControlKeysTx `serialize:"true"`
+
+	// Address of the account that provides the transaction fee
+	// Set in SyntacticVerify
+	PayerAddress ids.ShortID
+
+	// Signatures from the Subnet's current control keys
+	ControlSigs [][crypto.SECP256K1RSigLen]byte `serialize:"true"`
+
+	// Signature of key whose account provides the transaction fee
+	PayerSig [crypto.SECP256K1RSigLen]byte `serialize:"true"`
+
+	vm    *VM
+	id    ids.ID
+	bytes []byte
+}
+
+func (tx *UpdateSubnetControlKeysTx) initialize(vm *VM) error {
+	tx.vm = vm
+	txBytes, err := Codec.Marshal(tx) // byte repr. of the signed tx
+	tx.bytes = txBytes
+	tx.id = ids.NewID(hashing.ComputeHash256Array(txBytes))
+	return err
+}
+
+// ID of this transaction
+func (tx *UpdateSubnetControlKeysTx) ID() ids.ID { return tx.id }
+
+// Bytes returns the byte representation of this transaction
+func (tx *UpdateSubnetControlKeysTx) Bytes() []byte { return tx.bytes }
+
+// SyntacticVerify this transaction is well-formed
+// Also populates [tx.PayerAddress] with the address of the key that pays the
+// transaction fee
+func (tx *UpdateSubnetControlKeysTx) SyntacticVerify() error {
+	switch {
+	case tx == nil:
+		return errNilTx
+	case !tx.PayerAddress.IsZero(): // Only verify the transaction once
+		return nil
+	case tx.NetworkID != tx.vm.Ctx.NetworkID: // verify the transaction is on this network
+		return errWrongNetworkID
+	case tx.id.IsZero():
+		return errInvalidID
+	case tx.SubnetID.Equals(DefaultSubnetID):
+		return errDSCantValidate
+	case tx.Threshold > uint16(len(tx.ControlKeys)):
+		return errThresholdExceedsKeysLen
+	case tx.Threshold > maxThreshold:
+		return errThresholdTooHigh
+	case tx.Threshold == 0 && len(tx.ControlKeys) > 0:
+		return errUnneededKeys
+	case !ids.IsSortedAndUniqueShortIDs(tx.ControlKeys):
+		return errControlKeysNotSortedAndUnique
+	case !crypto.IsSortedAndUniqueSECP2561RSigs(tx.ControlSigs):
+		return errControlSigsNotSortedAndUnique
+	}
+
+	unsignedIntf := interface{}(&tx.UnsignedUpdateSubnetControlKeysTx)
+	unsignedBytes, err := Codec.Marshal(&unsignedIntf) // byte repr of unsigned tx
+	if err != nil {
+		return err
+	}
+
+	payerKey, err := tx.vm.factory.RecoverPublicKey(unsignedBytes, tx.PayerSig[:])
+	if err != nil {
+		return err
+	}
+	tx.PayerAddress = payerKey.Address()
+
+	return nil
+}
+
+// SemanticVerify this transaction is valid.
+func (tx *UpdateSubnetControlKeysTx) SemanticVerify(db database.Database) (func(), error) {
+	if err := tx.SyntacticVerify(); err != nil {
+		return nil, err
+	}
+
+	unsignedIntf := interface{}(&tx.UnsignedUpdateSubnetControlKeysTx)
+	unsignedBytes, err := Codec.Marshal(&unsignedIntf) // Byte representation of the unsigned transaction
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.vm.verifyControlSigs(db, tx.SubnetID, unsignedBytes, tx.ControlSigs); err != nil {
+		return nil, err
+	}
+
+	// Deduct tx fee from payer's account
+	account, err := tx.vm.getAccount(db, tx.PayerAddress)
+	if err != nil {
+		return nil, err
+	}
+	account, err = account.Remove(0, tx.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.vm.putAccount(db, account); err != nil {
+		return nil, err
+	}
+
+	// Replace the Subnet's control keys
+	newControl := &subnetControl{
+		ControlKeys: tx.ControlKeys,
+		Threshold:   tx.Threshold,
+	}
+	if err := tx.vm.putSubnetControl(db, tx.SubnetID, newControl); err != nil {
+		return nil, err
+	}
+
+	return func() {}, nil
+}
+
+// verifyControlSigs returns nil if [controlSigs] are signatures over
+// [unsignedBytes] from the current threshold of distinct control keys of the
+// subnet with ID [subnetID]
+func (vm *VM) verifyControlSigs(db database.Database, subnetID ids.ID, unsignedBytes []byte, controlSigs [][crypto.SECP256K1RSigLen]byte) error {
+	subnet, err := vm.getSubnet(db, subnetID)
+	if err != nil {
+		return err
+	}
+	control, err := vm.getSubnetControl(db, subnet)
+	if err != nil {
+		return err
+	}
+
+	if len(controlSigs) != int(control.Threshold) {
+		return fmt.Errorf("expected tx to have %d control sigs but has %d", control.Threshold, len(controlSigs))
+	}
+
+	unsignedBytesHash := hashing.ComputeHash256(unsignedBytes)
+	controlKeys := ids.ShortSet{}
+	controlKeys.Add(control.ControlKeys...)
+	signers := ids.ShortSet{}
+	for _, sig := range controlSigs {
+		key, err := vm.factory.RecoverHashPublicKey(unsignedBytesHash, sig[:])
+		if err != nil {
+			return err
+		}
+		address := key.Address()
+		if !controlKeys.Contains(address) {
+			return errUnknownControlKey
+		}
+		// Otherwise one key could sign more than once to meet the threshold
+		if signers.Contains(address) {
+			return errDuplicateControlSig
+		}
+		signers.Add(address)
+	}
+	return nil
+}
+
+// [controlKeys] must be unique. They will be sorted by this method.
+// [currentControlKeys] are the Subnet's current control keys that sign this tx.
+func (vm *VM) newUpdateSubnetControlKeysTx(nonce uint64, subnetID ids.ID, controlKeys []ids.ShortID,
+	threshold uint16, networkID uint32, currentControlKeys []*crypto.PrivateKeySECP256K1R,
+	payerKey *crypto.PrivateKeySECP256K1R,
+) (*UpdateSubnetControlKeysTx, error) {
+	tx := &UpdateSubnetControlKeysTx{
+		UnsignedUpdateSubnetControlKeysTx: UnsignedUpdateSubnetControlKeysTx{
+			NetworkID:   networkID,
+			SubnetID:    subnetID,
+			Nonce:       nonce,
+			ControlKeys: controlKeys,
+			Threshold:   threshold,
+		},
+	}
+
+	// Sort control keys
+	ids.SortShortIDs(tx.ControlKeys)
+	// Ensure control keys are unique
+	if !ids.IsSortedAndUniqueShortIDs(tx.ControlKeys) {
+		return nil, errControlKeysNotSortedAndUnique
+	}
+
+	// Generate byte repr. of unsigned transaction
+	unsignedIntf := interface{}(&tx.UnsignedUpdateSubnetControlKeysTx)
+	unsignedBytes, err := Codec.Marshal(&unsignedIntf)
+	if err != nil {
+		return nil, err
+	}
+	unsignedBytesHash := hashing.ComputeHash256(unsignedBytes)
+
+	// Sign the tx with the current control keys
+	tx.ControlSigs = make([][crypto.SECP256K1RSigLen]byte, len(currentControlKeys))
+	for i, key := range currentControlKeys {
+		sig, err := key.SignHash(unsignedBytesHash)
+		if err != nil {
+			return nil, err
+		}
+		copy(tx.ControlSigs[i][:], sig)
+	}
+
+	// Sort the control signatures
+	crypto.SortSECP2561RSigs(tx.ControlSigs)
+
+	// Sign with the payer key
+	payerSig, err := payerKey.Sign(unsignedBytes)
+	if err != nil {
+		return nil, err
+	}
+	copy(tx.PayerSig[:], payerSig)
+
+	return tx, tx.initialize(vm)
+}
+
+// subnetControl is the set of keys that control a subnet
+type subnetControl struct {
+	ControlKeys []ids.ShortID `serialize:"true"`
+	Threshold   uint16        `serialize:"true"`
+}
+
+// Bytes returns the byte representation of [control]
+func (control *subnetControl) Bytes() []byte {
+	bytes, _ := Codec.Marshal(control)
+	return bytes
+}
@@ -0,0 +1,367 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/gecko/database/versiondb"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/hashing"
+	"github.com/ava-labs/gecko/vms/timestampvm"
+)
+
+// test method SyntacticVerify
+func TestUpdateSubnetControlKeysTxSyntacticVerify(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	// Case 1: tx is nil
+	var tx *UpdateSubnetControlKeysTx
+	if err := tx.SyntacticVerify(); err == nil {
+		t.Fatal("should have failed because tx is nil")
+	}
+
+	newKeys := []ids.ShortID{keys[3].PublicKey().Address(), keys[4].PublicKey().Address()}
+	signers := []*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]}
+
+	// Case 2: network ID is wrong
+	tx, err := vm.newUpdateSubnetControlKeysTx(defaultNonce+1, testSubnet1.id, newKeys, 1, testNetworkID+1, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(); err == nil {
+		t.Fatal("should've errored because network ID is wrong")
+	}
+
+	// Case 3: the default subnet has no control keys
+	tx, err = vm.newUpdateSubnetControlKeysTx(defaultNonce+1, DefaultSubnetID, newKeys, 1, testNetworkID, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(); err == nil {
+		t.Fatal("should've errored because the default subnet has no control keys")
+	}
+
+	// Case 4: threshold is more than the number of keys
+	tx, err = vm.newUpdateSubnetControlKeysTx(defaultNonce+1, testSubnet1.id, newKeys, 3, testNetworkID, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(); err != errThresholdExceedsKeysLen {
+		t.Fatalf("should've errored with %s but got %v", errThresholdExceedsKeysLen, err)
+	}
+
+	// Case 5: threshold is 0 but there are keys
+	tx, err = vm.newUpdateSubnetControlKeysTx(defaultNonce+1, testSubnet1.id, newKeys, 0, testNetworkID, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(); err != errUnneededKeys {
+		t.Fatalf("should've errored with %s but got %v", errUnneededKeys, err)
+	}
+
+	// Case 6: valid
+	tx, err = vm.newUpdateSubnetControlKeysTx(defaultNonce+1, testSubnet1.id, newKeys, 1, testNetworkID, signers, defaultKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SyntacticVerify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// test method SemanticVerify
+func TestUpdateSubnetControlKeysTxSemanticVerify(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	newKeys := []ids.ShortID{keys[3].PublicKey().Address(), keys[4].PublicKey().Address()}
+
+	// Case 1: too few control signatures
+	tx, err := vm.newUpdateSubnetControlKeysTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		newKeys,
+		1,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.SemanticVerify(versiondb.New(vm.DB)); err == nil {
+		t.Fatal("should have failed because there are too few control signatures")
+	}
+
+	// Case 2: signed by keys that don't control the subnet
+	tx, err = vm.newUpdateSubnetControlKeysTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		newKeys,
+		1,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{keys[3], keys[4]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.SemanticVerify(versiondb.New(vm.DB)); err != errUnknownControlKey {
+		t.Fatalf("should have failed with %s but got %v", errUnknownControlKey, err)
+	}
+
+	// Case 3: one control key signs twice, with different nonces, to meet
+	// the threshold of 2
+	tx, err = vm.newUpdateSubnetControlKeysTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		newKeys,
+		1,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsignedIntf := interface{}(&tx.UnsignedUpdateSubnetControlKeysTx)
+	unsignedBytes, err := Codec.Marshal(&unsignedIntf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.ControlSigs = [][crypto.SECP256K1RSigLen]byte{
+		signWithNonce(t, testSubnet1ControlKeys[0], unsignedBytes, 1),
+		signWithNonce(t, testSubnet1ControlKeys[0], unsignedBytes, 2),
+	}
+	crypto.SortSECP2561RSigs(tx.ControlSigs)
+	if _, err := tx.SemanticVerify(versiondb.New(vm.DB)); err != errDuplicateControlSig {
+		t.Fatalf("should have failed with %s but got %v", errDuplicateControlSig, err)
+	}
+
+	// Case 4: subnet doesn't exist
+	tx, err = vm.newUpdateSubnetControlKeysTx(
+		defaultNonce+1,
+		ids.NewID([32]byte{1}),
+		newKeys,
+		1,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.SemanticVerify(versiondb.New(vm.DB)); err == nil {
+		t.Fatal("should have failed because the subnet doesn't exist")
+	}
+
+	// Case 5: valid
+	tx, err = vm.newUpdateSubnetControlKeysTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		newKeys,
+		1,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		defaultKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := versiondb.New(vm.DB)
+	if _, err := tx.SemanticVerify(db); err != nil {
+		t.Fatal(err)
+	}
+
+	control, err := vm.getSubnetControl(db, testSubnet1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if control.Threshold != 1 {
+		t.Fatalf("expected threshold 1 but got %d", control.Threshold)
+	}
+	if len(control.ControlKeys) != len(newKeys) {
+		t.Fatalf("expected %d control keys but got %d", len(newKeys), len(control.ControlKeys))
+	}
+	for i, key := range control.ControlKeys {
+		if !key.Equals(tx.ControlKeys[i]) {
+			t.Fatalf("expected control key %s but got %s", tx.ControlKeys[i], key)
+		}
+	}
+}
+
+// test that after a subnet's control keys are replaced, only the new keys can
+// create chains on it
+func TestUpdateSubnetControlKeys(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx, err := vm.newUpdateSubnetControlKeysTx(
+		defaultNonce+1,
+		testSubnet1.id,
+		[]ids.ShortID{keys[3].PublicKey().Address(), keys[4].PublicKey().Address()},
+		1,
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0],
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm.unissuedDecisionTxs = append(vm.unissuedDecisionTxs, tx)
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	blk.Accept()
+
+	// The old control keys can no longer create a chain
+	oldKeysTx, err := vm.newCreateChainTx(
+		defaultNonce+2,
+		testSubnet1.id,
+		nil,
+		timestampvm.ID,
+		nil,
+		"name",
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{testSubnet1ControlKeys[0], testSubnet1ControlKeys[1]},
+		keys[0],
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oldKeysTx.SemanticVerify(versiondb.New(vm.DB)); err == nil {
+		t.Fatal("should have failed because the chain is signed by the old control keys")
+	}
+
+	// The new control keys can
+	newKeysTx, err := vm.newCreateChainTx(
+		defaultNonce+2,
+		testSubnet1.id,
+		nil,
+		timestampvm.ID,
+		nil,
+		"name",
+		testNetworkID,
+		[]*crypto.PrivateKeySECP256K1R{keys[4]},
+		keys[0],
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newKeysTx.SemanticVerify(versiondb.New(vm.DB)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The API reports the new control keys
+	service := Service{vm: vm}
+	reply := GetSubnetsResponse{}
+	if err := service.GetSubnets(nil, &GetSubnetsArgs{IDs: []ids.ID{testSubnet1.id}}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Subnets) != 1 {
+		t.Fatalf("expected 1 subnet but got %d", len(reply.Subnets))
+	}
+	if subnet := reply.Subnets[0]; subnet.Threshold != 1 || len(subnet.ControlKeys) != 2 {
+		t.Fatalf("expected the subnet's new control keys but got %v with threshold %d", subnet.ControlKeys, subnet.Threshold)
+	}
+}
+
+var (
+	secp256k1P, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+	secp256k1N, _  = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+	secp256k1Gx, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
+	secp256k1Gy, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
+)
+
+// signWithNonce signs [msg] with [key] using the nonce [k] rather than the
+// deterministic nonce used by [key.Sign], so that a key can make more than one
+// valid signature over the same message
+func signWithNonce(t *testing.T, key *crypto.PrivateKeySECP256K1R, msg []byte, k int64) [crypto.SECP256K1RSigLen]byte {
+	// add returns the sum of the points (x1, y1) and (x2, y2), where the
+	// point at infinity is nil
+	add := func(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+		if x1 == nil {
+			return x2, y2
+		}
+		p := secp256k1P
+		slope := new(big.Int)
+		if x1.Cmp(x2) == 0 && y1.Cmp(y2) == 0 {
+			// slope = 3x^2 / 2y
+			slope.Mul(x1, x1).Mul(slope, big.NewInt(3))
+			slope.Mul(slope, new(big.Int).ModInverse(new(big.Int).Lsh(y1, 1), p))
+		} else {
+			// slope = (y2 - y1) / (x2 - x1)
+			slope.Sub(y2, y1)
+			slope.Mul(slope, new(big.Int).ModInverse(new(big.Int).Mod(new(big.Int).Sub(x2, x1), p), p))
+		}
+		slope.Mod(slope, p)
+		x := new(big.Int).Mul(slope, slope)
+		x.Sub(x, x1).Sub(x, x2).Mod(x, p)
+		y := new(big.Int).Sub(x1, x)
+		y.Mul(y, slope).Sub(y, y1).Mod(y, p)
+		return x, y
+	}
+
+	// R = kG
+	var rx, ry *big.Int
+	bx, by := secp256k1Gx, secp256k1Gy
+	for scalar := big.NewInt(k); scalar.Sign() > 0; scalar.Rsh(scalar, 1) {
+		if scalar.Bit(0) == 1 {
+			rx, ry = add(rx, ry, bx, by)
+		}
+		bx, by = add(bx, by, bx, by)
+	}
+
+	n := secp256k1N
+	r := new(big.Int).Mod(rx, n)
+	recoveryID := byte(ry.Bit(0))
+
+	// s = (hash + r * key) / k
+	e := new(big.Int).SetBytes(hashing.ComputeHash256(msg))
+	d := new(big.Int).SetBytes(key.Bytes())
+	s := new(big.Int).Mul(r, d)
+	s.Add(s, e).Mul(s, new(big.Int).ModInverse(big.NewInt(k), n)).Mod(s, n)
+	// Signatures must have a low s
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s.Sub(n, s)
+		recoveryID ^= 1
+	}
+
+	sig := [crypto.SECP256K1RSigLen]byte{}
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+	copy(sig[32-len(rBytes):32], rBytes)
+	copy(sig[64-len(sBytes):64], sBytes)
+	sig[64] = recoveryID
+
+	factory := crypto.FactorySECP256K1R{}
+	pk, err := factory.RecoverPublicKey(msg, sig[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pk.Address().Equals(key.PublicKey().Address()) {
+		t.Fatal("signature recovered the wrong key")
+	}
+	return sig
+}
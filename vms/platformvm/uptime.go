@@ -0,0 +1,102 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"time"
+)
+
+// validatorUptime is how long a default subnet validator was connected to this
+// node during its staking period
+type validatorUptime struct {
+	// Seconds the validator was connected between the start of its staking
+	// period and LastUpdated
+	UpDuration uint64 `serialize:"true"`
+
+	// Unix time up to which UpDuration has been recorded. 0 if it has never
+	// been recorded.
+	LastUpdated uint64 `serialize:"true"`
+}
+
+// Bytes returns the byte representation of [uptime]
+func (uptime *validatorUptime) Bytes() []byte {
+	bytes, _ := Codec.Marshal(uptime)
+	return bytes
+}
+
+// calculateUptime returns how long the validator added by [tx] was connected to
+// this node during its staking period, and how much of its staking period had
+// elapsed, as of [now]
+func (vm *VM) calculateUptime(tx *addDefaultSubnetValidatorTx, now time.Time) (time.Duration, time.Duration, error) {
+	startTime := tx.StartTime()
+	endTime := tx.EndTime()
+	if now.Before(endTime) {
+		endTime = now
+	}
+	if !endTime.After(startTime) {
+		return 0, 0, nil
+	}
+	elapsed := endTime.Sub(startTime)
+
+	uptime, err := vm.getUptime(vm.uptimeDB, tx.ID())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The up time before [lastUpdated] has been persisted. The up time after
+	// it is still in [vm.uptimes].
+	lastUpdated := startTime
+	if persistedTime := time.Unix(int64(uptime.LastUpdated), 0); persistedTime.After(lastUpdated) {
+		lastUpdated = persistedTime
+	}
+	upDuration := time.Duration(uptime.UpDuration)*time.Second + vm.uptimes.UpDuration(tx.NodeID, lastUpdated, endTime)
+	if upDuration > elapsed {
+		upDuration = elapsed
+	}
+	return upDuration, elapsed, nil
+}
+
+// meetsUptimeRequirement returns true if the validator added by [tx] was
+// connected to this node for at least [vm.uptimeRequirement] of the elapsed
+// part of its staking period
+func (vm *VM) meetsUptimeRequirement(tx *addDefaultSubnetValidatorTx) (bool, error) {
+	upDuration, elapsed, err := vm.calculateUptime(tx, vm.clock.Time())
+	if err != nil {
+		return false, err
+	}
+	if elapsed == 0 {
+		return true, nil
+	}
+	return float64(upDuration)/float64(elapsed) >= vm.uptimeRequirement, nil
+}
+
+// flushUptimes persists the uptime of each current default subnet validator, so
+// [vm.uptimes] no longer needs to remember the connections that have ended
+func (vm *VM) flushUptimes() error {
+	now := time.Unix(int64(vm.clock.Unix()), 0)
+
+	currentValidators, err := vm.getCurrentValidators(vm.DB, DefaultSubnetID)
+	if err != nil {
+		return err
+	}
+	for _, staker := range currentValidators.Txs {
+		tx, ok := staker.(*addDefaultSubnetValidatorTx)
+		if !ok {
+			continue
+		}
+		upDuration, _, err := vm.calculateUptime(tx, now)
+		if err != nil {
+			return err
+		}
+		uptime := &validatorUptime{
+			UpDuration:  uint64(upDuration / time.Second),
+			LastUpdated: uint64(now.Unix()),
+		}
+		if err := vm.putUptime(vm.uptimeDB, tx.ID(), uptime); err != nil {
+			return err
+		}
+	}
+	vm.uptimes.Prune(now)
+	return nil
+}
@@ -0,0 +1,170 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/gecko/ids"
+)
+
+// Returns the genesis validator that will be rewarded first
+func firstGenesisValidator(t *testing.T, vm *VM) *addDefaultSubnetValidatorTx {
+	currentValidators, err := vm.getCurrentValidators(vm.DB, DefaultSubnetID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, ok := currentValidators.Peek().(*addDefaultSubnetValidatorTx)
+	if !ok {
+		t.Fatal("expected the genesis validator to be an *addDefaultSubnetValidatorTx")
+	}
+	return tx
+}
+
+func TestCalculateUptime(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	tx := firstGenesisValidator(t, vm)
+
+	// Connected for the first and third hours of the staking period
+	vm.uptimes.Clock.Set(defaultValidateStartTime)
+	vm.uptimes.Connected(tx.NodeID)
+	vm.uptimes.Clock.Set(defaultValidateStartTime.Add(time.Hour))
+	vm.uptimes.Disconnected(tx.NodeID)
+	vm.uptimes.Clock.Set(defaultValidateStartTime.Add(2 * time.Hour))
+	vm.uptimes.Connected(tx.NodeID)
+	vm.uptimes.Clock.Set(defaultValidateStartTime.Add(3 * time.Hour))
+
+	now := defaultValidateStartTime.Add(4 * time.Hour)
+	upDuration, elapsed, err := vm.calculateUptime(tx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upDuration != 2*time.Hour {
+		t.Fatalf("expected 2h of up time but got %s", upDuration)
+	}
+	if elapsed != 4*time.Hour {
+		t.Fatalf("expected 4h to have elapsed but got %s", elapsed)
+	}
+
+	// Persisting the uptime shouldn't change it
+	vm.clock.Set(defaultValidateStartTime.Add(3 * time.Hour))
+	if err := vm.flushUptimes(); err != nil {
+		t.Fatal(err)
+	}
+	upDuration, _, err = vm.calculateUptime(tx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upDuration != 2*time.Hour {
+		t.Fatalf("expected 2h of up time after flushing but got %s", upDuration)
+	}
+
+	uptime, err := vm.getUptime(vm.uptimeDB, tx.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if persisted, err := vm.State.Has(vm.DB, uptimeTypeID, tx.ID()); err != nil {
+		t.Fatal(err)
+	} else if persisted {
+		t.Fatal("uptime shouldn't be persisted in the chain's state")
+	}
+	if uptime.UpDuration != uint64((2 * time.Hour).Seconds()) {
+		t.Fatalf("expected 2h of up time to be persisted but got %ds", uptime.UpDuration)
+	}
+
+	// Up time after the end of the staking period isn't counted
+	vm.uptimes.Clock.Set(defaultValidateEndTime.Add(time.Hour))
+	upDuration, elapsed, err = vm.calculateUptime(tx, defaultValidateEndTime.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := tx.Duration() - time.Hour; upDuration != expected {
+		t.Fatalf("expected %s of up time but got %s", expected, upDuration)
+	}
+	if elapsed != tx.Duration() {
+		t.Fatalf("expected %s to have elapsed but got %s", tx.Duration(), elapsed)
+	}
+}
+
+func TestRewardValidatorTxInitiallyPrefersCommit(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	vm.uptimeRequirement = .6
+	tx := firstGenesisValidator(t, vm)
+	rewardTx, err := vm.newRewardValidatorTx(tx.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Connected for the first half of the staking period
+	midpoint := tx.StartTime().Add(tx.Duration() / 2)
+	vm.uptimes.Clock.Set(tx.StartTime())
+	vm.uptimes.Connected(tx.NodeID)
+	vm.uptimes.Clock.Set(midpoint)
+	vm.uptimes.Disconnected(tx.NodeID)
+
+	vm.clock.Set(tx.EndTime())
+	if rewardTx.InitiallyPrefersCommit() {
+		t.Fatal("shouldn't prefer rewarding a validator that was connected for half of its staking period")
+	}
+
+	// Connected for the first half and the last quarter of the staking period
+	vm.uptimes.Clock.Set(midpoint.Add(tx.Duration() / 4))
+	vm.uptimes.Connected(tx.NodeID)
+	vm.uptimes.Clock.Set(tx.EndTime())
+	if !rewardTx.InitiallyPrefersCommit() {
+		t.Fatal("should prefer rewarding a validator that was connected for 3/4 of its staking period")
+	}
+}
+
+func TestGetUptime(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	vm.uptimeRequirement = .6
+	tx := firstGenesisValidator(t, vm)
+
+	vm.uptimes.Clock.Set(defaultValidateStartTime.Add(time.Hour))
+	vm.uptimes.Connected(tx.NodeID)
+	vm.uptimes.Clock.Set(defaultValidateStartTime.Add(4 * time.Hour))
+	vm.clock.Set(defaultValidateStartTime.Add(4 * time.Hour))
+
+	service := Service{vm: vm}
+	reply := GetUptimeReply{}
+	if err := service.GetUptime(nil, &GetUptimeArgs{ID: tx.NodeID}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case uint64(reply.ElapsedDuration) != uint64((4 * time.Hour).Seconds()):
+		t.Fatalf("expected 4h to have elapsed but got %ds", reply.ElapsedDuration)
+	case uint64(reply.UpDuration) != uint64((3 * time.Hour).Seconds()):
+		t.Fatalf("expected 3h of up time but got %ds", reply.UpDuration)
+	case reply.Uptime != .75:
+		t.Fatalf("expected uptime .75 but got %f", reply.Uptime)
+	case reply.UptimeRequirement != .6:
+		t.Fatalf("expected uptime requirement .6 but got %f", reply.UptimeRequirement)
+	case !reply.Connected:
+		t.Fatal("expected the validator to be connected")
+	}
+
+	if err := service.GetUptime(nil, &GetUptimeArgs{ID: ids.NewShortID([20]byte{1})}, &reply); err == nil {
+		t.Fatal("should have failed because the node isn't a validator")
+	}
+}
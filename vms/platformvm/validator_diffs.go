@@ -0,0 +1,205 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/validators"
+	"github.com/ava-labs/gecko/vms/components/state"
+)
+
+var (
+	// Key under which the height of the first recorded validator diff is
+	// stored. Validator sets before this height can't be reconstructed.
+	validatorDiffsStartKey = ids.NewID([32]byte{'d', 'i', 'f', 'f', 's'})
+
+	errHeightNotAccepted          = errors.New("no block has been accepted at that height")
+	errValidatorDiffsNotAvailable = errors.New("validator sets weren't recorded at that height")
+)
+
+// validatorWeightDiff is a change of one validator's weight in a subnet
+type validatorWeightDiff struct {
+	NodeID ids.ShortID `serialize:"true"`
+
+	// The validator's weight before and after the change. 0 if the node
+	// wasn't a validator.
+	Before uint64 `serialize:"true"`
+	After  uint64 `serialize:"true"`
+}
+
+// validatorDiffs are the changes to a subnet's validator set that were made
+// when a block was accepted
+type validatorDiffs struct {
+	Diffs []validatorWeightDiff `serialize:"true"`
+}
+
+// Bytes returns the byte representation of [diffs]
+func (diffs *validatorDiffs) Bytes() []byte {
+	bytes, _ := Codec.Marshal(diffs)
+	return bytes
+}
+
+// validatorDiffsKey returns the key of the changes to the validator set of
+// [subnetID] at [height]
+func validatorDiffsKey(subnetID ids.ID, height uint64) ids.ID {
+	return subnetID.Prefix(height)
+}
+
+// weights returns the weight of each validator in [vdrs]
+func weights(vdrs []validators.Validator) map[[20]byte]uint64 {
+	weights := make(map[[20]byte]uint64, len(vdrs))
+	for _, vdr := range vdrs {
+		weights[vdr.ID().Key()] = vdr.Weight()
+	}
+	return weights
+}
+
+// initValidatorDiffs records the height from which validator sets can be
+// reconstructed, if it hasn't been recorded yet. Nodes that accepted blocks
+// before validator diffs were recorded can't reconstruct the earlier sets.
+func (vm *VM) initValidatorDiffs() error {
+	exists, err := vm.State.Has(vm.DB, state.Uint64TypeID, validatorDiffsStartKey)
+	if err != nil || exists {
+		return err
+	}
+	height, err := vm.GetBlockHeight(vm.LastAccepted())
+	if err != nil {
+		return err
+	}
+	if err := vm.State.PutUint64(vm.DB, validatorDiffsStartKey, height); err != nil {
+		return err
+	}
+	return vm.DB.Commit()
+}
+
+// recordValidatorDiffs persists the change of the validator set of
+// [subnetID] from [previous] to [current] at the height of the last accepted
+// block
+func (vm *VM) recordValidatorDiffs(subnetID ids.ID, previous, current []validators.Validator) error {
+	height, err := vm.GetBlockHeight(vm.LastAccepted())
+	if err != nil {
+		return err
+	}
+
+	// The validator set may change more than once at the same height. If so,
+	// the recorded diffs span all of the changes.
+	diffs, err := vm.getValidatorDiffs(vm.DB, subnetID, height)
+	if err != nil {
+		return err
+	}
+	before := weights(previous)
+	for _, diff := range diffs.Diffs {
+		before[diff.NodeID.Key()] = diff.Before
+	}
+	after := weights(current)
+
+	newDiffs := &validatorDiffs{}
+	for key, weight := range before {
+		if weight != after[key] {
+			newDiffs.Diffs = append(newDiffs.Diffs, validatorWeightDiff{
+				NodeID: ids.NewShortID(key),
+				Before: weight,
+				After:  after[key],
+			})
+		}
+	}
+	for key, weight := range after {
+		if _, exists := before[key]; !exists && weight != 0 {
+			newDiffs.Diffs = append(newDiffs.Diffs, validatorWeightDiff{
+				NodeID: ids.NewShortID(key),
+				After:  weight,
+			})
+		}
+	}
+	if len(newDiffs.Diffs) == 0 && len(diffs.Diffs) == 0 {
+		return nil
+	}
+
+	if err := vm.putValidatorDiffs(vm.DB, subnetID, height, newDiffs); err != nil {
+		return err
+	}
+	return vm.DB.Commit()
+}
+
+// GetValidatorSetAt returns the validators of [subnetID], and their weights,
+// after the block at [height] was accepted
+func (vm *VM) GetValidatorSetAt(subnetID ids.ID, height uint64) ([]validators.Validator, error) {
+	lastHeight, err := vm.GetBlockHeight(vm.LastAccepted())
+	if err != nil {
+		return nil, err
+	}
+	if height > lastHeight {
+		return nil, fmt.Errorf("%w: %d", errHeightNotAccepted, height)
+	}
+	startHeight, err := vm.State.GetUint64(vm.DB, validatorDiffsStartKey)
+	if err != nil {
+		return nil, err
+	}
+	if height < startHeight {
+		return nil, fmt.Errorf("%w: %d", errValidatorDiffsNotAvailable, height)
+	}
+
+	currentValidators, err := vm.getCurrentValidators(vm.DB, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	vdrWeights := weights(vm.getValidators(currentValidators))
+
+	// Undo the changes made after [height], most recent first
+	for h := lastHeight; h > height; h-- {
+		diffs, err := vm.getValidatorDiffs(vm.DB, subnetID, h)
+		if err != nil {
+			return nil, err
+		}
+		for _, diff := range diffs.Diffs {
+			if diff.Before == 0 {
+				delete(vdrWeights, diff.NodeID.Key())
+			} else {
+				vdrWeights[diff.NodeID.Key()] = diff.Before
+			}
+		}
+	}
+
+	vdrs := make([]validators.Validator, 0, len(vdrWeights))
+	for key, weight := range vdrWeights {
+		vdrs = append(vdrs, &Validator{
+			NodeID: ids.NewShortID(key),
+			Wght:   weight,
+		})
+	}
+	return vdrs, nil
+}
+
+// put the changes to the validator set of [subnetID] at [height] in [db]
+func (vm *VM) putValidatorDiffs(db database.Database, subnetID ids.ID, height uint64, diffs *validatorDiffs) error {
+	return vm.State.Put(db, validatorDiffsTypeID, validatorDiffsKey(subnetID, height), diffs)
+}
+
+// get the changes to the validator set of [subnetID] at [height]. Returns no
+// changes if none were recorded.
+func (vm *VM) getValidatorDiffs(db database.Database, subnetID ids.ID, height uint64) (*validatorDiffs, error) {
+	key := validatorDiffsKey(subnetID, height)
+	exists, err := vm.State.Has(db, validatorDiffsTypeID, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &validatorDiffs{}, nil
+	}
+
+	diffsIntf, err := vm.State.Get(db, validatorDiffsTypeID, key)
+	if err != nil {
+		return nil, err
+	}
+	diffs, ok := diffsIntf.(*validatorDiffs)
+	if !ok {
+		vm.Ctx.Log.Warn("expected to retrieve *validatorDiffs from database but got different type")
+		return nil, errDB
+	}
+	return diffs, nil
+}
@@ -0,0 +1,112 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/gecko/ids"
+	"github.com/ava-labs/gecko/snow/validators"
+	"github.com/ava-labs/gecko/utils/json"
+)
+
+// commitNextProposal builds the next proposal block and accepts it along with
+// its commit option
+func commitNextProposal(t *testing.T, vm *VM) {
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := blk.(*ProposalBlock)
+	if err := block.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	block.Accept()
+
+	commit, ok := block.Options()[0].(*Commit)
+	if !ok {
+		t.Fatal(errShouldPrefCommit)
+	}
+	if err := commit.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	commit.Accept()
+	vm.SetPreference(commit.ID())
+}
+
+func TestGetValidatorSetAt(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	// Fast forward clock to time for genesis validators to leave
+	vm.clock.Set(defaultValidateEndTime)
+	commitNextProposal(t, vm) // advance the timestamp; heights 1 and 2
+	commitNextProposal(t, vm) // reward a genesis validator; heights 3 and 4
+
+	service := Service{vm: vm}
+	reply := GetValidatorsAtReply{}
+	if err := service.GetValidatorsAt(nil, &GetValidatorsAtArgs{Height: 4}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if len(reply.Validators) != len(keys)-1 {
+		t.Fatalf("expected %d validators at height 4 but got %d", len(keys)-1, len(reply.Validators))
+	}
+
+	for _, height := range []json.Uint64{0, 2, 3} {
+		if err := service.GetValidatorsAt(nil, &GetValidatorsAtArgs{Height: height}, &reply); err != nil {
+			t.Fatal(err)
+		}
+		if len(reply.Validators) != len(keys) {
+			t.Fatalf("expected %d validators at height %d but got %d", len(keys), height, len(reply.Validators))
+		}
+		for _, vdr := range reply.Validators {
+			if uint64(vdr.Weight) != defaultStakeAmount {
+				t.Fatalf("expected weight %d at height %d but got %d", defaultStakeAmount, height, vdr.Weight)
+			}
+		}
+	}
+
+	if err := service.GetValidatorsAt(nil, &GetValidatorsAtArgs{Height: 5}, &reply); err == nil {
+		t.Fatal("should have failed because no block has been accepted at height 5")
+	}
+}
+
+func TestRecordValidatorDiffsAtSameHeight(t *testing.T) {
+	vm := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		vm.Shutdown()
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	subnetID := ids.NewID([32]byte{1})
+	nodeID0 := ids.NewShortID([20]byte{1})
+	nodeID1 := ids.NewShortID([20]byte{2})
+	empty := []validators.Validator{}
+	first := []validators.Validator{&Validator{NodeID: nodeID0, Wght: 1}}
+	second := []validators.Validator{&Validator{NodeID: nodeID1, Wght: 2}}
+
+	// Both changes are made at the genesis height
+	if err := vm.recordValidatorDiffs(subnetID, empty, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.recordValidatorDiffs(subnetID, first, second); err != nil {
+		t.Fatal(err)
+	}
+
+	diffs, err := vm.getValidatorDiffs(vm.DB, subnetID, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs.Diffs) != 1 {
+		t.Fatalf("expected 1 diff but got %d", len(diffs.Diffs))
+	}
+	if diff := diffs.Diffs[0]; !diff.NodeID.Equals(nodeID1) || diff.Before != 0 || diff.After != 2 {
+		t.Fatalf("unexpected diff %+v", diff)
+	}
+}
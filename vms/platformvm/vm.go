@@ -13,13 +13,17 @@ import (
 
 	"github.com/ava-labs/gecko/chains"
 	"github.com/ava-labs/gecko/database"
+	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/database/versiondb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
 	"github.com/ava-labs/gecko/snow/consensus/snowman"
 	"github.com/ava-labs/gecko/snow/engine/common"
+	"github.com/ava-labs/gecko/snow/uptime"
 	"github.com/ava-labs/gecko/snow/validators"
 	"github.com/ava-labs/gecko/utils/crypto"
+	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/utils/logging"
 	"github.com/ava-labs/gecko/utils/math"
 	"github.com/ava-labs/gecko/utils/timer"
@@ -38,6 +42,11 @@ const (
 	chainsTypeID
 	blockTypeID
 	subnetsTypeID
+	subnetControlTypeID
+	uptimeTypeID
+	amountTypeID
+	validatorDiffsTypeID
+	txStatusTypeID
 
 	// Delta is the synchrony bound used for safe decision making
 	Delta = 10 * time.Second
@@ -49,10 +58,16 @@ const (
 	// rewarded
 	NumberOfShares = 1000000
 
+	// MaximumDelegationFactor is the most stake that can be delegated to a
+	// validator at any one time, as a multiple of the validator's own stake
+	MaximumDelegationFactor = 4
+
 	// TODO: Turn these constants into governable parameters
 
-	// InflationRate is the maximum inflation rate of AVA from staking
-	InflationRate = 1.04
+	// InflationRateNumerator and InflationRateDenominator define the maximum
+	// inflation rate of AVA from staking, which is 1.04
+	InflationRateNumerator   = 104
+	InflationRateDenominator = 100
 
 	// MinimumStakeAmount is the minimum amount of $AVA one must bond to be a staker
 	MinimumStakeAmount = 10 * units.MicroAva
@@ -78,6 +93,7 @@ var (
 	pendingValidatorsKey = ids.NewID([32]byte{'p', 'e', 'n', 'd', 'i', 'n', 'g'})
 	chainsKey            = ids.NewID([32]byte{'c', 'h', 'a', 'i', 'n', 's'})
 	subnetsKey           = ids.NewID([32]byte{'s', 'u', 'b', 'n', 'e', 't', 's'})
+	currentSupplyKey     = ids.NewID([32]byte{'s', 'u', 'p', 'p', 'l', 'y'})
 )
 
 var (
@@ -94,15 +110,24 @@ var (
 	errDBPutAccount             = errors.New("couldn't put account in database")
 	errDBChains                 = errors.New("couldn't retrieve chain list from database")
 	errDBPutChains              = errors.New("couldn't put chain list in database")
+	errDBPutSubnetControl       = errors.New("couldn't put subnet control keys in database")
+	errDBPutUptime              = errors.New("couldn't put validator uptime in database")
+	errDBCurrentSupply          = errors.New("couldn't retrieve current supply from database")
+	errDBPutCurrentSupply       = errors.New("couldn't put current supply in database")
+	errDBPutStake               = errors.New("couldn't put total stake in database")
 	errDBPutBlock               = errors.New("couldn't put block in database")
 	errRegisteringType          = errors.New("error registering type with database")
 	errMissingBlock             = errors.New("missing block")
 	errInvalidLastAcceptedBlock = errors.New("last accepted block must be a decision block")
+	errWrongHRP                 = errors.New("address belongs to a different network")
 )
 
 // Codec does serialization and deserialization
 var Codec codec.Codec
 
+// schema is the versioned layout of the VM's database
+var schema = migration.NewSchema("platformvm")
+
 func init() {
 	Codec = codec.NewDefault()
 
@@ -143,6 +168,12 @@ func init() {
 
 		Codec.RegisterType(&advanceTimeTx{}),
 		Codec.RegisterType(&rewardValidatorTx{}),
+
+		Codec.RegisterType(&UnsignedUpdateSubnetControlKeysTx{}),
+		Codec.RegisterType(&UpdateSubnetControlKeysTx{}),
+
+		Codec.RegisterType(&UnsignedRemoveNonDefaultSubnetValidatorTx{}),
+		Codec.RegisterType(&removeNonDefaultSubnetValidatorTx{}),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
@@ -169,6 +200,22 @@ type VM struct {
 	// AVM is the ID of the ava virtual machine
 	avm ids.ID
 
+	// Human readable part of bech32 addresses on this network
+	hrp string
+
+	// If true, addresses are formatted in bech32 rather than CB58
+	bech32Addresses bool
+
+	// Records when this node was connected to each validator
+	uptimes *uptime.Tracker
+
+	// Persists the uptimes recorded by [uptimes]. Not part of the chain's state.
+	uptimeDB database.Database
+
+	// Fraction of its staking period a validator must have been connected to
+	// this node for this node to initially prefer rewarding it
+	uptimeRequirement float64
+
 	fx    secp256k1fx.Fx
 	codec codec.Codec
 
@@ -187,6 +234,16 @@ type VM struct {
 	unissuedDecisionTxs []DecisionTx
 	unissuedAtomicTxs   []AtomicTx
 
+	// IDs of the transactions that have not been put into blocks yet
+	pendingTxIDs ids.Set
+
+	// Persists the transactions that have not been put into blocks yet. Not
+	// part of the chain's state.
+	mempoolDB database.Database
+
+	// Sends issued transactions to the other validators
+	gossiper common.Gossiper
+
 	// This timer goes off when it is time for the next validator to add/leave the validator set
 	// When it goes off resetTimer() is called, triggering creation of a new block
 	timer *timer.Timer
@@ -240,6 +297,16 @@ func (vm *VM) Initialize(
 			}
 		}
 
+		// The supply at genesis is the $AVA held by the genesis accounts plus
+		// the $AVA staked by the genesis validators
+		supply, err := genesisSupply(genesis)
+		if err != nil {
+			return err
+		}
+		if err := vm.putCurrentSupply(vm.DB, supply); err != nil {
+			return err
+		}
+
 		// Persist default subnet validator set at genesis
 		if err := vm.putCurrentValidators(vm.DB, genesis.Validators, DefaultSubnetID); err != nil {
 			return errDBPutCurrentValidators
@@ -294,9 +361,25 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	// This node is always connected to itself
+	if vm.uptimes == nil {
+		vm.uptimes = uptime.NewTracker()
+	}
+	vm.uptimes.Connected(vm.Ctx.NodeID)
+	if vm.uptimeDB == nil {
+		vm.uptimeDB = memdb.New()
+	}
+
 	// Transactions from clients that have not yet been put into blocks
 	// and added to consensus
 	vm.unissuedEvents = &EventHeap{SortByStartTime: true}
+	if vm.mempoolDB == nil {
+		vm.mempoolDB = memdb.New()
+	}
+	if err := vm.loadMempool(); err != nil {
+		ctx.Log.Error("failed to load the mempool: %s", err)
+		return err
+	}
 
 	vm.currentBlocks = make(map[[32]byte]Block)
 	vm.timer = timer.NewTimer(func() {
@@ -335,6 +418,28 @@ func (vm *VM) Initialize(
 		return errInvalidLastAcceptedBlock
 	}
 
+	// Index the blocks that were accepted before blocks were indexed by height
+	if err := vm.IndexHeights(); err != nil {
+		vm.Ctx.Log.Error("failed to index accepted blocks by height: %s", err)
+		return err
+	}
+
+	if err := vm.initValidatorDiffs(); err != nil {
+		vm.Ctx.Log.Error("failed to initialize validator set history: %s", err)
+		return err
+	}
+
+	// Record the supply in databases initialized before it was tracked
+	if err := vm.initCurrentSupply(genesisBytes); err != nil {
+		vm.Ctx.Log.Error("failed to initialize the current supply: %s", err)
+		return err
+	}
+
+	// Schedule the txs that were reloaded into the mempool
+	if vm.pendingTxIDs.Len() > 0 {
+		vm.resetTimer()
+	}
+
 	return nil
 }
 
@@ -361,12 +466,12 @@ func (vm *VM) initSubnets() error {
 		return err
 	}
 
-	if err := vm.updateValidators(DefaultSubnetID); err != nil {
+	if _, _, err := vm.setValidators(DefaultSubnetID); err != nil {
 		return err
 	}
 
 	for _, subnet := range subnets {
-		if err := vm.updateValidators(subnet.id); err != nil {
+		if _, _, err := vm.setValidators(subnet.id); err != nil {
 			return err
 		}
 	}
@@ -411,6 +516,10 @@ func (vm *VM) Shutdown() {
 	vm.timer.Stop()
 	vm.Ctx.Lock.Lock()
 
+	if err := vm.flushUptimes(); err != nil {
+		vm.Ctx.Log.Error("Persisting validator uptimes failed with %s", err)
+	}
+
 	if err := vm.DB.Close(); err != nil {
 		vm.Ctx.Log.Error("Closing the database failed with %s", err)
 	}
@@ -421,19 +530,41 @@ func (vm *VM) BuildBlock() (snowman.Block, error) {
 	vm.Ctx.Log.Debug("in BuildBlock")
 	preferredID := vm.Preferred()
 
-	// If there are pending decision txs, build a block with a batch of them
-	if len(vm.unissuedDecisionTxs) > 0 {
+	// Persist the statuses of the txs that are dropped
+	defer func() {
+		if err := vm.DB.Commit(); err != nil {
+			vm.Ctx.Log.Error("failed to persist the statuses of dropped txs: %s", err)
+		}
+	}()
+
+	// If there are pending decision txs, build a block with a batch of them.
+	// Each tx is verified on its own, so that an invalid tx doesn't cause the
+	// valid txs in its batch to be dropped.
+	for len(vm.unissuedDecisionTxs) > 0 {
 		numTxs := BatchSize
 		if numTxs > len(vm.unissuedDecisionTxs) {
 			numTxs = len(vm.unissuedDecisionTxs)
 		}
-		var txs []DecisionTx
-		txs, vm.unissuedDecisionTxs = vm.unissuedDecisionTxs[:numTxs], vm.unissuedDecisionTxs[numTxs:]
+		var batch []DecisionTx
+		batch, vm.unissuedDecisionTxs = vm.unissuedDecisionTxs[:numTxs], vm.unissuedDecisionTxs[numTxs:]
+		for _, tx := range batch {
+			vm.removeUnissuedTx(tx.ID())
+		}
+		txs, err := vm.validDecisionTxs(batch)
+		if err != nil {
+			return nil, err
+		}
+		if len(txs) == 0 {
+			continue
+		}
 		blk, err := vm.newStandardBlock(preferredID, txs)
 		if err != nil {
 			return nil, err
 		}
 		if err := blk.Verify(); err != nil {
+			for _, tx := range txs {
+				vm.dropTx(tx.ID(), "the block containing it is invalid")
+			}
 			vm.resetTimer()
 			return nil, err
 		}
@@ -447,11 +578,13 @@ func (vm *VM) BuildBlock() (snowman.Block, error) {
 	if len(vm.unissuedAtomicTxs) > 0 {
 		tx := vm.unissuedAtomicTxs[0]
 		vm.unissuedAtomicTxs = vm.unissuedAtomicTxs[1:]
+		vm.removeUnissuedTx(tx.ID())
 		blk, err := vm.newAtomicBlock(preferredID, tx)
 		if err != nil {
 			return nil, err
 		}
 		if err := blk.Verify(); err != nil {
+			vm.dropTx(tx.ID(), "the block containing it is invalid")
 			vm.resetTimer()
 			return nil, err
 		}
@@ -540,6 +673,7 @@ func (vm *VM) BuildBlock() (snowman.Block, error) {
 	syncTime := localTime.Add(Delta)
 	for vm.unissuedEvents.Len() > 0 {
 		tx := vm.unissuedEvents.Remove()
+		vm.removeUnissuedTx(tx.ID())
 		if !syncTime.After(tx.StartTime()) {
 			blk, err := vm.newProposalBlock(preferredID, tx)
 			if err != nil {
@@ -550,13 +684,39 @@ func (vm *VM) BuildBlock() (snowman.Block, error) {
 			}
 			return blk, vm.DB.Commit()
 		}
-		vm.Ctx.Log.Debug("dropping tx to add validator because start time too late")
+		vm.dropTx(tx.ID(), "its start time is too late")
 	}
 
 	vm.Ctx.Log.Debug("BuildBlock returning error (no blocks)")
 	return nil, errNoPendingBlocks
 }
 
+// validDecisionTxs returns the txs in [txs] that can be put, in order, into a
+// block built on the preferred block. The other txs are dropped.
+func (vm *VM) validDecisionTxs(txs []DecisionTx) ([]DecisionTx, error) {
+	preferredDB, err := vm.preferredState()
+	if err != nil {
+		return nil, err
+	}
+	db := versiondb.New(preferredDB)
+	defer db.Abort()
+
+	validTxs := make([]DecisionTx, 0, len(txs))
+	for _, tx := range txs {
+		txDB := versiondb.New(db)
+		if _, err := tx.SemanticVerify(txDB); err != nil {
+			txDB.Abort()
+			vm.dropTx(tx.ID(), fmt.Sprintf("it's invalid: %s", err))
+			continue
+		}
+		if err := txDB.Commit(); err != nil {
+			return nil, err
+		}
+		validTxs = append(validTxs, tx)
+	}
+	return validTxs, nil
+}
+
 // ParseBlock implements the snowman.ChainVM interface
 func (vm *VM) ParseBlock(bytes []byte) (snowman.Block, error) {
 	blockInterface, err := vm.unmarshalBlockFunc(bytes)
@@ -683,14 +843,24 @@ func (vm *VM) resetTimer() {
 	}
 
 	syncTime := localTime.Add(Delta)
+	numEvents := vm.unissuedEvents.Len()
 	for vm.unissuedEvents.Len() > 0 {
 		if !syncTime.After(vm.unissuedEvents.Peek().StartTime()) {
-			vm.SnowmanVM.NotifyBlockReady() // Should issue a ProposeAddValidator
-			return
+			break
 		}
 		// If the tx doesn't meet the syncrony bound, drop it
-		vm.unissuedEvents.Remove()
-		vm.Ctx.Log.Debug("dropping tx to add validator because its start time has passed")
+		tx := vm.unissuedEvents.Remove()
+		vm.removeUnissuedTx(tx.ID())
+		vm.dropTx(tx.ID(), "its start time has passed")
+	}
+	if vm.unissuedEvents.Len() != numEvents {
+		if err := vm.DB.Commit(); err != nil {
+			vm.Ctx.Log.Error("failed to persist the mempool: %s", err)
+		}
+	}
+	if vm.unissuedEvents.Len() > 0 {
+		vm.SnowmanVM.NotifyBlockReady() // Should issue a ProposeAddValidator
+		return
 	}
 
 	waitTime := nextValidatorSetChangeTime.Sub(localTime)
@@ -803,7 +973,18 @@ func (vm *VM) getValidators(validatorEvents *EventHeap) []validators.Validator {
 }
 
 // update the node's validator manager to contain the current validator set of the given Subnet
+// and record the change at the height of the last accepted block
 func (vm *VM) updateValidators(subnetID ids.ID) error {
+	previous, current, err := vm.setValidators(subnetID)
+	if err != nil {
+		return err
+	}
+	return vm.recordValidatorDiffs(subnetID, previous, current)
+}
+
+// set the node's validator manager to contain the current validator set of the given Subnet.
+// Returns the validators before and after the change.
+func (vm *VM) setValidators(subnetID ids.ID) ([]validators.Validator, []validators.Validator, error) {
 	validatorSet, subnetInitialized := vm.validators.GetValidatorSet(subnetID)
 	if !subnetInitialized { // validator manager doesn't know about this subnet yet
 		validatorSet = validators.NewSet()
@@ -812,17 +993,21 @@ func (vm *VM) updateValidators(subnetID ids.ID) error {
 
 	currentValidators, err := vm.getCurrentValidators(vm.DB, subnetID)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
+	previous := validatorSet.List()
 	validators := vm.getValidators(currentValidators)
 	validatorSet.Set(validators)
-	return nil
+	return previous, validators, nil
 }
 
 // Codec ...
 func (vm *VM) Codec() codec.Codec { return vm.codec }
 
+// Schema returns the versioned layout of this VM's database
+func (vm *VM) Schema() *migration.Schema { return schema }
+
 // Clock ...
 func (vm *VM) Clock() *timer.Clock { return &vm.clock }
 
@@ -853,3 +1038,34 @@ func (vm *VM) GetAtomicUTXOs(addrs ids.Set) ([]*ava.UTXO, error) {
 	}
 	return utxos, nil
 }
+
+// parseAddress parses [addrStr], which may be either bech32, in which case it
+// must use this network's HRP, or CB58
+func (vm *VM) parseAddress(addrStr string) (ids.ShortID, error) {
+	if hrp, b, err := formatting.ParseBech32(addrStr); err == nil {
+		if hrp != vm.hrp {
+			return ids.ShortID{}, errWrongHRP
+		}
+		return ids.ToShortID(b)
+	}
+	return ids.ShortFromString(addrStr)
+}
+
+// formatAddress formats [addr] in bech32 if configured to and CB58 otherwise
+func (vm *VM) formatAddress(addr ids.ShortID) string {
+	if vm.bech32Addresses {
+		if addrStr, err := formatting.FormatBech32(vm.hrp, addr.Bytes()); err == nil {
+			return addrStr
+		}
+	}
+	return addr.String()
+}
+
+// formatAddresses formats each of [addrs] as in formatAddress
+func (vm *VM) formatAddresses(addrs []ids.ShortID) []string {
+	formatted := make([]string, len(addrs))
+	for i, addr := range addrs {
+		formatted[i] = vm.formatAddress(addr)
+	}
+	return formatted
+}
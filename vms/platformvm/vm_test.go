@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/gecko/chains"
 	"github.com/ava-labs/gecko/chains/atomic"
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/database/prefixdb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
@@ -112,7 +113,8 @@ func defaultContext() *snow.Context {
 	return ctx
 }
 
-func defaultVM() *VM {
+// Returns the genesis bytes of the VM returned by defaultVM
+func defaultGenesis() []byte {
 	genesisAccounts := GenesisAccounts()
 	genesisValidators := GenesisCurrentValidators()
 	genesisChains := make([]*CreateChainTx, 0)
@@ -128,6 +130,11 @@ func defaultVM() *VM {
 	if err != nil {
 		panic(err)
 	}
+	return genesisBytes
+}
+
+func defaultVM() *VM {
+	genesisBytes := defaultGenesis()
 
 	vm := &VM{
 		SnowmanVM:    &core.SnowmanVM{},
@@ -855,6 +862,21 @@ func TestCreateChain(t *testing.T) {
 		t.Fatal("should've created new chain but didn't")
 	}
 
+	// Verify the chain can be read without the VM
+	storedChains, err := StoredChains(vm.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	foundNewChain = false
+	for _, chain := range storedChains {
+		if chain.ID().Equals(tx.ID()) {
+			foundNewChain = true
+		}
+	}
+	if !foundNewChain {
+		t.Fatal("should've read the new chain but didn't")
+	}
+
 	// Verify tx fee was deducted
 	account, err := vm.getAccount(vm.DB, tx.PayerAddress)
 	if err != nil {
@@ -865,6 +887,16 @@ func TestCreateChain(t *testing.T) {
 	}
 }
 
+func TestStoredChainsEmpty(t *testing.T) {
+	chains, err := StoredChains(memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chains) != 0 {
+		t.Fatalf("expected no chains but got %d", len(chains))
+	}
+}
+
 // test where we:
 // 1) Create a subnet
 // 2) Add a validator to the subnet's pending validator set
@@ -1514,7 +1546,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 	vmDB := prefixdb.New([]byte("vm"), db)
 	bootstrappingDB := prefixdb.New([]byte("bootstrapping"), db)
 
-	blocked, err := queue.New(bootstrappingDB)
+	blocked, err := queue.New(bootstrappingDB, migration.NewSchema("queue"))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,29 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"github.com/ava-labs/gecko/snow/engine/snowman"
+)
+
+// Errors that the client needs to be able to compare against are sent as error
+// codes, as errors sent over gRPC lose their identity. 0 means no error.
+var (
+	errCodeToError = map[uint32]error{
+		1: snowman.ErrHeightIndexedVMNotImplemented,
+		2: snowman.ErrIndexIncomplete,
+	}
+	errorToErrCode = map[error]uint32{
+		snowman.ErrHeightIndexedVMNotImplemented: 1,
+		snowman.ErrIndexIncomplete:               2,
+	}
+)
+
+// errorToRPCError returns [err] unless it's sent as an error code
+func errorToRPCError(err error) error {
+	if _, ok := errorToErrCode[err]; ok {
+		return nil
+	}
+	return err
+}
@@ -275,6 +275,29 @@ func (vm *VMClient) LastAccepted() ids.ID {
 	return id
 }
 
+// VerifyHeightIndex ...
+func (vm *VMClient) VerifyHeightIndex() error {
+	resp, err := vm.client.VerifyHeightIndex(context.Background(), &vmproto.VerifyHeightIndexRequest{})
+	if err != nil {
+		return err
+	}
+	return errCodeToError[resp.Err]
+}
+
+// GetBlockIDAtHeight ...
+func (vm *VMClient) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	resp, err := vm.client.GetBlockIDAtHeight(context.Background(), &vmproto.GetBlockIDAtHeightRequest{
+		Height: height,
+	})
+	if err != nil {
+		return ids.ID{}, err
+	}
+	if err := errCodeToError[resp.Err]; err != nil {
+		return ids.ID{}, err
+	}
+	return ids.ToID(resp.BlkID)
+}
+
 // BlockClient is an implementation of Block that talks over RPC.
 type BlockClient struct {
 	vm *VMClient
@@ -197,6 +197,30 @@ func (vm *VMServer) LastAccepted(_ context.Context, _ *vmproto.LastAcceptedReque
 	return &vmproto.LastAcceptedResponse{Id: vm.vm.LastAccepted().Bytes()}, nil
 }
 
+// VerifyHeightIndex ...
+func (vm *VMServer) VerifyHeightIndex(_ context.Context, _ *vmproto.VerifyHeightIndexRequest) (*vmproto.VerifyHeightIndexResponse, error) {
+	var err error
+	if hVM, ok := vm.vm.(snowman.HeightIndexedChainVM); ok {
+		err = hVM.VerifyHeightIndex()
+	} else {
+		err = snowman.ErrHeightIndexedVMNotImplemented
+	}
+	return &vmproto.VerifyHeightIndexResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+}
+
+// GetBlockIDAtHeight ...
+func (vm *VMServer) GetBlockIDAtHeight(_ context.Context, req *vmproto.GetBlockIDAtHeightRequest) (*vmproto.GetBlockIDAtHeightResponse, error) {
+	hVM, ok := vm.vm.(snowman.HeightIndexedChainVM)
+	if !ok {
+		return &vmproto.GetBlockIDAtHeightResponse{Err: errorToErrCode[snowman.ErrHeightIndexedVMNotImplemented]}, nil
+	}
+	blkID, err := hVM.GetBlockIDAtHeight(req.Height)
+	if err != nil {
+		return &vmproto.GetBlockIDAtHeightResponse{Err: errorToErrCode[err]}, errorToRPCError(err)
+	}
+	return &vmproto.GetBlockIDAtHeightResponse{BlkID: blkID.Bytes()}, nil
+}
+
 // BlockVerify ...
 func (vm *VMServer) BlockVerify(_ context.Context, req *vmproto.BlockVerifyRequest) (*vmproto.BlockVerifyResponse, error) {
 	id, err := ids.ToID(req.Id)
@@ -921,6 +921,162 @@ func (m *BlockRejectResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_BlockRejectResponse proto.InternalMessageInfo
 
+type VerifyHeightIndexRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyHeightIndexRequest) Reset()         { *m = VerifyHeightIndexRequest{} }
+func (m *VerifyHeightIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyHeightIndexRequest) ProtoMessage()    {}
+func (*VerifyHeightIndexRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{23}
+}
+
+func (m *VerifyHeightIndexRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyHeightIndexRequest.Unmarshal(m, b)
+}
+func (m *VerifyHeightIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyHeightIndexRequest.Marshal(b, m, deterministic)
+}
+func (m *VerifyHeightIndexRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyHeightIndexRequest.Merge(m, src)
+}
+func (m *VerifyHeightIndexRequest) XXX_Size() int {
+	return xxx_messageInfo_VerifyHeightIndexRequest.Size(m)
+}
+func (m *VerifyHeightIndexRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyHeightIndexRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyHeightIndexRequest proto.InternalMessageInfo
+
+type VerifyHeightIndexResponse struct {
+	Err                  uint32   `protobuf:"varint,1,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyHeightIndexResponse) Reset()         { *m = VerifyHeightIndexResponse{} }
+func (m *VerifyHeightIndexResponse) String() string { return proto.CompactTextString(m) }
+func (*VerifyHeightIndexResponse) ProtoMessage()    {}
+func (*VerifyHeightIndexResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{24}
+}
+
+func (m *VerifyHeightIndexResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyHeightIndexResponse.Unmarshal(m, b)
+}
+func (m *VerifyHeightIndexResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyHeightIndexResponse.Marshal(b, m, deterministic)
+}
+func (m *VerifyHeightIndexResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyHeightIndexResponse.Merge(m, src)
+}
+func (m *VerifyHeightIndexResponse) XXX_Size() int {
+	return xxx_messageInfo_VerifyHeightIndexResponse.Size(m)
+}
+func (m *VerifyHeightIndexResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyHeightIndexResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyHeightIndexResponse proto.InternalMessageInfo
+
+func (m *VerifyHeightIndexResponse) GetErr() uint32 {
+	if m != nil {
+		return m.Err
+	}
+	return 0
+}
+
+type GetBlockIDAtHeightRequest struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockIDAtHeightRequest) Reset()         { *m = GetBlockIDAtHeightRequest{} }
+func (m *GetBlockIDAtHeightRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockIDAtHeightRequest) ProtoMessage()    {}
+func (*GetBlockIDAtHeightRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{25}
+}
+
+func (m *GetBlockIDAtHeightRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockIDAtHeightRequest.Unmarshal(m, b)
+}
+func (m *GetBlockIDAtHeightRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockIDAtHeightRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockIDAtHeightRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockIDAtHeightRequest.Merge(m, src)
+}
+func (m *GetBlockIDAtHeightRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockIDAtHeightRequest.Size(m)
+}
+func (m *GetBlockIDAtHeightRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockIDAtHeightRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockIDAtHeightRequest proto.InternalMessageInfo
+
+func (m *GetBlockIDAtHeightRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type GetBlockIDAtHeightResponse struct {
+	BlkID                []byte   `protobuf:"bytes,1,opt,name=blkID,proto3" json:"blkID,omitempty"`
+	Err                  uint32   `protobuf:"varint,2,opt,name=err,proto3" json:"err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockIDAtHeightResponse) Reset()         { *m = GetBlockIDAtHeightResponse{} }
+func (m *GetBlockIDAtHeightResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockIDAtHeightResponse) ProtoMessage()    {}
+func (*GetBlockIDAtHeightResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{26}
+}
+
+func (m *GetBlockIDAtHeightResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockIDAtHeightResponse.Unmarshal(m, b)
+}
+func (m *GetBlockIDAtHeightResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockIDAtHeightResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlockIDAtHeightResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockIDAtHeightResponse.Merge(m, src)
+}
+func (m *GetBlockIDAtHeightResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockIDAtHeightResponse.Size(m)
+}
+func (m *GetBlockIDAtHeightResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockIDAtHeightResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockIDAtHeightResponse proto.InternalMessageInfo
+
+func (m *GetBlockIDAtHeightResponse) GetBlkID() []byte {
+	if m != nil {
+		return m.BlkID
+	}
+	return nil
+}
+
+func (m *GetBlockIDAtHeightResponse) GetErr() uint32 {
+	if m != nil {
+		return m.Err
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*InitializeRequest)(nil), "vmproto.InitializeRequest")
 	proto.RegisterType((*InitializeResponse)(nil), "vmproto.InitializeResponse")
@@ -945,51 +1101,61 @@ func init() {
 	proto.RegisterType((*BlockAcceptResponse)(nil), "vmproto.BlockAcceptResponse")
 	proto.RegisterType((*BlockRejectRequest)(nil), "vmproto.BlockRejectRequest")
 	proto.RegisterType((*BlockRejectResponse)(nil), "vmproto.BlockRejectResponse")
+	proto.RegisterType((*VerifyHeightIndexRequest)(nil), "vmproto.VerifyHeightIndexRequest")
+	proto.RegisterType((*VerifyHeightIndexResponse)(nil), "vmproto.VerifyHeightIndexResponse")
+	proto.RegisterType((*GetBlockIDAtHeightRequest)(nil), "vmproto.GetBlockIDAtHeightRequest")
+	proto.RegisterType((*GetBlockIDAtHeightResponse)(nil), "vmproto.GetBlockIDAtHeightResponse")
 }
 
 func init() { proto.RegisterFile("vm.proto", fileDescriptor_cab246c8c7c5372d) }
 
 var fileDescriptor_cab246c8c7c5372d = []byte{
-	// 617 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x6d, 0x6f, 0xd2, 0x50,
-	0x14, 0x4e, 0x21, 0x32, 0x3c, 0xc0, 0x06, 0x17, 0xd8, 0xb0, 0x6e, 0x13, 0x1b, 0xb3, 0x60, 0x62,
-	0xf8, 0x30, 0x7f, 0xc0, 0x22, 0x8a, 0x6e, 0xf1, 0x6d, 0x96, 0x84, 0x98, 0xe8, 0x97, 0x42, 0x0f,
-	0x5b, 0x95, 0xb5, 0xf5, 0xde, 0x0b, 0x73, 0xfe, 0x23, 0xff, 0xa5, 0xa1, 0xbd, 0x6d, 0xef, 0xbd,
-	0xb4, 0x59, 0xe2, 0xb7, 0x9e, 0x73, 0x9e, 0xf3, 0x9c, 0x97, 0x7b, 0x9e, 0x42, 0x75, 0x7d, 0x33,
-	0x0c, 0x69, 0xc0, 0x03, 0xb2, 0xb3, 0xbe, 0x89, 0x3e, 0xac, 0x5b, 0x68, 0x5d, 0xf8, 0x1e, 0xf7,
-	0x9c, 0xa5, 0xf7, 0x07, 0x6d, 0xfc, 0xb5, 0x42, 0xc6, 0x89, 0x09, 0x55, 0x77, 0x36, 0x41, 0xba,
-	0x46, 0xda, 0x33, 0xfa, 0xc6, 0xa0, 0x61, 0xa7, 0x36, 0xb1, 0xa0, 0x7e, 0x85, 0x3e, 0x32, 0x8f,
-	0x8d, 0xee, 0x38, 0xb2, 0x5e, 0xa9, 0x6f, 0x0c, 0xea, 0xb6, 0xe2, 0xdb, 0x60, 0xd0, 0xbf, 0xf2,
-	0x7c, 0x14, 0x1c, 0xe5, 0x88, 0x43, 0xf1, 0x59, 0x1d, 0x20, 0x72, 0x61, 0x16, 0x06, 0x3e, 0x43,
-	0xab, 0x05, 0x7b, 0x93, 0xeb, 0x15, 0x77, 0x83, 0x5b, 0x5f, 0x34, 0x63, 0x11, 0x68, 0x66, 0x2e,
-	0x01, 0x3b, 0x80, 0xee, 0x6b, 0x8a, 0x0e, 0xc7, 0x73, 0xc7, 0x77, 0x97, 0x48, 0x59, 0x02, 0x7e,
-	0x0b, 0xfb, 0x7a, 0x20, 0x4e, 0x21, 0x2f, 0xa0, 0x7a, 0x2d, 0x7c, 0x3d, 0xa3, 0x5f, 0x1e, 0xd4,
-	0x4e, 0x9b, 0x43, 0xb1, 0x84, 0xa1, 0x00, 0xdb, 0x29, 0xc2, 0xfa, 0x06, 0x3b, 0xc2, 0x49, 0xf6,
-	0xa1, 0x12, 0x52, 0x5c, 0x78, 0xbf, 0xa3, 0x55, 0x3c, 0xb4, 0x85, 0x45, 0xfa, 0x50, 0x5b, 0x06,
-	0xf3, 0x9f, 0x9f, 0x43, 0xee, 0x05, 0x7e, 0xbc, 0x87, 0x86, 0x2d, 0xbb, 0x36, 0x99, 0x4c, 0x5e,
-	0x80, 0xb0, 0xac, 0x36, 0xb4, 0x46, 0x2b, 0x6f, 0xe9, 0x8e, 0x36, 0xe0, 0xa4, 0xf3, 0x29, 0x10,
-	0xd9, 0x29, 0xba, 0xde, 0x85, 0x92, 0xe7, 0x46, 0x85, 0xeb, 0x76, 0xc9, 0x73, 0x37, 0x2f, 0x13,
-	0x3a, 0x14, 0x7d, 0x7e, 0xf1, 0x46, 0x6c, 0x3e, 0xb5, 0x49, 0x07, 0x1e, 0xcc, 0xa2, 0x27, 0x29,
-	0x47, 0x81, 0xd8, 0xb0, 0x9e, 0x43, 0xeb, 0xd2, 0xa1, 0x0c, 0xe5, 0x62, 0x19, 0xd4, 0x90, 0xa1,
-	0x5f, 0x81, 0xc8, 0xd0, 0xff, 0x68, 0x61, 0x33, 0x31, 0x77, 0xf8, 0x8a, 0xa5, 0x13, 0x47, 0x96,
-	0xf5, 0x14, 0xf6, 0xde, 0x21, 0x57, 0x5a, 0xd0, 0x68, 0xad, 0xef, 0xd0, 0xcc, 0x20, 0xa2, 0xb4,
-	0x5c, 0xca, 0x28, 0x9a, 0xb6, 0x24, 0x8d, 0x50, 0xd8, 0xc0, 0x09, 0x74, 0x26, 0xc8, 0x2f, 0x29,
-	0x2e, 0x90, 0xa2, 0x3f, 0xc7, 0xa2, 0x2e, 0x0e, 0xa0, 0xab, 0xe1, 0xc4, 0xc5, 0x75, 0xa1, 0xfd,
-	0xc1, 0x61, 0xfc, 0xd5, 0x7c, 0x8e, 0x21, 0x47, 0x37, 0x79, 0xb5, 0x13, 0xe8, 0xa8, 0xee, 0xfc,
-	0xa5, 0x59, 0xcf, 0x80, 0x44, 0xa3, 0x4d, 0x91, 0x7a, 0x8b, 0xbb, 0xa2, 0xea, 0x5d, 0x68, 0x2b,
-	0x28, 0x51, 0x3b, 0x49, 0x8e, 0xab, 0xdc, 0x97, 0x9c, 0xa0, 0xb4, 0x64, 0x1b, 0x7f, 0xe0, 0xfc,
-	0xde, 0xe4, 0x04, 0x15, 0x27, 0x9f, 0xfe, 0xad, 0x40, 0x69, 0xfa, 0x91, 0x8c, 0x01, 0x32, 0xad,
-	0x12, 0x33, 0xd5, 0xcd, 0xd6, 0x9f, 0xc3, 0x7c, 0x9c, 0x1b, 0x13, 0x4b, 0x39, 0x83, 0x6a, 0xa2,
-	0x64, 0xd2, 0x4b, 0x81, 0x9a, 0xde, 0xcd, 0x47, 0x39, 0x11, 0x41, 0xf0, 0x05, 0x76, 0x55, 0x75,
-	0x93, 0xe3, 0x14, 0x9c, 0xfb, 0x3f, 0x30, 0x9f, 0x14, 0xc6, 0x05, 0xe5, 0x18, 0x20, 0x93, 0x9d,
-	0x34, 0xda, 0x96, 0x40, 0xa5, 0xd1, 0x72, 0x74, 0x3a, 0x06, 0xc8, 0xa4, 0x23, 0xd1, 0x6c, 0x49,
-	0x4f, 0xa2, 0xc9, 0xd1, 0xda, 0x19, 0x54, 0x13, 0x11, 0x48, 0x1b, 0xd2, 0xa4, 0x23, 0x6d, 0x68,
-	0x4b, 0x31, 0x9f, 0xa0, 0xa1, 0xdc, 0x2f, 0x39, 0xca, 0xb6, 0x99, 0x73, 0xff, 0xe6, 0x71, 0x51,
-	0x58, 0xf0, 0xbd, 0x87, 0xba, 0x7c, 0xdf, 0xe4, 0x30, 0xc5, 0xe7, 0xa8, 0xc1, 0x3c, 0x2a, 0x88,
-	0x0a, 0xb2, 0x73, 0xa8, 0x49, 0xe7, 0x4d, 0xa4, 0x85, 0x6e, 0x49, 0xc3, 0x3c, 0xcc, 0x0f, 0x6a,
-	0x4c, 0x71, 0x09, 0x9d, 0x49, 0xd1, 0x89, 0xce, 0xa4, 0xca, 0x23, 0x65, 0x8a, 0x0f, 0x5f, 0x67,
-	0x52, 0x44, 0xa3, 0x33, 0xa9, 0x5a, 0x99, 0x55, 0xa2, 0xd0, 0xcb, 0x7f, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0xbb, 0xac, 0x5b, 0xc8, 0x65, 0x07, 0x00, 0x00,
+	// 718 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xdb, 0x4e, 0xdb, 0x40,
+	0x10, 0x55, 0x92, 0x16, 0xd2, 0x21, 0x40, 0xb2, 0x24, 0x90, 0x2c, 0x81, 0x86, 0x6d, 0x85, 0xa8,
+	0xd4, 0xf2, 0x00, 0x1f, 0x50, 0x41, 0xa1, 0x25, 0xea, 0x8d, 0x1a, 0x09, 0x55, 0xbd, 0x3c, 0x98,
+	0x78, 0x20, 0x2e, 0xc1, 0x49, 0xed, 0x0d, 0x97, 0x7e, 0x6f, 0x3f, 0xa4, 0xb2, 0x3d, 0xb6, 0xd7,
+	0xf6, 0x1a, 0xa4, 0xbe, 0x79, 0xe7, 0x9c, 0x39, 0x73, 0xf1, 0xee, 0x81, 0xea, 0xf5, 0xd5, 0xf6,
+	0xc4, 0x1d, 0xcb, 0x31, 0x9b, 0xbd, 0xbe, 0x0a, 0x3e, 0xc4, 0x0d, 0x34, 0xfa, 0x8e, 0x2d, 0x6d,
+	0x73, 0x64, 0xff, 0x41, 0x03, 0x7f, 0x4f, 0xd1, 0x93, 0x8c, 0x43, 0xd5, 0x3a, 0x3b, 0x41, 0xf7,
+	0x1a, 0xdd, 0x76, 0xa9, 0x57, 0xda, 0x9a, 0x37, 0xe2, 0x33, 0x13, 0x50, 0xbb, 0x40, 0x07, 0x3d,
+	0xdb, 0xdb, 0xbf, 0x93, 0xe8, 0xb5, 0xcb, 0xbd, 0xd2, 0x56, 0xcd, 0x48, 0xc5, 0x7c, 0x0e, 0x3a,
+	0x17, 0xb6, 0x83, 0xa4, 0x51, 0x09, 0x34, 0x52, 0x31, 0xd1, 0x04, 0xa6, 0x16, 0xf6, 0x26, 0x63,
+	0xc7, 0x43, 0xd1, 0x80, 0xc5, 0x93, 0xe1, 0x54, 0x5a, 0xe3, 0x1b, 0x87, 0x9a, 0x11, 0x0c, 0xea,
+	0x49, 0x88, 0x68, 0x2b, 0xd0, 0x7a, 0xe3, 0xa2, 0x29, 0xf1, 0xc8, 0x74, 0xac, 0x11, 0xba, 0x5e,
+	0x44, 0x7e, 0x0b, 0xcb, 0x59, 0x20, 0x4c, 0x61, 0x2f, 0xa1, 0x3a, 0xa4, 0x58, 0xbb, 0xd4, 0xab,
+	0x6c, 0xcd, 0xed, 0xd4, 0xb7, 0x69, 0x09, 0xdb, 0x44, 0x36, 0x62, 0x86, 0xf8, 0x0e, 0xb3, 0x14,
+	0x64, 0xcb, 0x30, 0x33, 0x71, 0xf1, 0xdc, 0xbe, 0x0d, 0x56, 0xf1, 0xc4, 0xa0, 0x13, 0xeb, 0xc1,
+	0xdc, 0x68, 0x3c, 0xb8, 0xfc, 0x3c, 0x91, 0xf6, 0xd8, 0x09, 0xf7, 0x30, 0x6f, 0xa8, 0x21, 0x3f,
+	0xd3, 0x53, 0x17, 0x40, 0x27, 0xb1, 0x04, 0x8d, 0xfd, 0xa9, 0x3d, 0xb2, 0xf6, 0x7d, 0x72, 0xd4,
+	0xf9, 0x29, 0x30, 0x35, 0x48, 0x5d, 0x2f, 0x40, 0xd9, 0xb6, 0x82, 0xc2, 0x35, 0xa3, 0x6c, 0x5b,
+	0xfe, 0x9f, 0x99, 0x98, 0x2e, 0x3a, 0xb2, 0x7f, 0x40, 0x9b, 0x8f, 0xcf, 0xac, 0x09, 0x8f, 0xcf,
+	0x82, 0x5f, 0x52, 0x09, 0x80, 0xf0, 0x20, 0x5e, 0x40, 0xe3, 0xd8, 0x74, 0x3d, 0x54, 0x8b, 0x25,
+	0xd4, 0x92, 0x4a, 0xfd, 0x0a, 0x4c, 0xa5, 0xfe, 0x47, 0x0b, 0xfe, 0xc4, 0xd2, 0x94, 0x53, 0x2f,
+	0x9e, 0x38, 0x38, 0x89, 0x0d, 0x58, 0x7c, 0x87, 0x32, 0xd5, 0x42, 0x46, 0x56, 0xfc, 0x80, 0x7a,
+	0x42, 0xa1, 0xd2, 0x6a, 0xa9, 0x52, 0xd1, 0xb4, 0x65, 0x65, 0x84, 0xc2, 0x06, 0x36, 0xa1, 0x79,
+	0x82, 0xf2, 0xd8, 0xc5, 0x73, 0x74, 0xd1, 0x19, 0x60, 0x51, 0x17, 0x2b, 0xd0, 0xca, 0xf0, 0xe8,
+	0xc6, 0xb5, 0x60, 0xe9, 0x83, 0xe9, 0xc9, 0xbd, 0xc1, 0x00, 0x27, 0x12, 0xad, 0xe8, 0xaf, 0x6d,
+	0x42, 0x33, 0x1d, 0xd6, 0x2f, 0x4d, 0x3c, 0x07, 0x16, 0x8c, 0x76, 0x8a, 0xae, 0x7d, 0x7e, 0x57,
+	0x54, 0xbd, 0x05, 0x4b, 0x29, 0x16, 0xd5, 0x8e, 0x92, 0xc3, 0x2a, 0x0f, 0x25, 0x47, 0xac, 0x4c,
+	0xb2, 0x81, 0xbf, 0x70, 0xf0, 0x60, 0x72, 0xc4, 0xa2, 0x64, 0x0e, 0xed, 0xb0, 0x97, 0x23, 0xb4,
+	0x2f, 0x86, 0xb2, 0xef, 0x58, 0x78, 0x1b, 0x8d, 0xfe, 0x0a, 0x3a, 0x1a, 0x8c, 0xe6, 0xaf, 0x43,
+	0x05, 0xdd, 0xc8, 0x3c, 0xfc, 0x4f, 0xb1, 0x0b, 0x9d, 0xe8, 0xff, 0xf6, 0x0f, 0xf6, 0x64, 0x98,
+	0x14, 0xb5, 0xb3, 0x0c, 0x33, 0xc3, 0x20, 0x10, 0x64, 0x3c, 0x32, 0xe8, 0x24, 0x0e, 0x80, 0xeb,
+	0x92, 0xa8, 0x88, 0x7f, 0x05, 0x46, 0x97, 0xf1, 0xdd, 0x08, 0x0f, 0x51, 0xe9, 0x72, 0x5c, 0x7a,
+	0xe7, 0xef, 0x2c, 0x94, 0x4f, 0x3f, 0xb2, 0x43, 0x80, 0xc4, 0x71, 0x18, 0x8f, 0x5f, 0x7f, 0xce,
+	0xff, 0xf8, 0xaa, 0x16, 0xa3, 0xaa, 0xaf, 0xa1, 0x1a, 0xf9, 0x11, 0x6b, 0xc7, 0xc4, 0x8c, 0x6b,
+	0xf1, 0x8e, 0x06, 0x21, 0x81, 0x2f, 0xb0, 0x90, 0xf6, 0x28, 0xb6, 0x1e, 0x93, 0xb5, 0xae, 0xc6,
+	0x9f, 0x16, 0xe2, 0x24, 0x79, 0x08, 0x90, 0x98, 0x87, 0x32, 0x5a, 0xce, 0x66, 0xf8, 0xaa, 0x16,
+	0x4b, 0x64, 0x12, 0x03, 0x50, 0x64, 0x72, 0x06, 0xc2, 0x57, 0xb5, 0x58, 0xb2, 0xa1, 0xe8, 0xaf,
+	0x29, 0x1b, 0xca, 0x18, 0x00, 0xef, 0x68, 0x10, 0x12, 0xf8, 0x04, 0xf3, 0xa9, 0x57, 0xc8, 0xd6,
+	0x92, 0x6d, 0x6a, 0x5e, 0x31, 0x5f, 0x2f, 0x82, 0x49, 0xef, 0x3d, 0xd4, 0xd4, 0x57, 0xca, 0xba,
+	0x31, 0x5f, 0xf3, 0xa6, 0xf9, 0x5a, 0x01, 0x4a, 0x62, 0xdf, 0xa0, 0x91, 0xbb, 0xf7, 0x6c, 0x23,
+	0xce, 0x29, 0x7a, 0x2f, 0x5c, 0xdc, 0x47, 0x21, 0xed, 0x9f, 0xc0, 0xf2, 0xf7, 0x9d, 0x89, 0xdc,
+	0xa6, 0x72, 0x2f, 0x88, 0x3f, 0xbb, 0x97, 0x43, 0xf2, 0x47, 0x30, 0xa7, 0xf8, 0x0b, 0x53, 0xee,
+	0x42, 0xce, 0x9b, 0x78, 0x57, 0x0f, 0x66, 0x94, 0xc2, 0xed, 0x64, 0x95, 0x52, 0x46, 0xc5, 0xbb,
+	0x7a, 0x30, 0xa3, 0x14, 0x3a, 0x4f, 0x56, 0x29, 0xe5, 0x5a, 0xbc, 0xab, 0x07, 0x43, 0xa5, 0xb3,
+	0x99, 0x00, 0xda, 0xfd, 0x37, 0x00, 0x7d, 0xba, 0xca, 0xd6, 0xe6, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1012,6 +1178,8 @@ type VMClient interface {
 	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
 	SetPreference(ctx context.Context, in *SetPreferenceRequest, opts ...grpc.CallOption) (*SetPreferenceResponse, error)
 	LastAccepted(ctx context.Context, in *LastAcceptedRequest, opts ...grpc.CallOption) (*LastAcceptedResponse, error)
+	VerifyHeightIndex(ctx context.Context, in *VerifyHeightIndexRequest, opts ...grpc.CallOption) (*VerifyHeightIndexResponse, error)
+	GetBlockIDAtHeight(ctx context.Context, in *GetBlockIDAtHeightRequest, opts ...grpc.CallOption) (*GetBlockIDAtHeightResponse, error)
 	BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error)
 	BlockAccept(ctx context.Context, in *BlockAcceptRequest, opts ...grpc.CallOption) (*BlockAcceptResponse, error)
 	BlockReject(ctx context.Context, in *BlockRejectRequest, opts ...grpc.CallOption) (*BlockRejectResponse, error)
@@ -1097,6 +1265,24 @@ func (c *vMClient) LastAccepted(ctx context.Context, in *LastAcceptedRequest, op
 	return out, nil
 }
 
+func (c *vMClient) VerifyHeightIndex(ctx context.Context, in *VerifyHeightIndexRequest, opts ...grpc.CallOption) (*VerifyHeightIndexResponse, error) {
+	out := new(VerifyHeightIndexResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/VerifyHeightIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) GetBlockIDAtHeight(ctx context.Context, in *GetBlockIDAtHeightRequest, opts ...grpc.CallOption) (*GetBlockIDAtHeightResponse, error) {
+	out := new(GetBlockIDAtHeightResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/GetBlockIDAtHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vMClient) BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error) {
 	out := new(BlockVerifyResponse)
 	err := c.cc.Invoke(ctx, "/vmproto.VM/BlockVerify", in, out, opts...)
@@ -1134,6 +1320,8 @@ type VMServer interface {
 	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
 	SetPreference(context.Context, *SetPreferenceRequest) (*SetPreferenceResponse, error)
 	LastAccepted(context.Context, *LastAcceptedRequest) (*LastAcceptedResponse, error)
+	VerifyHeightIndex(context.Context, *VerifyHeightIndexRequest) (*VerifyHeightIndexResponse, error)
+	GetBlockIDAtHeight(context.Context, *GetBlockIDAtHeightRequest) (*GetBlockIDAtHeightResponse, error)
 	BlockVerify(context.Context, *BlockVerifyRequest) (*BlockVerifyResponse, error)
 	BlockAccept(context.Context, *BlockAcceptRequest) (*BlockAcceptResponse, error)
 	BlockReject(context.Context, *BlockRejectRequest) (*BlockRejectResponse, error)
@@ -1167,6 +1355,12 @@ func (*UnimplementedVMServer) SetPreference(ctx context.Context, req *SetPrefere
 func (*UnimplementedVMServer) LastAccepted(ctx context.Context, req *LastAcceptedRequest) (*LastAcceptedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LastAccepted not implemented")
 }
+func (*UnimplementedVMServer) VerifyHeightIndex(ctx context.Context, req *VerifyHeightIndexRequest) (*VerifyHeightIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyHeightIndex not implemented")
+}
+func (*UnimplementedVMServer) GetBlockIDAtHeight(ctx context.Context, req *GetBlockIDAtHeightRequest) (*GetBlockIDAtHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockIDAtHeight not implemented")
+}
 func (*UnimplementedVMServer) BlockVerify(ctx context.Context, req *BlockVerifyRequest) (*BlockVerifyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BlockVerify not implemented")
 }
@@ -1325,6 +1519,42 @@ func _VM_LastAccepted_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VM_VerifyHeightIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyHeightIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).VerifyHeightIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/VerifyHeightIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).VerifyHeightIndex(ctx, req.(*VerifyHeightIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_GetBlockIDAtHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockIDAtHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).GetBlockIDAtHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/GetBlockIDAtHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).GetBlockIDAtHeight(ctx, req.(*GetBlockIDAtHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VM_BlockVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlockVerifyRequest)
 	if err := dec(in); err != nil {
@@ -1415,6 +1645,14 @@ var _VM_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LastAccepted",
 			Handler:    _VM_LastAccepted_Handler,
 		},
+		{
+			MethodName: "VerifyHeightIndex",
+			Handler:    _VM_VerifyHeightIndex_Handler,
+		},
+		{
+			MethodName: "GetBlockIDAtHeight",
+			Handler:    _VM_GetBlockIDAtHeight_Handler,
+		},
 		{
 			MethodName: "BlockVerify",
 			Handler:    _VM_BlockVerify_Handler,
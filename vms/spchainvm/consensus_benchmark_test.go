@@ -8,6 +8,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/database/prefixdb"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
@@ -44,7 +45,7 @@ func ConsensusLeader(numBlocks, numTxsPerBlock int, b *testing.B) {
 		vmDB := prefixdb.New([]byte("vm"), db)
 		bootstrappingDB := prefixdb.New([]byte("bootstrapping"), db)
 
-		blocked, err := queue.New(bootstrappingDB)
+		blocked, err := queue.New(bootstrappingDB, migration.NewSchema("queue"))
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -172,7 +173,7 @@ func ConsensusFollower(numBlocks, numTxsPerBlock int, b *testing.B) {
 		vmDB := prefixdb.New([]byte("vm"), db)
 		bootstrappingDB := prefixdb.New([]byte("bootstrapping"), db)
 
-		blocked, err := queue.New(bootstrappingDB)
+		blocked, err := queue.New(bootstrappingDB, migration.NewSchema("queue"))
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -228,6 +228,14 @@ func (lb *LiveBlock) VerifyState() error {
 		lb.validity = err
 	}
 
+	// Blocks are only indexed by height if their parent is. The parent of a
+	// block accepted before blocks were indexed by height isn't.
+	if parentHeight, err := lb.vm.state.Height(parent.database(), parent.ID()); err == nil {
+		if err := lb.vm.state.SetHeight(lb.db, lb.ID(), parentHeight+1); err != nil {
+			lb.validity = err
+		}
+	}
+
 	// If this block is valid, add it as a child of its parent
 	// and add this block to currentBlocks
 	if lb.validity == nil {
@@ -16,18 +16,21 @@ const (
 	statusID
 	lastAcceptedID
 	dbInitializedID
+	heightID
+	heightIndexID
 )
 
 var (
 	lastAccepted  = ids.Empty.Prefix(lastAcceptedID)
 	dbInitialized = ids.Empty.Prefix(dbInitializedID)
+	heightIndex   = ids.Empty.Prefix(heightIndexID)
 )
 
 // prefixedState wraps a state object. By prefixing the state, there will be no
 // collisions between different types of objects that have the same hash.
 type prefixedState struct {
-	state                  state
-	block, account, status cache.Cacher
+	state                          state
+	block, account, status, height cache.Cacher
 }
 
 // Block attempts to load a block from storage.
@@ -70,6 +73,26 @@ func (s *prefixedState) SetLastAccepted(db database.Database, id ids.ID) error {
 	return s.state.SetAlias(db, lastAccepted, id)
 }
 
+// Height returns the height of the provided block id from storage.
+func (s *prefixedState) Height(db database.Database, id ids.ID) (uint64, error) {
+	return s.state.Height(db, s.uniqueID(id, heightID, s.height))
+}
+
+// BlockIDAtHeight returns the ID of the block accepted at the provided height
+// from storage.
+func (s *prefixedState) BlockIDAtHeight(db database.Database, height uint64) (ids.ID, error) {
+	return s.state.Alias(db, heightIndex.Prefix(height))
+}
+
+// SetHeight saves the provided height of the block, and indexes the block by
+// its height, in storage.
+func (s *prefixedState) SetHeight(db database.Database, id ids.ID, height uint64) error {
+	if err := s.state.SetHeight(db, s.uniqueID(id, heightID, s.height), height); err != nil {
+		return err
+	}
+	return s.state.SetAlias(db, heightIndex.Prefix(height), id)
+}
+
 // DBInitialized returns the status of this database. If the database is
 // uninitialized, the status will be unknown.
 func (s *prefixedState) DBInitialized(db database.Database) (choices.Status, error) {
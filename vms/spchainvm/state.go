@@ -115,3 +115,40 @@ func (s *state) SetAlias(db database.Database, id ids.ID, alias ids.ID) error {
 	}
 	return db.Put(id.Bytes(), alias.Bytes())
 }
+
+// Height returns a block height from storage.
+func (s *state) Height(db database.Database, id ids.ID) (uint64, error) {
+	bytes, err := db.Get(id.Bytes())
+	if err != nil {
+		return 0, err
+	}
+
+	// The key was in the database
+	p := wrappers.Packer{Bytes: bytes}
+	height := p.UnpackLong()
+
+	if p.Offset != len(bytes) {
+		p.Add(errExtraSpace)
+	}
+	if p.Errored() {
+		return 0, p.Err
+	}
+
+	return height, nil
+}
+
+// SetHeight saves a block height in storage.
+func (s *state) SetHeight(db database.Database, id ids.ID, height uint64) error {
+	p := wrappers.Packer{Bytes: make([]byte, 8)}
+
+	p.PackLong(height)
+
+	if p.Offset != len(p.Bytes) {
+		p.Add(errExtraSpace)
+	}
+	if p.Errored() {
+		return p.Err
+	}
+
+	return db.Put(id.Bytes(), p.Bytes)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/gecko/snow/choices"
 	"github.com/ava-labs/gecko/snow/consensus/snowman"
 	"github.com/ava-labs/gecko/snow/engine/common"
+	smeng "github.com/ava-labs/gecko/snow/engine/snowman"
 	"github.com/ava-labs/gecko/utils/crypto"
 	"github.com/ava-labs/gecko/utils/formatting"
 	"github.com/ava-labs/gecko/utils/timer"
@@ -87,6 +88,7 @@ func (vm *VM) Initialize(
 		block:   &cache.LRU{Size: idCacheSize},
 		account: &cache.LRU{Size: idCacheSize},
 		status:  &cache.LRU{Size: idCacheSize},
+		height:  &cache.LRU{Size: idCacheSize},
 	}
 	vm.baseDB = db
 	vm.factory.Cache.Size = sigCache
@@ -113,7 +115,9 @@ func (vm *VM) Initialize(
 	vm.lastAccepted = lastAccepted
 
 	vm.currentBlocks = make(map[[32]byte]*LiveBlock)
-	return nil
+
+	// Index the blocks that were accepted before blocks were indexed by height
+	return vm.indexHeights()
 }
 
 // Shutdown implements the snowman.ChainVM interface
@@ -199,6 +203,22 @@ func (vm *VM) SetPreference(preferred ids.ID) { vm.preferred = preferred }
 // LastAccepted returns the last accepted block ID
 func (vm *VM) LastAccepted() ids.ID { return vm.lastAccepted }
 
+// VerifyHeightIndex implements the snowman.HeightIndexedChainVM interface
+func (vm *VM) VerifyHeightIndex() error {
+	if _, err := vm.state.Height(vm.baseDB, vm.lastAccepted); err != nil {
+		return smeng.ErrIndexIncomplete
+	}
+	return nil
+}
+
+// GetBlockIDAtHeight implements the snowman.HeightIndexedChainVM interface
+func (vm *VM) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	if err := vm.VerifyHeightIndex(); err != nil {
+		return ids.ID{}, err
+	}
+	return vm.state.BlockIDAtHeight(vm.baseDB, height)
+}
+
 // CreateHandlers makes new service objects with references to the vm
 func (vm *VM) CreateHandlers() map[string]*common.HTTPHandler {
 	newServer := rpc.NewServer()
@@ -291,6 +311,7 @@ func (vm *VM) initState(genesisBytes []byte) error {
 	errs.Add(vm.state.SetBlock(vdb, block.ID(), block))
 	errs.Add(vm.state.SetStatus(vdb, block.ID(), choices.Accepted))
 	errs.Add(vm.state.SetLastAccepted(vdb, block.ID()))
+	errs.Add(vm.state.SetHeight(vdb, block.ID(), 0))
 	for _, account := range accounts {
 		errs.Add(vm.state.SetAccount(vdb, account.ID().LongID(), account))
 	}
@@ -303,6 +324,35 @@ func (vm *VM) initState(genesisBytes []byte) error {
 	return vdb.Commit()
 }
 
+// indexHeights indexes the accepted blocks by height, if they aren't already
+func (vm *VM) indexHeights() error {
+	if vm.VerifyHeightIndex() == nil {
+		return nil
+	}
+
+	blkIDs := []ids.ID(nil)
+	for blkID := vm.lastAccepted; ; {
+		blk, err := vm.state.Block(vm.baseDB, blkID)
+		if err == database.ErrNotFound {
+			break // [blkID] is the genesis block's parent
+		}
+		if err != nil {
+			return err
+		}
+		blkIDs = append(blkIDs, blkID)
+		blkID = blk.ParentID()
+	}
+
+	vdb := versiondb.New(vm.baseDB)
+	for i, blkID := range blkIDs {
+		if err := vm.state.SetHeight(vdb, blkID, uint64(len(blkIDs)-1-i)); err != nil {
+			return err
+		}
+	}
+	vm.ctx.Log.Info("Indexed %d accepted blocks by height", len(blkIDs))
+	return vdb.Commit()
+}
+
 func (vm *VM) issueTx(tx *Tx) {
 	vm.ctx.Log.Verbo("Issuing tx:\n%s", formatting.DumpBytes{Bytes: tx.Bytes()})
 
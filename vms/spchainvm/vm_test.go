@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/gecko/database/memdb"
+	"github.com/ava-labs/gecko/database/migration"
 	"github.com/ava-labs/gecko/ids"
 	"github.com/ava-labs/gecko/snow"
 	"github.com/ava-labs/gecko/snow/consensus/snowball"
@@ -64,7 +65,7 @@ func TestPayments(t *testing.T) {
 	bootstrappingDB := memdb.New()
 
 	msgChan := make(chan common.Message, 1)
-	blocker, _ := queue.New(bootstrappingDB)
+	blocker, _ := queue.New(bootstrappingDB, migration.NewSchema("queue"))
 
 	vm := &VM{}
 	defer func() { ctx.Lock.Lock(); vm.Shutdown(); vm.ctx.Lock.Unlock() }()
@@ -144,4 +145,41 @@ func TestPayments(t *testing.T) {
 	} else if account := vm.GetAccount(vm.baseDB, keys[1].PublicKey().Address()); account.Balance() != 20*units.KiloAva+200 {
 		t.Fatalf("Wrong Balance")
 	}
+
+	// The accepted block is indexed at height 1
+	if blkID, err := vm.GetBlockIDAtHeight(1); err != nil {
+		t.Fatal(err)
+	} else if !blkID.Equals(*queriedVtxID) {
+		t.Fatalf("expected block %s at height 1 but got %s", *queriedVtxID, blkID)
+	}
+}
+
+func TestIndexHeights(t *testing.T) {
+	codec := Codec{}
+	genesisData, _ := codec.MarshalGenesis(GenesisAccounts())
+	db := memdb.New()
+
+	vm := &VM{}
+	defer func() { ctx.Lock.Lock(); vm.Shutdown(); vm.ctx.Lock.Unlock() }()
+	if err := vm.Initialize(ctx, db, genesisData, make(chan common.Message, 1), nil); err != nil {
+		t.Fatal(err)
+	}
+	genesisID := vm.LastAccepted()
+
+	// A database from before blocks were indexed by height
+	if err := db.Delete(vm.state.uniqueID(genesisID, heightID, vm.state.height).Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.VerifyHeightIndex(); err != smeng.ErrIndexIncomplete {
+		t.Fatalf("expected the height index to be incomplete but got %v", err)
+	}
+
+	if err := vm.indexHeights(); err != nil {
+		t.Fatal(err)
+	}
+	if blkID, err := vm.GetBlockIDAtHeight(0); err != nil {
+		t.Fatal(err)
+	} else if !blkID.Equals(genesisID) {
+		t.Fatalf("expected block %s at height 0 but got %s", genesisID, blkID)
+	}
 }
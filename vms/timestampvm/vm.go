@@ -88,7 +88,9 @@ func (vm *VM) Initialize(
 			return err
 		}
 	}
-	return nil
+
+	// Index the blocks that were accepted before blocks were indexed by height
+	return vm.IndexHeights()
 }
 
 // CreateHandlers returns a map where:
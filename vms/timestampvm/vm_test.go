@@ -182,6 +182,21 @@ func TestHappyPath(t *testing.T) {
 		t.Fatal("expected IDs to match but they don't")
 	}
 
+	// Check the accepted blocks are indexed by height
+	if err := vm.VerifyHeightIndex(); err != nil {
+		t.Fatal(err)
+	}
+	for height, blkID := range []ids.ID{genesisBlock.ID(), block2.ID(), block3.ID()} {
+		if blkIDAtHeight, err := vm.GetBlockIDAtHeight(uint64(height)); err != nil {
+			t.Fatal(err)
+		} else if !blkIDAtHeight.Equals(blkID) {
+			t.Fatalf("expected block %s at height %d but got %s", blkID, height, blkIDAtHeight)
+		}
+	}
+	if _, err := vm.GetBlockIDAtHeight(3); err == nil {
+		t.Fatal("should have failed because no block was accepted at height 3")
+	}
+
 	ctx.Lock.Unlock()
 }
 